@@ -0,0 +1,71 @@
+package a
+
+import (
+	"runtime"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+type handle uintptr
+
+var proc windows.LazyProc
+
+// call 是普通的 Go 函数，可能保存参数，不受编译器的特殊处理
+func call(args ...uintptr) {}
+
+// asm 没有函数体，由汇编实现
+func asm(a uintptr)
+
+func direct(b []byte) {
+	syscall.Syscall(0, uintptr(unsafe.Pointer(&b[0])), uintptr(len(b)), 0)
+	windows.SyscallN(0, uintptr(unsafe.Pointer(&b[0])))
+	proc.Call(uintptr(handle(uintptr(unsafe.Pointer(&b[0])))))
+	new(windows.Proc).Call(uintptr(unsafe.Pointer(&b[0])))
+	asm(uintptr(unsafe.Pointer(&b[0])))
+}
+
+func goCall(b []byte) {
+	call(uintptr(unsafe.Pointer(&b[0])), uintptr(len(b))) // want `uintptr\(unsafe.Pointer\(&b\[0\]\)\) is kept outside a call expression`
+}
+
+func arithmetic(b []byte) byte {
+	return *(*byte)(unsafe.Pointer(uintptr(unsafe.Pointer(&b[0])) + 1))
+}
+
+func arithmeticArg(b []byte, off uintptr) {
+	syscall.Syscall(0, uintptr(unsafe.Pointer(&b[0]))+off, 0, 0) // want `uintptr\(unsafe.Pointer\(&b\[0\]\)\) is kept outside a call expression`
+}
+
+func stored(b []byte) {
+	p := uintptr(unsafe.Pointer(&b[0])) // want `uintptr\(unsafe.Pointer\(&b\[0\]\)\) is kept outside a call expression`
+	call(p)
+}
+
+func returned(v *int) uintptr {
+	return uintptr(unsafe.Pointer(v)) // want `uintptr\(unsafe.Pointer\(v\)\) is kept outside a call expression`
+}
+
+func field(v *int) {
+	args := struct{ addr uintptr }{
+		addr: uintptr(unsafe.Pointer(v)), // want `uintptr\(unsafe.Pointer\(v\)\) is kept outside a call expression`
+	}
+	call(args.addr)
+}
+
+func pinnedValue(v *int) {
+	var p runtime.Pinner
+	defer p.Unpin()
+	p.Pin(v)
+	addr := uintptr(unsafe.Pointer(v))
+	call(addr)
+}
+
+func pinnedLate(v *int) {
+	var p runtime.Pinner
+	defer p.Unpin()
+	addr := uintptr(unsafe.Pointer(v)) // want `uintptr\(unsafe.Pointer\(v\)\) is kept outside a call expression`
+	p.Pin(v)
+	call(addr)
+}
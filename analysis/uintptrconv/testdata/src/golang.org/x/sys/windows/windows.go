@@ -0,0 +1,12 @@
+// Package windows 是测试用的 golang.org/x/sys/windows 替身，只声明分析器识别的调用
+package windows
+
+type LazyProc struct{}
+
+func (p *LazyProc) Call(a ...uintptr) (r1, r2 uintptr, err error) { return 0, 0, nil }
+
+type Proc struct{}
+
+func (p *Proc) Call(a ...uintptr) (r1, r2 uintptr, err error) { return 0, 0, nil }
+
+func SyscallN(trap uintptr, args ...uintptr) (r1, r2 uintptr, err error) { return 0, 0, nil }
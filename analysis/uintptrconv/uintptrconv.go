@@ -0,0 +1,197 @@
+// Package uintptrconv 定义一个分析器，检查在调用表达式之外保存的 uintptr(unsafe.Pointer(...)) 转换。
+//
+// Go 的 unsafe 规则只允许在 syscall.SyscallN 等系统调用、(*LazyProc).Call、汇编函数的参数中，
+// 或在立即转换回 unsafe.Pointer 的指针运算中将指针转换为 uintptr。一旦转换结果被保存到变量、字段或返回值中，GC 就不再跟踪原对象，
+// 原对象可能在真正使用该地址之前被移动或回收。使用 runtime.Pinner 固定过的对象除外。
+package uintptrconv
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const doc = `report uintptr(unsafe.Pointer(...)) conversions kept outside call expressions
+
+A pointer converted to uintptr is no longer tracked by the garbage collector.
+The conversion is only safe when it appears directly in the argument list of
+syscall.Syscall*, syscall.SyscallN, (*LazyProc).Call, (*Proc).Call or an
+assembly function, in pointer arithmetic converted straight back to
+unsafe.Pointer, or when the object was pinned with runtime.Pinner earlier in
+the same function.`
+
+// Analyzer 检查在调用表达式之外保存的 uintptr(unsafe.Pointer(...)) 转换
+var Analyzer = &analysis.Analyzer{
+	Name:     "uintptrconv",
+	Doc:      doc,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	ins := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	asm := make(map[*types.Func]bool)
+	for _, f := range pass.Files {
+		for _, decl := range f.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && fn.Body == nil {
+				if obj, ok := pass.TypesInfo.Defs[fn.Name].(*types.Func); ok {
+					asm[obj] = true
+				}
+			}
+		}
+	}
+	ins.WithStack([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		call := n.(*ast.CallExpr)
+		operand, ok := pointerToUintptr(pass.TypesInfo, call)
+		if !ok {
+			return true
+		}
+		if usedInCall(pass.TypesInfo, asm, stack) || pinned(pass.TypesInfo, stack, operand, call.Pos()) {
+			return true
+		}
+		pass.Reportf(call.Pos(), "uintptr(unsafe.Pointer(%s)) is kept outside a call expression; "+
+			"pass it directly as a call argument or pin the object with runtime.Pinner", types.ExprString(operand))
+		return true
+	})
+	return nil, nil
+}
+
+// pointerToUintptr 判断 call 是否为 unsafe.Pointer 到 uintptr 的转换，并返回被转换的原始操作数
+func pointerToUintptr(info *types.Info, call *ast.CallExpr) (ast.Expr, bool) {
+	if !isConversionTo(info, call, types.Uintptr) {
+		return nil, false
+	}
+	arg := ast.Unparen(call.Args[0])
+	if tv, ok := info.Types[arg]; !ok || !isBasic(tv.Type, types.UnsafePointer) {
+		return nil, false
+	}
+	if inner, ok := arg.(*ast.CallExpr); ok && isConversionTo(info, inner, types.UnsafePointer) {
+		return ast.Unparen(inner.Args[0]), true
+	}
+	return arg, true
+}
+
+// usedInCall 判断转换结果是否直接作为允许的调用（见 allowedCall）的参数，或参与指针运算后立即转换回 unsafe.Pointer。
+// 作为其他函数的参数时，被调用的函数可能保存该值，不受编译器的特殊处理。
+func usedInCall(info *types.Info, asm map[*types.Func]bool, stack []ast.Node) bool {
+	arith := false
+	child := stack[len(stack)-1]
+	for i := len(stack) - 2; i >= 0; i-- {
+		switch parent := stack[i].(type) {
+		case *ast.ParenExpr:
+		case *ast.BinaryExpr:
+			// 指针运算的结果只能转换回 unsafe.Pointer，不能作为调用参数
+			arith = true
+		case *ast.CallExpr:
+			tv, ok := info.Types[parent.Fun]
+			if ok && tv.IsType() {
+				if isBasic(tv.Type, types.UnsafePointer) {
+					return true
+				}
+				// 其他类型转换（如 windows.Handle(...)）不改变值，继续向上检查
+				break
+			}
+			return !arith && child != parent.Fun && allowedCall(info, asm, parent)
+		default:
+			return false
+		}
+		child = stack[i]
+	}
+	return false
+}
+
+// allowedCall 判断 call 是否为编译器会在调用期间保持参数指向的对象存活的调用：
+// syscall 或 golang.org/x/sys/windows 中的 Syscall*、(*LazyProc).Call、(*Proc).Call，以及没有函数体的汇编函数
+func allowedCall(info *types.Info, asm map[*types.Func]bool, call *ast.CallExpr) bool {
+	fn, ok := typeutil.Callee(info, call).(*types.Func)
+	if !ok {
+		return false
+	}
+	if asm[fn] {
+		return true
+	}
+	if fn.Pkg() == nil {
+		return false
+	}
+	switch fn.Pkg().Path() {
+	case "syscall", "golang.org/x/sys/windows":
+	default:
+		return false
+	}
+	recv := fn.Type().(*types.Signature).Recv()
+	if recv == nil {
+		return strings.HasPrefix(fn.Name(), "Syscall")
+	}
+	ptr, ok := recv.Type().(*types.Pointer)
+	if !ok || fn.Name() != "Call" {
+		return false
+	}
+	named, ok := ptr.Elem().(*types.Named)
+	return ok && (named.Obj().Name() == "LazyProc" || named.Obj().Name() == "Proc")
+}
+
+// pinned 判断所在函数中在 pos 之前是否以相同的表达式调用过 (*runtime.Pinner).Pin
+func pinned(info *types.Info, stack []ast.Node, operand ast.Expr, pos token.Pos) bool {
+	var body *ast.BlockStmt
+	for i := len(stack) - 1; i >= 0 && body == nil; i-- {
+		switch fn := stack[i].(type) {
+		case *ast.FuncDecl:
+			body = fn.Body
+		case *ast.FuncLit:
+			body = fn.Body
+		}
+	}
+	if body == nil {
+		return false
+	}
+	want := types.ExprString(operand)
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || found || call.Pos() >= pos || len(call.Args) != 1 || !isPinnerPin(info, call) {
+			return !found
+		}
+		arg := ast.Unparen(call.Args[0])
+		if inner, ok := arg.(*ast.CallExpr); ok && isConversionTo(info, inner, types.UnsafePointer) {
+			arg = ast.Unparen(inner.Args[0])
+		}
+		found = types.ExprString(arg) == want
+		return !found
+	})
+	return found
+}
+
+func isPinnerPin(info *types.Info, call *ast.CallExpr) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	fn, ok := info.Uses[sel.Sel].(*types.Func)
+	if !ok || fn.Name() != "Pin" || fn.Pkg() == nil || fn.Pkg().Path() != "runtime" {
+		return false
+	}
+	recv := fn.Type().(*types.Signature).Recv()
+	return recv != nil && types.TypeString(recv.Type(), nil) == "*runtime.Pinner"
+}
+
+func isConversionTo(info *types.Info, call *ast.CallExpr, kind types.BasicKind) bool {
+	if len(call.Args) != 1 {
+		return false
+	}
+	tv, ok := info.Types[call.Fun]
+	return ok && tv.IsType() && isBasic(tv.Type, kind)
+}
+
+func isBasic(t types.Type, kind types.BasicKind) bool {
+	b, ok := t.Underlying().(*types.Basic)
+	return ok && b.Kind() == kind
+}
@@ -0,0 +1,12 @@
+package uintptrconv_test
+
+import (
+	"testing"
+
+	"github.com/C1ph3rX13/xwindows/analysis/uintptrconv"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), uintptrconv.Analyzer, "a")
+}
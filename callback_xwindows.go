@@ -0,0 +1,347 @@
+package xwindows
+
+import (
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+/*
+回调注册表
+
+syscall.NewCallback 创建的回调永远不会被释放，且进程内总数上限约为 2000，
+为每次枚举创建新回调的服务最终会崩溃。
+
+这里为每种回调签名只创建一个固定的跳板函数，跳板通过 lParam/context 参数携带的编号
+查找本次调用注册的 Go 闭包并分发。对于不带 lParam 的回调（LOCALE_ENUMPROC、TIMEFMT_ENUMPROC），
+使用固定数量的跳板槽位，调用方在枚举期间独占一个槽位。
+
+闭包中的 panic 会被捕获，回调返回 FALSE 以停止枚举，panic 以 *CallbackPanic 错误返回给调用方。
+*/
+
+// CallbackPanic 记录回调闭包中发生的 panic
+type CallbackPanic struct {
+	Value any    // recover() 返回的值
+	Stack []byte // 发生 panic 时的调用栈
+}
+
+func (p *CallbackPanic) Error() string {
+	return fmt.Sprintf("%v: %v", ErrCallbackPanic, p.Value)
+}
+
+func (p *CallbackPanic) Unwrap() error {
+	return ErrCallbackPanic
+}
+
+// callbackEntry 是一次枚举调用注册的闭包及其状态
+type callbackEntry struct {
+	fn       any
+	stopped  bool           // 闭包返回 false 主动停止了枚举
+	panicked *CallbackPanic // 闭包中发生的 panic
+}
+
+// invoke 调用闭包并捕获其中的 panic，返回值即回调的 BOOL 返回值
+func (e *callbackEntry) invoke(call func() bool) (ret uintptr) {
+	defer func() {
+		if r := recover(); r != nil {
+			e.panicked = &CallbackPanic{Value: r, Stack: debug.Stack()}
+			ret = 0
+		}
+	}()
+	if call() {
+		return 1
+	}
+	e.stopped = true
+	return 0
+}
+
+// result 将 API 返回的错误与闭包状态合并。
+// 闭包主动停止枚举时 API 返回 FALSE，这种情况不视为错误。
+func (e *callbackEntry) result(apiErr error) error {
+	if e.panicked != nil {
+		return e.panicked
+	}
+	if e.stopped {
+		return nil
+	}
+	return apiErr
+}
+
+type callbackRegistry struct {
+	mu      sync.Mutex
+	next    uintptr
+	entries map[uintptr]*callbackEntry
+}
+
+// callbacks 保存通过 lParam/context 分发的闭包
+var callbacks = &callbackRegistry{entries: make(map[uintptr]*callbackEntry)}
+
+// register 注册闭包并返回用作 lParam/context 的编号，编号从 1 开始
+func (r *callbackRegistry) register(fn any) (uintptr, *callbackEntry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.next++
+	for r.next == 0 || r.entries[r.next] != nil {
+		r.next++
+	}
+	e := &callbackEntry{fn: fn}
+	r.entries[r.next] = e
+	return r.next, e
+}
+
+func (r *callbackRegistry) unregister(id uintptr) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.entries, id)
+}
+
+func (r *callbackRegistry) lookup(id uintptr) *callbackEntry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.entries[id]
+}
+
+// callbackSlots 是不带 lParam 的回调可同时进行的枚举数量
+const callbackSlots = 8
+
+// slotPool 为不带 lParam 的单参数回调提供固定数量的跳板
+type slotPool struct {
+	once    sync.Once
+	free    chan int
+	procs   [callbackSlots]uintptr
+	entries [callbackSlots]*callbackEntry
+}
+
+// stringCallbacks 用于 LOCALE_ENUMPROCA/W 和 TIMEFMT_ENUMPROCA，参数为字符串指针
+var stringCallbacks = &slotPool{}
+
+func (s *slotPool) init() {
+	s.free = make(chan int, callbackSlots)
+	for i := range callbackSlots {
+		s.procs[i] = syscall.NewCallback(func(str unsafe.Pointer) uintptr {
+			e := s.entries[i]
+			if e == nil {
+				return 0
+			}
+			return e.invoke(func() bool { return e.fn.(func(unsafe.Pointer) bool)(str) })
+		})
+		s.free <- i
+	}
+}
+
+// acquire 独占一个槽位，槽位全部被占用时阻塞
+func (s *slotPool) acquire(fn func(unsafe.Pointer) bool) (slot int, proc uintptr, e *callbackEntry) {
+	s.once.Do(s.init)
+	slot = <-s.free
+	e = &callbackEntry{fn: fn}
+	s.entries[slot] = e
+	return slot, s.procs[slot], e
+}
+
+func (s *slotPool) release(slot int) {
+	s.entries[slot] = nil
+	s.free <- slot
+}
+
+// 带 lParam/context 的跳板，每种签名只创建一次
+var (
+	// WNDENUMPROC: BOOL (HWND hwnd, LPARAM lParam)
+	wndEnumProc = sync.OnceValue(func() uintptr {
+		return syscall.NewCallback(func(hwnd windows.HWND, lParam uintptr) uintptr {
+			e := callbacks.lookup(lParam)
+			if e == nil {
+				return 0
+			}
+			return e.invoke(func() bool { return e.fn.(func(windows.HWND) bool)(hwnd) })
+		})
+	})
+
+	// LOCALE_ENUMPROCEX: BOOL (LPWSTR lpLocaleString, DWORD dwFlags, LPARAM lParam)
+	localeEnumProcEx = sync.OnceValue(func() uintptr {
+		return syscall.NewCallback(func(name *uint16, flags uintptr, lParam uintptr) uintptr {
+			e := callbacks.lookup(lParam)
+			if e == nil {
+				return 0
+			}
+			return e.invoke(func() bool { return e.fn.(func(string, uint32) bool)(windows.UTF16PtrToString(name), uint32(flags)) })
+		})
+	})
+
+	// TIMEFMT_ENUMPROCEX: BOOL (LPWSTR lpTimeFormatString, LPARAM lParam)
+	timeFmtEnumProcEx = sync.OnceValue(func() uintptr {
+		return syscall.NewCallback(func(format *uint16, lParam uintptr) uintptr {
+			e := callbacks.lookup(lParam)
+			if e == nil {
+				return 0
+			}
+			return e.invoke(func() bool { return e.fn.(func(string) bool)(windows.UTF16PtrToString(format)) })
+		})
+	})
+
+	// DATEFMT_ENUMPROCEXEX: BOOL (LPWSTR lpDateFormatString, CALID CalendarID, LPARAM lParam)
+	dateFmtEnumProcExEx = sync.OnceValue(func() uintptr {
+		return syscall.NewCallback(func(format *uint16, calendar uintptr, lParam uintptr) uintptr {
+			e := callbacks.lookup(lParam)
+			if e == nil {
+				return 0
+			}
+			return e.invoke(func() bool {
+				return e.fn.(func(string, uint32) bool)(windows.UTF16PtrToString(format), uint32(calendar))
+			})
+		})
+	})
+
+	// PENUM_PAGE_FILE_CALLBACKW: BOOL (LPVOID pContext, PENUM_PAGE_FILE_INFORMATION pPageFileInfo, LPCWSTR lpFilename)
+	enumPageFileProc = sync.OnceValue(func() uintptr {
+		return syscall.NewCallback(func(context uintptr, info *ENUM_PAGE_FILE_INFORMATION, filename *uint16) uintptr {
+			e := callbacks.lookup(context)
+			if e == nil {
+				return 0
+			}
+			return e.invoke(func() bool {
+				return e.fn.(func(*ENUM_PAGE_FILE_INFORMATION, string) bool)(info, windows.UTF16PtrToString(filename))
+			})
+		})
+	})
+
+	// PENUMLOADED_MODULES_CALLBACKW64: BOOL (PCWSTR ModuleName, DWORD64 ModuleBase, ULONG ModuleSize, PVOID UserContext)
+	// 32 位系统上 DWORD64 参数占用两个参数槽位
+	loadedModulesProc = sync.OnceValue(func() uintptr {
+		dispatch := func(name *uint16, base uint64, size uintptr, context uintptr) uintptr {
+			e := callbacks.lookup(context)
+			if e == nil {
+				return 0
+			}
+			return e.invoke(func() bool {
+				return e.fn.(func(string, uint64, uint32) bool)(windows.UTF16PtrToString(name), base, uint32(size))
+			})
+		}
+		if unsafe.Sizeof(uintptr(0)) == 8 {
+			return syscall.NewCallback(func(name *uint16, base, size, context uintptr) uintptr {
+				return dispatch(name, uint64(base), size, context)
+			})
+		}
+		return syscall.NewCallback(func(name *uint16, baseLow, baseHigh, size, context uintptr) uintptr {
+			return dispatch(name, uint64(baseHigh)<<32|uint64(baseLow), size, context)
+		})
+	})
+)
+
+// EnumWindowsFunc 枚举屏幕上的所有顶级窗口，fn 返回 false 时停止枚举
+func EnumWindowsFunc(fn func(hwnd windows.HWND) bool) error {
+	id, e := callbacks.register(fn)
+	defer callbacks.unregister(id)
+	_, err := EnumWindows(windows.Handle(wndEnumProc()), id)
+	return e.result(err)
+}
+
+// EnumChildWindowsFunc 枚举指定父窗口的子窗口，fn 返回 false 时停止枚举。
+// EnumChildWindows 的返回值没有意义，只会返回闭包中发生的 panic。
+func EnumChildWindowsFunc(parent windows.HWND, fn func(hwnd windows.HWND) bool) error {
+	id, e := callbacks.register(fn)
+	defer callbacks.unregister(id)
+	_, _, _ = syscall.SyscallN(
+		procEnumChildWindows.Addr(),
+		uintptr(parent),
+		wndEnumProc(),
+		id,
+	)
+	return e.result(nil)
+}
+
+// EnumDesktopWindowsFunc 枚举与指定桌面关联的所有顶级窗口，desktop 为 0 时使用当前桌面
+func EnumDesktopWindowsFunc(desktop windows.Handle, fn func(hwnd windows.HWND) bool) error {
+	id, e := callbacks.register(fn)
+	defer callbacks.unregister(id)
+	_, err := EnumDesktopWindows(desktop, wndEnumProc(), id)
+	return e.result(err)
+}
+
+// EnumThreadWindowsFunc 枚举与线程关联的所有非子窗口，fn 返回 false 时停止枚举。
+// 线程没有窗口时 EnumThreadWindows 同样返回 FALSE，因此只会返回闭包中发生的 panic。
+func EnumThreadWindowsFunc(threadId uint32, fn func(hwnd windows.HWND) bool) error {
+	id, e := callbacks.register(fn)
+	defer callbacks.unregister(id)
+	_, _ = EnumThreadWindows(threadId, wndEnumProc(), id)
+	return e.result(nil)
+}
+
+// EnumSystemLocalesAFunc 枚举区域设置标识符（十六进制 LCID 字符串），fn 返回 false 时停止枚举
+func EnumSystemLocalesAFunc(flags uint32, fn func(locale string) bool) error {
+	slot, proc, e := stringCallbacks.acquire(func(str unsafe.Pointer) bool {
+		return fn(windows.BytePtrToString((*byte)(str)))
+	})
+	defer stringCallbacks.release(slot)
+	_, err := EnumSystemLocalesA(proc, flags)
+	return e.result(err)
+}
+
+// EnumSystemLocalesWFunc 枚举区域设置标识符（十六进制 LCID 字符串），fn 返回 false 时停止枚举
+func EnumSystemLocalesWFunc(flags uint32, fn func(locale string) bool) error {
+	slot, proc, e := stringCallbacks.acquire(func(str unsafe.Pointer) bool {
+		return fn(windows.UTF16PtrToString((*uint16)(str)))
+	})
+	defer stringCallbacks.release(slot)
+	_, err := EnumSystemLocalesW(proc, flags)
+	return e.result(err)
+}
+
+// EnumSystemLocalesExFunc 枚举区域设置名称及其 LOCALE_* 标志，fn 返回 false 时停止枚举
+func EnumSystemLocalesExFunc(flags uint32, fn func(name string, flags uint32) bool) error {
+	id, e := callbacks.register(fn)
+	defer callbacks.unregister(id)
+	_, err := EnumSystemLocalesEx(localeEnumProcEx(), flags, id, 0)
+	return e.result(err)
+}
+
+// EnumTimeFormatsAFunc 枚举指定区域设置的时间格式图片字符串，fn 返回 false 时停止枚举
+func EnumTimeFormatsAFunc(locale uint32, flags uint32, fn func(format string) bool) error {
+	slot, proc, e := stringCallbacks.acquire(func(str unsafe.Pointer) bool {
+		return fn(windows.BytePtrToString((*byte)(str)))
+	})
+	defer stringCallbacks.release(slot)
+	_, err := EnumTimeFormatsA(windows.HWND(proc), uintptr(locale), flags)
+	return e.result(err)
+}
+
+// EnumTimeFormatsExFunc 枚举区域设置 locale 的时间格式图片字符串，fn 返回 false 时停止枚举
+func EnumTimeFormatsExFunc(locale string, flags uint32, fn func(format string) bool) error {
+	name, err := windows.UTF16PtrFromString(locale)
+	if err != nil {
+		return err
+	}
+	id, e := callbacks.register(fn)
+	defer callbacks.unregister(id)
+	return e.result(EnumTimeFormatsEx(timeFmtEnumProcEx(), name, flags, id))
+}
+
+// EnumDateFormatsExExFunc 枚举区域设置 locale 的日期格式图片字符串及其日历，fn 返回 false 时停止枚举
+func EnumDateFormatsExExFunc(locale string, flags uint32, fn func(format string, calendar uint32) bool) error {
+	name, err := windows.UTF16PtrFromString(locale)
+	if err != nil {
+		return err
+	}
+	id, e := callbacks.register(fn)
+	defer callbacks.unregister(id)
+	return e.result(EnumDateFormatsExEx(dateFmtEnumProcExEx(), name, flags, id))
+}
+
+// EnumPageFilesWFunc 为系统中每个已安装的页面文件调用 fn，fn 返回 false 时停止枚举
+func EnumPageFilesWFunc(fn func(info *ENUM_PAGE_FILE_INFORMATION, filename string) bool) error {
+	id, e := callbacks.register(fn)
+	defer callbacks.unregister(id)
+	_, err := EnumPageFilesW(enumPageFileProc(), id)
+	return e.result(err)
+}
+
+// EnumerateLoadedModulesFunc 枚举指定进程的已加载模块，fn 返回 false 时停止枚举
+func EnumerateLoadedModulesFunc(process windows.Handle, fn func(name string, base uint64, size uint32) bool) error {
+	id, e := callbacks.register(fn)
+	defer callbacks.unregister(id)
+	_, err := EnumerateLoadedModulesW64(process, loadedModulesProc(), id)
+	return e.result(err)
+}
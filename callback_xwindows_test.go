@@ -0,0 +1,56 @@
+package xwindows
+
+import (
+	"errors"
+	"testing"
+
+	"golang.org/x/sys/windows"
+)
+
+func TestEnumWindowsFunc(t *testing.T) {
+	tests := []struct {
+		name      string
+		fn        func(hwnd windows.HWND) bool
+		wantErr   error
+		wantCalls int
+	}{
+		{
+			name:      "stop after first window",
+			fn:        func(hwnd windows.HWND) bool { return false },
+			wantCalls: 1,
+		},
+		{
+			name:      "panic is returned as error",
+			fn:        func(hwnd windows.HWND) bool { panic("boom") },
+			wantErr:   ErrCallbackPanic,
+			wantCalls: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			err := EnumWindowsFunc(func(hwnd windows.HWND) bool {
+				calls++
+				return tt.fn(hwnd)
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("EnumWindowsFunc() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("EnumWindowsFunc() calls = %d, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestCallbackRegistryReuse(t *testing.T) {
+	// 远超 syscall.NewCallback 的上限，验证跳板被复用
+	for i := 0; i < 5000; i++ {
+		if err := EnumSystemLocalesExFunc(0, func(name string, flags uint32) bool { return false }); err != nil {
+			t.Fatalf("EnumSystemLocalesExFunc() iteration %d error = %v", i, err)
+		}
+	}
+	if n := len(callbacks.entries); n != 0 {
+		t.Errorf("callbacks.entries = %d, want 0", n)
+	}
+}
@@ -11,6 +11,9 @@ package xwindows
 */
 import "C"
 
+// TimeGetTimeC 通过 CGO 调用 timeGetTime
+//
+// Deprecated: 与 TimeGetTime 重复且需要 CGO，请使用 TimeGetTime。
 func TimeGetTimeC() uint32 {
 	return uint32(C.TimeGetTimeCGO())
 }
@@ -0,0 +1,124 @@
+// Package clock 提供 Windows 单调时钟源的统一接口和可控的假时钟。
+//
+// 各时钟的读数是自任意起点（通常为系统启动）以来经过的时间，只有同一时钟的两次读数之差有意义。
+// 计数换算和 32 位毫秒计数的回绕处理不依赖 Windows API，可以在任何平台上测试。
+package clock
+
+import (
+	"math/bits"
+	"sync"
+	"time"
+)
+
+// Clock 是单调时钟
+type Clock interface {
+	// Now 返回自时钟起点以来经过的时间
+	Now() time.Duration
+	// Since 返回自读数 t 以来经过的时间
+	Since(t time.Duration) time.Duration
+}
+
+// Func 将读数函数转换为 Clock
+type Func func() time.Duration
+
+func (f Func) Now() time.Duration { return f() }
+
+func (f Func) Since(t time.Duration) time.Duration { return f() - t }
+
+// Ticks 是 GetTickCount 或 timeGetTime 返回的 32 位毫秒计数，每 2^32 毫秒（约 49.7 天）回绕。
+// 比较和求差按序列号算术进行，只要两个计数相隔不超过约 24.8 天，结果在回绕前后都正确。
+type Ticks uint32
+
+// Sub 返回 t - u，t 早于 u 时为负值
+func (t Ticks) Sub(u Ticks) time.Duration {
+	return time.Duration(int32(t-u)) * time.Millisecond
+}
+
+// Add 返回 t + d，结果按 32 位回绕，d 截断到毫秒
+func (t Ticks) Add(d time.Duration) Ticks {
+	return t + Ticks(int64(d/time.Millisecond))
+}
+
+// After 报告 t 是否晚于 u
+func (t Ticks) After(u Ticks) bool {
+	return int32(t-u) > 0
+}
+
+// Before 报告 t 是否早于 u
+func (t Ticks) Before(u Ticks) bool {
+	return int32(t-u) < 0
+}
+
+// Extend 将 32 位计数扩展为 64 位毫秒计数，选择与参考值 ref（如上一次扩展的结果或 GetTickCount64 的读数）
+// 最接近的候选值，t 与 ref 相隔不超过约 24.8 天时结果正确。
+func Extend(ref uint64, t Ticks) uint64 {
+	return ref + uint64(int64(t.Sub(Ticks(ref))/time.Millisecond))
+}
+
+// CounterDuration 将频率为 freq（每秒计数）的计数器差值换算为时间，例如 QueryPerformanceCounter 的读数。
+// 中间结果使用 128 位乘法，不会溢出。
+func CounterDuration(count, freq int64) time.Duration {
+	if freq <= 0 {
+		return 0
+	}
+	neg := count < 0
+	if neg {
+		count = -count
+	}
+	hi, lo := bits.Mul64(uint64(count), uint64(time.Second))
+	if hi >= uint64(freq) {
+		// 结果超出 time.Duration 的范围
+		if neg {
+			return -1 << 63
+		}
+		return 1<<63 - 1
+	}
+	q, _ := bits.Div64(hi, lo, uint64(freq))
+	d := time.Duration(min(q, 1<<63-1))
+	if neg {
+		return -d
+	}
+	return d
+}
+
+// InterruptDuration 将以 100 纳秒为单位的中断时间换算为时间
+func InterruptDuration(units uint64) time.Duration {
+	return time.Duration(units * 100)
+}
+
+// Fake 是由测试控制的时钟，只有调用 Advance 或 Set 时才会前进
+type Fake struct {
+	mu  sync.Mutex
+	now time.Duration
+}
+
+// NewFake 创建读数为 start 的假时钟
+func NewFake(start time.Duration) *Fake {
+	return &Fake{now: start}
+}
+
+func (f *Fake) Now() time.Duration {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+func (f *Fake) Since(t time.Duration) time.Duration {
+	return f.Now() - t
+}
+
+// Advance 使时钟前进 d，d 为负时忽略（单调时钟不会后退）
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if d > 0 {
+		f.now += d
+	}
+}
+
+// Set 将读数设置为 t，早于当前读数时忽略
+func (f *Fake) Set(t time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = max(f.now, t)
+}
@@ -0,0 +1,102 @@
+package clock
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestTicks(t *testing.T) {
+	tests := []struct {
+		name string
+		t, u Ticks
+		want time.Duration
+	}{
+		{"plain", 5000, 2000, 3 * time.Second},
+		{"negative", 2000, 5000, -3 * time.Second},
+		{"wrap", 500, math.MaxUint32 - 499, time.Second},
+		{"wrap negative", math.MaxUint32 - 499, 500, -time.Second},
+		{"half range", 1 << 31, 1, (1<<31 - 1) * time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.t.Sub(tt.u); got != tt.want {
+				t.Errorf("Sub() = %v, want %v", got, tt.want)
+			}
+			if got := tt.t.After(tt.u); got != (tt.want > 0) {
+				t.Errorf("After() = %v", got)
+			}
+			if got := tt.t.Before(tt.u); got != (tt.want < 0) {
+				t.Errorf("Before() = %v", got)
+			}
+			if got := tt.u.Add(tt.want); got != tt.t {
+				t.Errorf("Add() = %d, want %d", got, tt.t)
+			}
+		})
+	}
+}
+
+func TestExtend(t *testing.T) {
+	tests := []struct {
+		name string
+		ref  uint64
+		t    Ticks
+		want uint64
+	}{
+		{"same epoch", 1000, 1500, 1500},
+		{"behind ref", 1500, 1000, 1000},
+		{"crossed wrap", 1<<32 - 10, 20, 1<<32 + 20},
+		{"before wrap", 1<<32 + 5, math.MaxUint32 - 4, 1<<32 - 5},
+		{"later epoch", 3<<32 + 100, 50, 3<<32 + 50},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Extend(tt.ref, tt.t); got != tt.want {
+				t.Errorf("Extend(%d, %d) = %d, want %d", tt.ref, tt.t, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCounterDuration(t *testing.T) {
+	tests := []struct {
+		name        string
+		count, freq int64
+		want        time.Duration
+	}{
+		{"10MHz", 10_000_000, 10_000_000, time.Second},
+		{"3.579545MHz", 3_579_545 * 2, 3_579_545, 2 * time.Second},
+		{"fraction", 1, 3, 333333333 * time.Nanosecond},
+		{"negative", -5_000_000, 10_000_000, -500 * time.Millisecond},
+		// count * 1e9 溢出 int64，但结果在范围内
+		{"large", 100 * 365 * 24 * 3600 * 10_000_000, 10_000_000, 100 * 365 * 24 * time.Hour},
+		{"overflow", math.MaxInt64, 1, math.MaxInt64},
+		{"zero freq", 1, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CounterDuration(tt.count, tt.freq); got != tt.want {
+				t.Errorf("CounterDuration(%d, %d) = %v, want %v", tt.count, tt.freq, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFake(t *testing.T) {
+	var c Clock = NewFake(time.Hour)
+	f := c.(*Fake)
+	start := c.Now()
+	f.Advance(1500 * time.Millisecond)
+	f.Advance(-time.Second)
+	if got := c.Since(start); got != 1500*time.Millisecond {
+		t.Fatalf("Since() = %v, want 1.5s", got)
+	}
+	f.Set(0)
+	if got := c.Now(); got != time.Hour+1500*time.Millisecond {
+		t.Fatalf("Now() after Set(0) = %v, clock moved backwards", got)
+	}
+	f.Set(2 * time.Hour)
+	if got := c.Now(); got != 2*time.Hour {
+		t.Fatalf("Now() = %v, want 2h", got)
+	}
+}
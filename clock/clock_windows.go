@@ -0,0 +1,53 @@
+package clock
+
+import (
+	"sync"
+	"time"
+
+	"github.com/C1ph3rX13/xwindows"
+)
+
+// TickCount 返回基于 GetTickCount64 的时钟，分辨率与系统计时器相同（通常为 10 到 16 毫秒），包括睡眠时间
+func TickCount() Clock {
+	return Func(func() time.Duration {
+		return time.Duration(xwindows.GetTickCount64()) * time.Millisecond
+	})
+}
+
+// perfFrequency 在系统启动时确定，只需查询一次
+var perfFrequency = sync.OnceValue(func() int64 {
+	var freq int64
+	xwindows.QueryPerformanceFrequency(&freq)
+	return freq
+})
+
+// Performance 返回基于 QueryPerformanceCounter 的时钟，分辨率小于 1 微秒
+func Performance() Clock {
+	return Func(func() time.Duration {
+		var count int64
+		xwindows.QueryPerformanceCounter(&count)
+		return CounterDuration(count, perfFrequency())
+	})
+}
+
+// UnbiasedInterruptTime 返回基于 QueryUnbiasedInterruptTime 的时钟，不包括睡眠和休眠的时间
+func UnbiasedInterruptTime() Clock {
+	return Func(func() time.Duration {
+		var t uint64
+		xwindows.QueryUnbiasedInterruptTime(&t)
+		return InterruptDuration(t)
+	})
+}
+
+// InterruptTimePrecise 返回基于 QueryInterruptTimePrecise 的时钟，包括睡眠时间，需要 Windows 10 及以上版本
+func InterruptTimePrecise() (Clock, error) {
+	var t uint64
+	if err := xwindows.QueryInterruptTimePrecise(&t); err != nil {
+		return nil, err
+	}
+	return Func(func() time.Duration {
+		var t uint64
+		xwindows.QueryInterruptTimePrecise(&t)
+		return InterruptDuration(t)
+	}), nil
+}
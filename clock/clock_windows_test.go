@@ -0,0 +1,56 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/C1ph3rX13/xwindows"
+)
+
+func TestClocks(t *testing.T) {
+	type source struct {
+		name       string
+		clock      Clock
+		resolution time.Duration
+	}
+	clocks := []source{
+		{"TickCount", TickCount(), 20 * time.Millisecond},
+		{"Performance", Performance(), time.Millisecond},
+		{"UnbiasedInterruptTime", UnbiasedInterruptTime(), 20 * time.Millisecond},
+	}
+	if c, err := InterruptTimePrecise(); err == nil {
+		clocks = append(clocks, source{"InterruptTimePrecise", c, time.Millisecond})
+	}
+	for _, tt := range clocks {
+		t.Run(tt.name, func(t *testing.T) {
+			start := tt.clock.Now()
+			if start <= 0 {
+				t.Fatalf("Now() = %v", start)
+			}
+			time.Sleep(50 * time.Millisecond)
+			got := tt.clock.Since(start)
+			if got < 50*time.Millisecond-tt.resolution || got > 5*time.Second {
+				t.Errorf("Since() after 50ms sleep = %v", got)
+			}
+		})
+	}
+}
+
+// TestTickCount64 检查 GetTickCount64 返回完整的 64 位值：低 32 位与 GetTickCount 一致，
+// 并且与同样包括睡眠时间的中断时间相符（系统运行超过 49.7 天时才能发现截断）
+func TestTickCount64(t *testing.T) {
+	tick, _ := xwindows.GetTickCount()
+	tick64 := xwindows.GetTickCount64()
+	if d := uint32(tick64) - uint32(tick); d > 1000 {
+		t.Errorf("GetTickCount64() = %d, GetTickCount() = %d", tick64, tick)
+	}
+	c, err := InterruptTimePrecise()
+	if err != nil {
+		t.Skip(err)
+	}
+	uptime := c.Now()
+	got := time.Duration(xwindows.GetTickCount64()) * time.Millisecond
+	if diff := got - uptime; diff < -time.Second || diff > time.Second {
+		t.Errorf("GetTickCount64() = %v, interrupt time = %v", got, uptime)
+	}
+}
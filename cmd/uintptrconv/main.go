@@ -0,0 +1,16 @@
+// uintptrconv 检查在调用表达式之外保存的 uintptr(unsafe.Pointer(...)) 转换。
+//
+// 用法:
+//
+//	go run github.com/C1ph3rX13/xwindows/cmd/uintptrconv ./...
+//	go vet -vettool=$(which uintptrconv) ./...
+package main
+
+import (
+	"github.com/C1ph3rX13/xwindows/analysis/uintptrconv"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(uintptrconv.Analyzer)
+}
@@ -0,0 +1,158 @@
+// Package cmdline 按 Windows 的规则编码和解析命令行与环境块。
+//
+// Windows 进程只接收一个命令行字符串，由被启动的程序（通常是 MSVCRT 启动代码或 CommandLineToArgvW）
+// 自行拆分为参数。Quote 和 Join 生成的命令行经 Split（以及 CommandLineToArgvW）拆分后与原参数相同。
+// 本包不依赖 Windows API，可以在任何平台上测试。
+package cmdline
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrProgramQuote = errors.New("cmdline: program name cannot contain a double quote")
+	ErrNUL          = errors.New("cmdline: argument contains NUL")
+)
+
+// Quote 按 MSVCRT/CommandLineToArgvW 规则引用单个参数（argv[0] 之外）。
+// 不含空白和双引号的非空参数原样返回；否则用双引号包围，
+// 双引号前的反斜杠加倍并转义双引号，末尾的反斜杠加倍以免转义结束引号。
+func Quote(arg string) string {
+	if arg == "" {
+		return `""`
+	}
+	if !strings.ContainsAny(arg, " \t\n\v\"") {
+		return arg
+	}
+	var b strings.Builder
+	b.Grow(len(arg) + 2)
+	b.WriteByte('"')
+	slashes := 0
+	for i := 0; i < len(arg); i++ {
+		c := arg[i]
+		switch c {
+		case '\\':
+			slashes++
+		case '"':
+			// 2n 个反斜杠加 \" 解析为 n 个反斜杠和一个双引号
+			b.WriteString(strings.Repeat(`\`, slashes+1))
+			slashes = 0
+		default:
+			slashes = 0
+		}
+		b.WriteByte(c)
+	}
+	b.WriteString(strings.Repeat(`\`, slashes))
+	b.WriteByte('"')
+	return b.String()
+}
+
+// QuoteProgram 引用程序名（argv[0]）。
+// 程序名的解析规则不同：反斜杠没有特殊含义，第一个双引号到下一个双引号之间的内容即为程序名，
+// 因此程序名不能包含双引号。
+func QuoteProgram(name string) (string, error) {
+	if strings.IndexByte(name, '"') >= 0 {
+		return "", ErrProgramQuote
+	}
+	if name == "" || strings.ContainsAny(name, " \t") {
+		return `"` + name + `"`, nil
+	}
+	return name, nil
+}
+
+// Join 将 argv 编码为 CreateProcess 的命令行，argv[0] 为程序名
+func Join(argv []string) (string, error) {
+	var b strings.Builder
+	for i, arg := range argv {
+		if strings.IndexByte(arg, 0) >= 0 {
+			return "", ErrNUL
+		}
+		if i == 0 {
+			name, err := QuoteProgram(arg)
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(name)
+			continue
+		}
+		b.WriteByte(' ')
+		b.WriteString(Quote(arg))
+	}
+	return b.String(), nil
+}
+
+// Split 按 CommandLineToArgvW 的规则将命令行拆分为参数。
+// 在引号内遇到连续两个双引号时产生一个字面双引号并保持在引号内，与 2008 年之后的 MSVCRT 和 Go 的 os 包一致。
+func Split(cmd string) []string {
+	var args []string
+	if cmd == "" {
+		return args
+	}
+
+	// argv[0]：反斜杠不转义，双引号只切换引号状态
+	var name strings.Builder
+	inQuote := false
+	i := 0
+	for ; i < len(cmd); i++ {
+		c := cmd[i]
+		if c == '"' {
+			inQuote = !inQuote
+			continue
+		}
+		if !inQuote && (c == ' ' || c == '\t') {
+			break
+		}
+		name.WriteByte(c)
+	}
+	args = append(args, name.String())
+
+	for {
+		for i < len(cmd) && (cmd[i] == ' ' || cmd[i] == '\t') {
+			i++
+		}
+		if i >= len(cmd) {
+			return args
+		}
+		var arg string
+		arg, i = readArg(cmd, i)
+		args = append(args, arg)
+	}
+}
+
+// readArg 从 cmd[i] 开始读取一个参数，返回参数和下一个参数的起始位置
+func readArg(cmd string, i int) (string, int) {
+	var b strings.Builder
+	inQuote := false
+	slashes := 0
+	for ; i < len(cmd); i++ {
+		c := cmd[i]
+		switch c {
+		case '\\':
+			slashes++
+			continue
+		case '"':
+			b.WriteString(strings.Repeat(`\`, slashes/2))
+			if slashes%2 == 1 {
+				b.WriteByte('"')
+			} else if inQuote && i+1 < len(cmd) && cmd[i+1] == '"' {
+				b.WriteByte('"')
+				i++
+			} else {
+				inQuote = !inQuote
+			}
+			slashes = 0
+			continue
+		case ' ', '\t':
+			if !inQuote {
+				b.WriteString(strings.Repeat(`\`, slashes))
+				return b.String(), i
+			}
+		}
+		b.WriteString(strings.Repeat(`\`, slashes))
+		slashes = 0
+		b.WriteByte(c)
+	}
+	b.WriteString(strings.Repeat(`\`, slashes))
+	return b.String(), i
+}
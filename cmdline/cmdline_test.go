@@ -0,0 +1,170 @@
+package cmdline
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"unicode/utf16"
+)
+
+func TestQuote(t *testing.T) {
+	tests := []struct {
+		name string
+		arg  string
+		want string
+	}{
+		{"plain", `abc`, `abc`},
+		{"empty", ``, `""`},
+		{"space", `a b`, `"a b"`},
+		{"tab", "a\tb", "\"a\tb\""},
+		{"quote", `a"b`, `"a\"b"`},
+		{"backslash not before quote", `a\\b`, `a\\b`},
+		{"backslash before quote", `a\"b`, `"a\\\"b"`},
+		{"trailing backslash", `a b\`, `"a b\\"`},
+		{"trailing backslashes", `a b\\`, `"a b\\\\"`},
+		{"path", `C:\Program Files\`, `"C:\Program Files\\"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Quote(tt.arg); got != tt.want {
+				t.Errorf("Quote(%q) = %q, want %q", tt.arg, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		name string
+		cmd  string
+		want []string
+	}{
+		{"empty", ``, nil},
+		{"program only", `a.exe`, []string{`a.exe`}},
+		{"quoted program", `"C:\Program Files\a.exe" x`, []string{`C:\Program Files\a.exe`, `x`}},
+		{"program backslash quote", `C:\dir\" x`, []string{`C:\dir\ x`}},
+		{"whitespace runs", "a.exe  x \t y ", []string{`a.exe`, `x`, `y`}},
+		{"quoted space", `a.exe "x y" z`, []string{`a.exe`, `x y`, `z`}},
+		{"escaped quote", `a.exe a\"b`, []string{`a.exe`, `a"b`}},
+		{"even backslashes", `a.exe a\\"b c"`, []string{`a.exe`, `a\b c`}},
+		{"odd backslashes", `a.exe a\\\"b`, []string{`a.exe`, `a\"b`}},
+		{"backslashes without quote", `a.exe a\\\b`, []string{`a.exe`, `a\\\b`}},
+		{"doubled quote in quotes", `a.exe "a""b" c`, []string{`a.exe`, `a"b`, `c`}},
+		{"empty argument", `a.exe "" x`, []string{`a.exe`, ``, `x`}},
+		{"unterminated quote", `a.exe "x y`, []string{`a.exe`, `x y`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Split(tt.cmd); !slices.Equal(got, tt.want) {
+				t.Errorf("Split(%q) = %q, want %q", tt.cmd, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJoinRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		argv []string
+	}{
+		{"program only", []string{`a.exe`}},
+		{"empty program", []string{``, `x`}},
+		{"program with spaces", []string{`C:\Program Files\a.exe`, `-v`}},
+		{"empty arguments", []string{`a.exe`, ``, ``}},
+		{"quotes", []string{`a.exe`, `"`, `""`, `a"b"c`}},
+		{"backslashes", []string{`a.exe`, `\`, `\\`, `a\`, `a b\`, `\"`, `\\"\\`}},
+		{"whitespace", []string{`a.exe`, " ", "\t", "a\nb", "a\vb"}},
+		{"unicode", []string{`a.exe`, `中文 参数`, `😀`}},
+		{"mixed", []string{`C:\tools\x.exe`, `/path:C:\Program Files\`, `--name="a b"`, `-x`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := Join(tt.argv)
+			if err != nil {
+				t.Fatalf("Join() error = %v", err)
+			}
+			if got := Split(cmd); !slices.Equal(got, tt.argv) {
+				t.Errorf("Split(Join(%q)) = %q via %q", tt.argv, got, cmd)
+			}
+		})
+	}
+}
+
+func TestJoinErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		argv []string
+		want error
+	}{
+		{"quote in program", []string{`a".exe`}, ErrProgramQuote},
+		{"NUL in program", []string{"a\x00.exe"}, ErrNUL},
+		{"NUL in argument", []string{`a.exe`, "x\x00"}, ErrNUL},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Join(tt.argv); !errors.Is(err, tt.want) {
+				t.Errorf("Join() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnvBlock(t *testing.T) {
+	tests := []struct {
+		name string
+		env  []string
+		want []string
+	}{
+		{"empty", nil, nil},
+		{"sorted case-insensitively", []string{"b=2", "A=1", "c=3"}, []string{"A=1", "b=2", "c=3"}},
+		{"last duplicate wins", []string{"Path=a", "x=1", "PATH=b"}, []string{"PATH=b", "x=1"}},
+		{"drive variable", []string{"=C:=C:\\work", "A=1"}, []string{"=C:=C:\\work", "A=1"}},
+		{"empty value", []string{"A="}, []string{"A="}},
+		{"value with equals", []string{"A=b=c"}, []string{"A=b=c"}},
+		{"unicode", []string{"名称=值😀"}, []string{"名称=值😀"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			block, err := EnvBlock(tt.env)
+			if err != nil {
+				t.Fatalf("EnvBlock() error = %v", err)
+			}
+			if n := len(block); n < 2 || block[n-1] != 0 || block[n-2] != 0 {
+				t.Fatalf("EnvBlock() = %v, want double NUL terminated", block)
+			}
+			if got := ParseEnvBlock(block); !slices.Equal(got, tt.want) {
+				t.Errorf("ParseEnvBlock(EnvBlock(%q)) = %q, want %q", tt.env, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnvBlockEncoding(t *testing.T) {
+	block, err := EnvBlock([]string{"B=2", "A=1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := append(utf16.Encode([]rune("A=1\x00B=2\x00")), 0)
+	if !slices.Equal(block, want) {
+		t.Errorf("EnvBlock() = %v, want %v", block, want)
+	}
+}
+
+func TestEnvBlockErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		env  []string
+	}{
+		{"missing equals", []string{"A"}},
+		{"empty", []string{""}},
+		{"only drive prefix", []string{"=C:"}},
+		{"NUL", []string{"A=1\x00B=2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := EnvBlock(tt.env); !errors.Is(err, ErrEnvEntry) {
+				t.Errorf("EnvBlock(%q) error = %v, want %v", tt.env, err, ErrEnvEntry)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package cmdline
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"unicode/utf16"
+)
+
+var ErrEnvEntry = errors.New(`cmdline: environment entry must be "name=value" without NUL`)
+
+// envName 返回 "name=value" 中的名称。以 = 开头的条目（如 =C:=C:\work）名称从第二个字符开始查找 =
+func envName(entry string) (string, bool) {
+	i := strings.IndexByte(entry[min(1, len(entry)):], '=')
+	if i < 0 {
+		return "", false
+	}
+	return entry[:i+min(1, len(entry))], true
+}
+
+// EnvBlock 将 "name=value" 列表编码为 CREATE_UNICODE_ENVIRONMENT 所需的 UTF-16 环境块。
+// 名称不区分大小写，重复的名称以最后一个为准；条目按名称的大写形式排序，
+// 这是 CreateProcess 文档要求的顺序。块以两个 NUL 结束，空列表编码为两个 NUL。
+func EnvBlock(env []string) ([]uint16, error) {
+	index := make(map[string]int, len(env))
+	var entries []string
+	for _, entry := range env {
+		name, ok := envName(entry)
+		if !ok || strings.IndexByte(entry, 0) >= 0 {
+			return nil, ErrEnvEntry
+		}
+		key := strings.ToUpper(name)
+		if i, ok := index[key]; ok {
+			entries[i] = entry
+			continue
+		}
+		index[key] = len(entries)
+		entries = append(entries, entry)
+	}
+	slices.SortStableFunc(entries, func(a, b string) int {
+		na, _ := envName(a)
+		nb, _ := envName(b)
+		return strings.Compare(strings.ToUpper(na), strings.ToUpper(nb))
+	})
+
+	var block []uint16
+	for _, entry := range entries {
+		block = append(block, utf16.Encode([]rune(entry))...)
+		block = append(block, 0)
+	}
+	if len(block) == 0 {
+		block = append(block, 0)
+	}
+	return append(block, 0), nil
+}
+
+// ParseEnvBlock 解析以两个 NUL 结束的 UTF-16 环境块（如 GetEnvironmentStringsW 的返回值）
+func ParseEnvBlock(block []uint16) []string {
+	var env []string
+	for len(block) > 0 && block[0] != 0 {
+		end := slices.Index(block, 0)
+		if end < 0 {
+			end = len(block)
+		}
+		env = append(env, string(utf16.Decode(block[:end])))
+		block = block[min(end+1, len(block)):]
+	}
+	return env
+}
@@ -0,0 +1,155 @@
+package xwindows
+
+import (
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// ConsoleSize 是控制台缓冲区的列数和行数
+type ConsoleSize struct {
+	Cols int16
+	Rows int16
+}
+
+func (s ConsoleSize) coord() windows.Coord {
+	return windows.Coord{X: s.Cols, Y: s.Rows}
+}
+
+// PseudoConsole 是伪控制台 (ConPTY)。
+// 附加到伪控制台的进程看到的是真实的控制台：Write 写入的数据（包括 VT 输入序列）作为键盘输入，
+// Read 读取的是渲染后的屏幕内容，以 UTF-8 和 VT 序列表示，可以直接交给终端模拟器。
+type PseudoConsole struct {
+	handle    windows.Handle
+	input     *os.File // 伪控制台输入管道的写入端
+	output    *os.File // 伪控制台输出管道的读取端
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+// NewPseudoConsole 创建指定大小的伪控制台，需要 Windows 10 1809 及以上版本
+func NewPseudoConsole(size ConsoleSize) (*PseudoConsole, error) {
+	if size.Cols <= 0 || size.Rows <= 0 {
+		return nil, ErrInvalidSize
+	}
+	var inR, inW, outR, outW windows.Handle
+	if err := CreatePipe(&inR, &inW, nil, 0); err != nil {
+		return nil, err
+	}
+	if err := CreatePipe(&outR, &outW, nil, 0); err != nil {
+		closeHandles([]windows.Handle{inR, inW})
+		return nil, err
+	}
+	var h windows.Handle
+	err := CreatePseudoConsole(size.coord(), inR, outW, 0, &h)
+	// 伪控制台持有这两端的副本
+	closeHandles([]windows.Handle{inR, outW})
+	if err != nil {
+		closeHandles([]windows.Handle{inW, outR})
+		return nil, err
+	}
+	return &PseudoConsole{
+		handle: h,
+		input:  os.NewFile(uintptr(inW), "|conpty-input"),
+		output: os.NewFile(uintptr(outR), "|conpty-output"),
+		done:   make(chan struct{}),
+	}, nil
+}
+
+// Handle 返回伪控制台句柄 (HPCON)
+func (pc *PseudoConsole) Handle() windows.Handle {
+	return pc.handle
+}
+
+// Read 读取伪控制台的输出，Close 之后输出结束时返回 io.EOF
+func (pc *PseudoConsole) Read(b []byte) (int, error) {
+	n, err := pc.output.Read(b)
+	if errors.Is(err, windows.ERROR_BROKEN_PIPE) {
+		err = io.EOF
+	}
+	return n, err
+}
+
+// Write 向伪控制台写入输入，回车使用 "\r"
+func (pc *PseudoConsole) Write(b []byte) (int, error) {
+	return pc.input.Write(b)
+}
+
+// Resize 调整伪控制台的大小，附加的进程会收到 WINDOW_BUFFER_SIZE_EVENT
+func (pc *PseudoConsole) Resize(size ConsoleSize) error {
+	if size.Cols <= 0 || size.Rows <= 0 {
+		return ErrInvalidSize
+	}
+	return ResizePseudoConsole(pc.handle, size.coord())
+}
+
+// follow 将 sizes 中的每个大小应用到伪控制台，直到 sizes 关闭或伪控制台关闭
+func (pc *PseudoConsole) follow(sizes <-chan ConsoleSize) {
+	for {
+		select {
+		case size, ok := <-sizes:
+			if !ok {
+				return
+			}
+			pc.Resize(size)
+		case <-pc.done:
+			return
+		}
+	}
+}
+
+// Close 关闭伪控制台并终止附加的进程，重复调用返回 nil。
+// 在 Windows 11 24H2 之前的版本上，关闭时伪控制台会写出最后一帧，调用 Close 时必须有 goroutine 在读取输出，否则 Close 不会返回。
+func (pc *PseudoConsole) Close() error {
+	var err error
+	pc.closeOnce.Do(func() {
+		close(pc.done)
+		err = pc.input.Close()
+		ClosePseudoConsole(pc.handle)
+		// 等待中的 Read 在伪控制台关闭输出管道后返回 io.EOF，之后才能关闭读取端
+		err = errors.Join(err, pc.output.Close())
+	})
+	return err
+}
+
+// WithPseudoConsole 将进程附加到伪控制台。不要同时设置标准句柄，否则进程的输入输出不经过伪控制台。
+func (s *ProcessSpec) WithPseudoConsole(pc *PseudoConsole) *ProcessSpec {
+	// 属性的值是 HPCON 本身而不是指向它的指针
+	return s.withAttribute(windows.PROC_THREAD_ATTRIBUTE_PSEUDOCONSOLE,
+		*(*unsafe.Pointer)(unsafe.Pointer(&pc.handle)), unsafe.Sizeof(pc.handle))
+}
+
+// StartPseudoConsole 创建伪控制台并启动附加到它的进程。
+// resize 不为 nil 时，从中收到的每个大小都会应用到伪控制台，直到 resize 关闭或伪控制台关闭。
+// 进程退出后伪控制台的输出不会结束，调用方应在 Wait 返回后调用 Close。
+//
+//	pc, p, err := xwindows.StartPseudoConsole(xwindows.NewProcessSpec("cmd.exe"), xwindows.ConsoleSize{Cols: 120, Rows: 30}, resizes)
+//	if err != nil {
+//		return err
+//	}
+//	go io.Copy(pc, os.Stdin)
+//	go func() {
+//		p.Wait()
+//		pc.Close()
+//	}()
+//	io.Copy(os.Stdout, pc)
+func StartPseudoConsole(spec *ProcessSpec, size ConsoleSize, resize <-chan ConsoleSize) (*PseudoConsole, *Process, error) {
+	pc, err := NewPseudoConsole(size)
+	if err != nil {
+		return nil, nil, err
+	}
+	p, err := spec.WithPseudoConsole(pc).Start()
+	if err != nil {
+		// 尚无进程输出，输出管道的缓冲区足以容纳伪控制台的初始化序列，Close 不会阻塞
+		pc.Close()
+		return nil, nil, err
+	}
+	if resize != nil {
+		go pc.follow(resize)
+	}
+	return pc, p, nil
+}
@@ -0,0 +1,79 @@
+package xwindows
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// startConsole 启动附加到伪控制台的进程，返回收集输出的缓冲区和读取结束时关闭的通道
+func startConsole(t *testing.T, spec *ProcessSpec, resize <-chan ConsoleSize) (*PseudoConsole, *Process, *bytes.Buffer, <-chan struct{}) {
+	t.Helper()
+	pc, p, err := StartPseudoConsole(spec, ConsoleSize{Cols: 80, Rows: 25}, resize)
+	if errors.Is(err, ErrNotImplemented) {
+		t.Skip("pseudo console requires Windows 10 1809 or later")
+	}
+	if err != nil {
+		t.Fatalf("StartPseudoConsole() error = %v", err)
+	}
+	t.Cleanup(func() { p.Close() })
+	var out bytes.Buffer
+	done := make(chan struct{})
+	go func() {
+		io.Copy(&out, pc)
+		close(done)
+	}()
+	return pc, p, &out, done
+}
+
+func waitConsole(t *testing.T, pc *PseudoConsole, p *Process, done <-chan struct{}) uint32 {
+	t.Helper()
+	if err := p.Wait(); err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+	if err := pc.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("output not closed after Close")
+	}
+	code, err := p.ExitCode()
+	if err != nil {
+		t.Fatalf("ExitCode() error = %v", err)
+	}
+	return code
+}
+
+func TestPseudoConsoleOutput(t *testing.T) {
+	pc, p, out, done := startConsole(t, NewProcessSpec("cmd.exe", "/c", "echo xwindows-conpty"), nil)
+	if code := waitConsole(t, pc, p, done); code != 0 {
+		t.Errorf("exit code = %d, want 0", code)
+	}
+	if !strings.Contains(out.String(), "xwindows-conpty") {
+		t.Errorf("output = %q, want to contain xwindows-conpty", out.String())
+	}
+}
+
+func TestPseudoConsoleInput(t *testing.T) {
+	resize := make(chan ConsoleSize, 1)
+	defer close(resize)
+	pc, p, _, done := startConsole(t, NewProcessSpec("cmd.exe", "/q", "/k"), resize)
+	resize <- ConsoleSize{Cols: 100, Rows: 30}
+	if err := pc.Resize(ConsoleSize{Cols: 120, Rows: 40}); err != nil {
+		t.Errorf("Resize() error = %v", err)
+	}
+	if err := pc.Resize(ConsoleSize{}); !errors.Is(err, ErrInvalidSize) {
+		t.Errorf("Resize(0x0) error = %v, want %v", err, ErrInvalidSize)
+	}
+	if _, err := io.WriteString(pc, "exit 5\r"); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if code := waitConsole(t, pc, p, done); code != 5 {
+		t.Errorf("exit code = %d, want 5", code)
+	}
+}
@@ -0,0 +1,198 @@
+// Package console 封装 Windows 控制台：虚拟终端 (VT) 模式、屏幕缓冲区信息、输入事件、标题、代码页和控制处理函数。
+//
+// 输入记录的解码不依赖 Windows API，可以在任何平台上测试。
+package console
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"unicode/utf16"
+)
+
+var (
+	ErrNoConsole = errors.New("console: process has no console")
+)
+
+// INPUT_RECORD.EventType
+const (
+	keyEvent              = 0x0001
+	mouseEvent            = 0x0002
+	windowBufferSizeEvent = 0x0004
+	menuEvent             = 0x0008
+	focusEvent            = 0x0010
+)
+
+// Modifiers 是按键和鼠标事件发生时控制键的状态 (dwControlKeyState)
+type Modifiers uint32
+
+const (
+	RightAlt   Modifiers = 0x0001
+	LeftAlt    Modifiers = 0x0002
+	RightCtrl  Modifiers = 0x0004
+	LeftCtrl   Modifiers = 0x0008
+	Shift      Modifiers = 0x0010
+	NumLock    Modifiers = 0x0020
+	ScrollLock Modifiers = 0x0040
+	CapsLock   Modifiers = 0x0080
+	Enhanced   Modifiers = 0x0100 // 扩展键，如方向键和右侧的 Ctrl、Alt
+)
+
+// Ctrl 报告是否按下了任一 Ctrl 键
+func (m Modifiers) Ctrl() bool { return m&(LeftCtrl|RightCtrl) != 0 }
+
+// Alt 报告是否按下了任一 Alt 键
+func (m Modifiers) Alt() bool { return m&(LeftAlt|RightAlt) != 0 }
+
+// Shift 报告是否按下了 Shift 键
+func (m Modifiers) Shift() bool { return m&Shift != 0 }
+
+// Event 是 ReadEvents 发送的输入事件：*KeyEvent、*MouseEvent、*ResizeEvent 或 *FocusEvent
+type Event interface {
+	isEvent()
+}
+
+// KeyEvent 是键盘事件。Rune 为 0 表示按键不产生字符（如方向键、功能键），
+// 辅助平面的字符由两条记录组成，合并为一个事件。
+type KeyEvent struct {
+	Down       bool
+	Repeat     uint16 // 按住按键时的重复次数
+	VirtualKey uint16 // VK_* 虚拟键码
+	ScanCode   uint16
+	Rune       rune
+	Modifiers  Modifiers
+}
+
+// MouseButtons 是鼠标按钮的状态 (dwButtonState 的低 16 位)
+type MouseButtons uint16
+
+const (
+	LeftButton   MouseButtons = 0x0001 // FROM_LEFT_1ST_BUTTON_PRESSED
+	RightButton  MouseButtons = 0x0002 // RIGHTMOST_BUTTON_PRESSED
+	MiddleButton MouseButtons = 0x0004 // FROM_LEFT_2ND_BUTTON_PRESSED
+)
+
+// MouseFlags 是鼠标事件的类型 (dwEventFlags)，0 表示按钮按下或释放
+type MouseFlags uint32
+
+const (
+	MouseMoved   MouseFlags = 0x0001
+	DoubleClick  MouseFlags = 0x0002
+	MouseWheeled MouseFlags = 0x0004
+	MouseHWheel  MouseFlags = 0x0008
+)
+
+// MouseEvent 是鼠标事件，坐标是屏幕缓冲区中的字符单元。
+// 滚轮事件的 Wheel 为正时向前（远离用户）或向右滚动，单位为 WHEEL_DELTA (120)。
+type MouseEvent struct {
+	X, Y      int16
+	Buttons   MouseButtons
+	Wheel     int16
+	Modifiers Modifiers
+	Flags     MouseFlags
+}
+
+// ResizeEvent 是屏幕缓冲区大小改变事件，需要启用 ENABLE_WINDOW_INPUT
+type ResizeEvent struct {
+	Cols, Rows int16
+}
+
+// FocusEvent 是控制台窗口获得或失去焦点的事件
+type FocusEvent struct {
+	Focused bool
+}
+
+func (*KeyEvent) isEvent()    {}
+func (*MouseEvent) isEvent()  {}
+func (*ResizeEvent) isEvent() {}
+func (*FocusEvent) isEvent()  {}
+
+// inputDecoder 将 INPUT_RECORD 解码为 Event，保存尚未配对的高代理项
+type inputDecoder struct {
+	high rune
+}
+
+// decode 解码一条输入记录，data 是 INPUT_RECORD.Event 联合体。
+// 菜单事件、未知事件和代理对的前一半返回 nil。
+func (d *inputDecoder) decode(kind uint16, data []byte) Event {
+	le := binary.LittleEndian
+	switch kind {
+	case keyEvent:
+		e := &KeyEvent{
+			Down:       le.Uint32(data[0:]) != 0,
+			Repeat:     le.Uint16(data[4:]),
+			VirtualKey: le.Uint16(data[6:]),
+			ScanCode:   le.Uint16(data[8:]),
+			Rune:       rune(le.Uint16(data[10:])),
+			Modifiers:  Modifiers(le.Uint32(data[12:])),
+		}
+		switch {
+		case utf16.IsSurrogate(e.Rune) && e.Rune < 0xdc00:
+			d.high = e.Rune
+			return nil
+		case utf16.IsSurrogate(e.Rune):
+			if d.high == 0 {
+				e.Rune = 0xfffd
+			} else {
+				e.Rune = utf16.DecodeRune(d.high, e.Rune)
+			}
+		}
+		d.high = 0
+		return e
+	case mouseEvent:
+		state := le.Uint32(data[4:])
+		e := &MouseEvent{
+			X:         int16(le.Uint16(data[0:])),
+			Y:         int16(le.Uint16(data[2:])),
+			Buttons:   MouseButtons(state),
+			Modifiers: Modifiers(le.Uint32(data[8:])),
+			Flags:     MouseFlags(le.Uint32(data[12:])),
+		}
+		// 滚轮事件的 dwButtonState 高 16 位是有符号的滚动量
+		if e.Flags&(MouseWheeled|MouseHWheel) != 0 {
+			e.Wheel = int16(state >> 16)
+		}
+		return e
+	case windowBufferSizeEvent:
+		return &ResizeEvent{Cols: int16(le.Uint16(data[0:])), Rows: int16(le.Uint16(data[2:]))}
+	case focusEvent:
+		return &FocusEvent{Focused: le.Uint32(data[0:]) != 0}
+	}
+	return nil
+}
+
+// CtrlEvent 是控制处理函数收到的控制信号 (CTRL_*_EVENT)
+type CtrlEvent uint32
+
+const (
+	CtrlC        CtrlEvent = 0
+	CtrlBreak    CtrlEvent = 1
+	CtrlClose    CtrlEvent = 2 // 用户关闭控制台窗口
+	CtrlLogoff   CtrlEvent = 5 // 用户注销，只有服务会收到
+	CtrlShutdown CtrlEvent = 6 // 系统关机，只有服务会收到
+)
+
+func (e CtrlEvent) String() string {
+	switch e {
+	case CtrlC:
+		return "CTRL_C_EVENT"
+	case CtrlBreak:
+		return "CTRL_BREAK_EVENT"
+	case CtrlClose:
+		return "CTRL_CLOSE_EVENT"
+	case CtrlLogoff:
+		return "CTRL_LOGOFF_EVENT"
+	case CtrlShutdown:
+		return "CTRL_SHUTDOWN_EVENT"
+	}
+	return fmt.Sprintf("CtrlEvent(%d)", uint32(e))
+}
+
+// CtrlError 是 NotifyContext 返回的上下文因控制信号取消时的原因 (context.Cause)
+type CtrlError struct {
+	Event CtrlEvent
+}
+
+func (e *CtrlError) Error() string {
+	return "console: received " + e.Event.String()
+}
@@ -0,0 +1,108 @@
+package console
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+// record 按小端序拼接 INPUT_RECORD.Event 的字段
+func record(fields ...any) []byte {
+	data := make([]byte, 0, 16)
+	for _, f := range fields {
+		data, _ = binary.Append(data, binary.LittleEndian, f)
+	}
+	return append(data, make([]byte, 16-len(data))...)
+}
+
+func TestDecodeKey(t *testing.T) {
+	var d inputDecoder
+	e := d.decode(keyEvent, record(int32(1), uint16(2), uint16(0x41), uint16(0x1e), uint16('a'), uint32(LeftCtrl|Shift)))
+	key, ok := e.(*KeyEvent)
+	if !ok {
+		t.Fatalf("decode() = %T, want *KeyEvent", e)
+	}
+	want := KeyEvent{Down: true, Repeat: 2, VirtualKey: 0x41, ScanCode: 0x1e, Rune: 'a', Modifiers: LeftCtrl | Shift}
+	if *key != want {
+		t.Errorf("decode() = %+v, want %+v", *key, want)
+	}
+	if !key.Modifiers.Ctrl() || !key.Modifiers.Shift() || key.Modifiers.Alt() {
+		t.Errorf("Modifiers = %#x: Ctrl/Shift/Alt mismatch", key.Modifiers)
+	}
+}
+
+func TestDecodeSurrogates(t *testing.T) {
+	tests := []struct {
+		name  string
+		units []uint16
+		want  []rune
+	}{
+		{"bmp", []uint16{'x'}, []rune{'x'}},
+		{"pair", []uint16{0xd83d, 0xde00}, []rune{0x1f600}},
+		{"lone low", []uint16{0xde00, 'y'}, []rune{0xfffd, 'y'}},
+		{"high then bmp", []uint16{0xd83d, 'z'}, []rune{'z'}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d inputDecoder
+			var got []rune
+			for _, u := range tt.units {
+				if e := d.decode(keyEvent, record(int32(1), uint16(1), uint16(0), uint16(0), u)); e != nil {
+					got = append(got, e.(*KeyEvent).Rune)
+				}
+			}
+			if string(got) != string(tt.want) {
+				t.Errorf("runes = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeOther(t *testing.T) {
+	tests := []struct {
+		name string
+		kind uint16
+		data []byte
+		want Event
+	}{
+		{"mouse click", mouseEvent, record(int16(3), int16(7), uint32(LeftButton), uint32(0), uint32(0)),
+			&MouseEvent{X: 3, Y: 7, Buttons: LeftButton}},
+		{"mouse wheel", mouseEvent, record(int16(1), int16(2), uint32(0xff880000), uint32(RightAlt), uint32(MouseWheeled)),
+			&MouseEvent{X: 1, Y: 2, Wheel: -120, Modifiers: RightAlt, Flags: MouseWheeled}},
+		{"resize", windowBufferSizeEvent, record(int16(120), int16(30)), &ResizeEvent{Cols: 120, Rows: 30}},
+		{"focus", focusEvent, record(int32(1)), &FocusEvent{Focused: true}},
+		{"menu", menuEvent, record(uint32(1)), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d inputDecoder
+			got := d.decode(tt.kind, tt.data)
+			switch want := tt.want.(type) {
+			case nil:
+				if got != nil {
+					t.Errorf("decode() = %+v, want nil", got)
+				}
+			case *MouseEvent:
+				if g, ok := got.(*MouseEvent); !ok || *g != *want {
+					t.Errorf("decode() = %+v, want %+v", got, want)
+				}
+			case *ResizeEvent:
+				if g, ok := got.(*ResizeEvent); !ok || *g != *want {
+					t.Errorf("decode() = %+v, want %+v", got, want)
+				}
+			case *FocusEvent:
+				if g, ok := got.(*FocusEvent); !ok || *g != *want {
+					t.Errorf("decode() = %+v, want %+v", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestCtrlEventString(t *testing.T) {
+	if got := CtrlClose.String(); got != "CTRL_CLOSE_EVENT" {
+		t.Errorf("CtrlClose.String() = %q", got)
+	}
+	if got := CtrlEvent(9).String(); got != "CtrlEvent(9)" {
+		t.Errorf("CtrlEvent(9).String() = %q", got)
+	}
+}
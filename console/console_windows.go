@@ -0,0 +1,254 @@
+package console
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"unsafe"
+
+	"github.com/C1ph3rX13/xwindows"
+	"golang.org/x/sys/windows"
+)
+
+// Console 是当前进程附加的控制台的输入缓冲区和活动屏幕缓冲区。
+// 即使标准输入输出被重定向，Open 打开的仍然是控制台本身。
+type Console struct {
+	in  windows.Handle // CONIN$
+	out windows.Handle // CONOUT$
+}
+
+// Open 打开当前进程附加的控制台，进程没有控制台时返回 ErrNoConsole
+func Open() (*Console, error) {
+	in, err := openConsoleFile("CONIN$")
+	if err != nil {
+		return nil, err
+	}
+	out, err := openConsoleFile("CONOUT$")
+	if err != nil {
+		xwindows.CloseHandle(in)
+		return nil, err
+	}
+	return &Console{in: in, out: out}, nil
+}
+
+func openConsoleFile(name string) (windows.Handle, error) {
+	p, err := windows.UTF16PtrFromString(name)
+	if err != nil {
+		return 0, err
+	}
+	h, err := windows.CreateFile(p, windows.GENERIC_READ|windows.GENERIC_WRITE,
+		windows.FILE_SHARE_READ|windows.FILE_SHARE_WRITE, nil, windows.OPEN_EXISTING, 0, 0)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrNoConsole, err)
+	}
+	return h, nil
+}
+
+// Input 返回控制台输入缓冲区句柄
+func (c *Console) Input() windows.Handle {
+	return c.in
+}
+
+// Output 返回活动屏幕缓冲区句柄
+func (c *Console) Output() windows.Handle {
+	return c.out
+}
+
+// Close 关闭控制台句柄，不会使进程脱离控制台
+func (c *Console) Close() error {
+	return errors.Join(xwindows.CloseHandle(c.in), xwindows.CloseHandle(c.out))
+}
+
+// updateMode 在 h 的控制台模式中设置 set 并清除 clear，返回恢复原模式的函数
+func updateMode(h windows.Handle, set, clear uint32) (restore func() error, err error) {
+	var old uint32
+	if err := windows.GetConsoleMode(h, &old); err != nil {
+		return nil, err
+	}
+	if err := windows.SetConsoleMode(h, old&^clear|set); err != nil {
+		return nil, err
+	}
+	return func() error { return windows.SetConsoleMode(h, old) }, nil
+}
+
+// EnableVirtualTerminal 启用 VT 序列：输出中的 VT 序列由控制台解释，
+// 键盘输入以 VT 序列的形式出现在 KeyEvent.Rune 和 ReadFile 中。
+// 需要 Windows 10 1511 及以上版本，返回的 restore 恢复原来的模式。
+//
+//	restore, err := c.EnableVirtualTerminal()
+//	if err != nil {
+//		return err
+//	}
+//	defer restore()
+//	fmt.Print("\x1b[1;31merror\x1b[0m")
+func (c *Console) EnableVirtualTerminal() (restore func() error, err error) {
+	return c.setVirtualTerminal(true)
+}
+
+// DisableVirtualTerminal 禁用 VT 序列的处理和 VT 输入，返回的 restore 恢复原来的模式
+func (c *Console) DisableVirtualTerminal() (restore func() error, err error) {
+	return c.setVirtualTerminal(false)
+}
+
+func (c *Console) setVirtualTerminal(enable bool) (func() error, error) {
+	var set, clear uint32 = 0, windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING
+	if enable {
+		set, clear = clear, set
+	}
+	restoreOut, err := updateMode(c.out, set, clear)
+	if err != nil {
+		return nil, err
+	}
+	set, clear = 0, windows.ENABLE_VIRTUAL_TERMINAL_INPUT
+	if enable {
+		set, clear = clear, set
+	}
+	restoreIn, err := updateMode(c.in, set, clear)
+	if err != nil {
+		restoreOut()
+		return nil, err
+	}
+	return func() error { return errors.Join(restoreIn(), restoreOut()) }, nil
+}
+
+// ScreenBufferInfo 是屏幕缓冲区的信息
+type ScreenBufferInfo struct {
+	Size       xwindows.ConsoleSize // 缓冲区的列数和行数
+	Cursor     windows.Coord        // 光标在缓冲区中的位置
+	Window     windows.SmallRect    // 窗口在缓冲区中的位置，坐标包含边界
+	MaxWindow  xwindows.ConsoleSize // 按当前字体和屏幕大小，窗口最大的列数和行数
+	Attributes uint16               // 写入字符使用的 FOREGROUND_* 和 BACKGROUND_* 属性
+	ColorTable [16]uint32           // 16 种控制台颜色，COLORREF 格式 0x00BBGGRR
+}
+
+// WindowSize 返回窗口可见部分的列数和行数
+func (i *ScreenBufferInfo) WindowSize() xwindows.ConsoleSize {
+	return xwindows.ConsoleSize{
+		Cols: i.Window.Right - i.Window.Left + 1,
+		Rows: i.Window.Bottom - i.Window.Top + 1,
+	}
+}
+
+// Info 查询活动屏幕缓冲区的大小、光标、窗口和颜色
+func (c *Console) Info() (*ScreenBufferInfo, error) {
+	var raw xwindows.CONSOLE_SCREEN_BUFFER_INFOEX
+	raw.CbSize = uint32(unsafe.Sizeof(raw))
+	if err := xwindows.GetConsoleScreenBufferInfoEx(c.out, &raw); err != nil {
+		return nil, err
+	}
+	return &ScreenBufferInfo{
+		Size:       xwindows.ConsoleSize{Cols: raw.Size.X, Rows: raw.Size.Y},
+		Cursor:     raw.CursorPosition,
+		Window:     raw.Window,
+		MaxWindow:  xwindows.ConsoleSize{Cols: raw.MaximumWindowSize.X, Rows: raw.MaximumWindowSize.Y},
+		Attributes: raw.Attributes,
+		ColorTable: raw.ColorTable,
+	}, nil
+}
+
+// SetBufferSize 改变活动屏幕缓冲区的大小，不能小于当前窗口的大小
+func (c *Console) SetBufferSize(size xwindows.ConsoleSize) error {
+	if size.Cols <= 0 || size.Rows <= 0 {
+		return xwindows.ErrInvalidSize
+	}
+	return xwindows.SetConsoleScreenBufferSize(c.out, windows.Coord{X: size.Cols, Y: size.Rows})
+}
+
+// ReadEvents 读取输入事件并发送到 events，直到 ctx 取消或读取失败。
+// 读取期间启用窗口和鼠标输入并关闭快速编辑模式（否则鼠标用于选择文本），返回时恢复原来的模式。
+// ctx 取消时返回 ctx.Err()。
+//
+//	events := make(chan console.Event)
+//	go c.ReadEvents(ctx, events)
+//	for e := range events {
+//		switch e := e.(type) {
+//		case *console.ResizeEvent:
+//			redraw(e.Cols, e.Rows)
+//		case *console.KeyEvent:
+//			if e.Down && e.Rune == 'q' {
+//				cancel()
+//			}
+//		}
+//	}
+//
+// ReadEvents 返回时关闭 events。
+func (c *Console) ReadEvents(ctx context.Context, events chan<- Event) error {
+	defer close(events)
+	restore, err := updateMode(c.in,
+		windows.ENABLE_WINDOW_INPUT|windows.ENABLE_MOUSE_INPUT|windows.ENABLE_EXTENDED_FLAGS,
+		windows.ENABLE_QUICK_EDIT_MODE)
+	if err != nil {
+		return err
+	}
+	defer restore()
+
+	var d inputDecoder
+	records := make([]xwindows.INPUT_RECORD, 64)
+	for {
+		// ReadConsoleInputW 无法取消，先等待输入缓冲区中有事件
+		if _, err := xwindows.Wait(ctx, c.in); err != nil {
+			return err
+		}
+		var n uint32
+		if err := windows.GetNumberOfConsoleInputEvents(c.in, &n); err != nil {
+			return err
+		}
+		if n == 0 {
+			continue
+		}
+		if err := xwindows.ReadConsoleInputW(c.in, &records[0], uint32(min(int(n), len(records))), &n); err != nil {
+			return err
+		}
+		for i := range records[:n] {
+			e := d.decode(records[i].EventType, records[i].Event[:])
+			if e == nil {
+				continue
+			}
+			select {
+			case events <- e:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+}
+
+// Title 返回控制台窗口的标题
+func Title() (string, error) {
+	buf := make([]uint16, 256)
+	for {
+		n, err := xwindows.GetConsoleTitleW(&buf[0], uint32(len(buf)))
+		if err != nil {
+			return "", err
+		}
+		// 缓冲区不足时标题被截断，无法与恰好填满的情况区分，扩大后重试
+		if int(n) < len(buf)-1 {
+			return windows.UTF16ToString(buf[:n]), nil
+		}
+		buf = make([]uint16, 2*len(buf))
+	}
+}
+
+// SetTitle 设置控制台窗口的标题
+func SetTitle(title string) error {
+	return xwindows.SetConsoleTitleW(title)
+}
+
+// CodePages 返回控制台的输入代码页和输出代码页
+func CodePages() (in, out uint32, err error) {
+	if in, err = windows.GetConsoleCP(); err != nil {
+		return 0, 0, err
+	}
+	if out, err = windows.GetConsoleOutputCP(); err != nil {
+		return 0, 0, err
+	}
+	return in, out, nil
+}
+
+// SetCodePages 设置控制台的输入代码页和输出代码页，如 windows.CP_UTF8 (65001)
+func SetCodePages(in, out uint32) error {
+	if err := windows.SetConsoleCP(in); err != nil {
+		return err
+	}
+	return windows.SetConsoleOutputCP(out)
+}
@@ -0,0 +1,71 @@
+package console
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestInfo(t *testing.T) {
+	c, err := Open()
+	if errors.Is(err, ErrNoConsole) {
+		t.Skip("no console attached")
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	info, err := c.Info()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Size.Cols <= 0 || info.Size.Rows <= 0 {
+		t.Errorf("Size = %+v", info.Size)
+	}
+	if ws := info.WindowSize(); ws.Cols > info.Size.Cols || ws.Rows > info.Size.Rows {
+		t.Errorf("WindowSize() = %+v larger than buffer %+v", ws, info.Size)
+	}
+}
+
+func TestNotifyContext(t *testing.T) {
+	ctx, stop, err := NotifyContext(context.Background(), CtrlBreak, CtrlClose)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stop()
+
+	// 没有监听者关心的信号交给下一个处理函数
+	if ret := handleCtrl(uint32(CtrlC)); ret != 0 {
+		t.Fatalf("handleCtrl(CtrlC) = %d, want 0", ret)
+	}
+	if ctx.Err() != nil {
+		t.Fatal("context canceled by unwatched event")
+	}
+	if ret := handleCtrl(uint32(CtrlBreak)); ret != 1 {
+		t.Fatalf("handleCtrl(CtrlBreak) = %d, want 1", ret)
+	}
+	var ce *CtrlError
+	if !errors.As(context.Cause(ctx), &ce) || ce.Event != CtrlBreak {
+		t.Fatalf("Cause = %v, want CtrlError{CtrlBreak}", context.Cause(ctx))
+	}
+}
+
+func TestNotifyContextCloseWaitsForStop(t *testing.T) {
+	ctx, stop, err := NotifyContext(context.Background(), CtrlClose)
+	if err != nil {
+		t.Fatal(err)
+	}
+	returned := make(chan uintptr)
+	go func() { returned <- handleCtrl(uint32(CtrlClose)) }()
+	<-ctx.Done()
+	select {
+	case <-returned:
+		t.Fatal("handler returned before stop")
+	case <-time.After(50 * time.Millisecond):
+	}
+	stop()
+	if ret := <-returned; ret != 1 {
+		t.Fatalf("handleCtrl(CtrlClose) = %d, want 1", ret)
+	}
+}
@@ -0,0 +1,99 @@
+package console
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"syscall"
+
+	"github.com/C1ph3rX13/xwindows"
+)
+
+// ctrlListener 是 NotifyContext 注册的监听者
+type ctrlListener struct {
+	events []CtrlEvent
+	cancel context.CancelCauseFunc
+	done   chan struct{} // stop 调用后关闭
+}
+
+var ctrl struct {
+	mu        sync.Mutex
+	listeners []*ctrlListener
+}
+
+// ctrlHandler 是唯一的 HandlerRoutine，首次使用时注册并且不再移除。
+// 没有监听者关心的信号返回 FALSE，交给下一个处理函数（默认处理函数终止进程）。
+var ctrlHandler = sync.OnceValue(func() error {
+	return xwindows.SetConsoleCtrlHandler(syscall.NewCallback(handleCtrl), true)
+})
+
+// handleCtrl 在系统为控制信号创建的线程中调用
+func handleCtrl(event uint32) uintptr {
+	e := CtrlEvent(event)
+	ctrl.mu.Lock()
+	var matched []*ctrlListener
+	for _, l := range ctrl.listeners {
+		if slices.Contains(l.events, e) {
+			matched = append(matched, l)
+		}
+	}
+	ctrl.mu.Unlock()
+	if len(matched) == 0 {
+		return 0
+	}
+	for _, l := range matched {
+		l.cancel(&CtrlError{Event: e})
+	}
+	switch e {
+	case CtrlClose, CtrlLogoff, CtrlShutdown:
+		// 处理函数返回后进程立即被终止，等待监听者完成清理并调用 stop。
+		// 系统在超时（关闭窗口为 5 秒）后仍会终止进程。
+		for _, l := range matched {
+			<-l.done
+		}
+	}
+	return 1
+}
+
+// NotifyContext 返回在收到指定控制信号时取消的上下文，context.Cause 返回 *CtrlError。
+// 未指定 events 时监听所有控制信号。收到信号的进程不会被默认处理函数终止，
+// 除非没有任何监听者关心该信号。
+//
+// 对于 CtrlClose、CtrlLogoff 和 CtrlShutdown，处理函数返回后进程即被终止，
+// 因此处理函数会等待 stop 被调用：清理完成后必须调用 stop。
+//
+//	ctx, stop, err := console.NotifyContext(context.Background(), console.CtrlC, console.CtrlClose)
+//	if err != nil {
+//		return err
+//	}
+//	defer stop()
+//	<-ctx.Done()
+//	var ce *console.CtrlError
+//	if errors.As(context.Cause(ctx), &ce) {
+//		log.Printf("stopping: %v", ce.Event)
+//	}
+func NotifyContext(parent context.Context, events ...CtrlEvent) (context.Context, context.CancelFunc, error) {
+	if err := ctrlHandler(); err != nil {
+		return nil, nil, err
+	}
+	if len(events) == 0 {
+		events = []CtrlEvent{CtrlC, CtrlBreak, CtrlClose, CtrlLogoff, CtrlShutdown}
+	}
+	ctx, cancel := context.WithCancelCause(parent)
+	l := &ctrlListener{events: events, cancel: cancel, done: make(chan struct{})}
+	ctrl.mu.Lock()
+	ctrl.listeners = append(ctrl.listeners, l)
+	ctrl.mu.Unlock()
+
+	var once sync.Once
+	stop := func() {
+		once.Do(func() {
+			ctrl.mu.Lock()
+			ctrl.listeners = slices.DeleteFunc(ctrl.listeners, func(x *ctrlListener) bool { return x == l })
+			ctrl.mu.Unlock()
+			cancel(nil)
+			close(l.done)
+		})
+	}
+	return ctx, stop, nil
+}
@@ -0,0 +1,117 @@
+package xwindows
+
+import (
+	"errors"
+	"iter"
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// PageFile 描述一个已安装的页面文件，大小以字节为单位
+type PageFile struct {
+	Name       string
+	TotalSize  uint64
+	TotalInUse uint64
+	PeakUsage  uint64
+}
+
+// LoadedModule 描述进程中的一个已加载模块
+type LoadedModule struct {
+	Name string
+	Base uint64
+	Size uint32
+}
+
+// enumSeq 将基于回调的枚举转换为 iter.Seq2。
+// 循环体 break 时停止枚举；枚举失败时以零值和错误调用一次 yield；循环体中的 panic 原样向上传播。
+func enumSeq[T any](enum func(fn func(T) bool) error) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		err := enum(func(v T) bool {
+			return yield(v, nil)
+		})
+		var p *CallbackPanic
+		if errors.As(err, &p) {
+			panic(p.Value)
+		}
+		if err != nil {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
+
+// Windows 返回屏幕上所有顶级窗口的迭代器
+func Windows() iter.Seq2[windows.HWND, error] {
+	return enumSeq(EnumWindowsFunc)
+}
+
+// ChildWindows 返回指定父窗口所有子窗口的迭代器
+func ChildWindows(parent windows.HWND) iter.Seq2[windows.HWND, error] {
+	return enumSeq(func(fn func(windows.HWND) bool) error {
+		return EnumChildWindowsFunc(parent, fn)
+	})
+}
+
+// ThreadWindows 返回与指定线程关联的所有非子窗口的迭代器
+func ThreadWindows(threadId uint32) iter.Seq2[windows.HWND, error] {
+	return enumSeq(func(fn func(windows.HWND) bool) error {
+		return EnumThreadWindowsFunc(threadId, fn)
+	})
+}
+
+// DesktopWindows 返回指定桌面所有顶级窗口的迭代器，desktop 为 0 时使用当前桌面
+func DesktopWindows(desktop windows.Handle) iter.Seq2[windows.HWND, error] {
+	return enumSeq(func(fn func(windows.HWND) bool) error {
+		return EnumDesktopWindowsFunc(desktop, fn)
+	})
+}
+
+// SystemLocales 返回区域设置名称（如 "zh-CN"）的迭代器，flags 为 LOCALE_* 枚举标志
+func SystemLocales(flags uint32) iter.Seq2[string, error] {
+	return enumSeq(func(fn func(string) bool) error {
+		return EnumSystemLocalesExFunc(flags, func(name string, _ uint32) bool {
+			return fn(name)
+		})
+	})
+}
+
+// TimeFormats 返回区域设置 locale 的时间格式图片字符串（如 "h:mm:ss tt"）的迭代器，flags 为 0 或 TIME_NOSECONDS
+func TimeFormats(locale string, flags uint32) iter.Seq2[string, error] {
+	return enumSeq(func(fn func(string) bool) error {
+		return EnumTimeFormatsExFunc(locale, flags, fn)
+	})
+}
+
+// DateFormats 返回区域设置 locale 的日期格式图片字符串的迭代器，flags 为 DATE_SHORTDATE、DATE_LONGDATE 等
+func DateFormats(locale string, flags uint32) iter.Seq2[string, error] {
+	return enumSeq(func(fn func(string) bool) error {
+		return EnumDateFormatsExExFunc(locale, flags, func(format string, _ uint32) bool {
+			return fn(format)
+		})
+	})
+}
+
+// PageFiles 返回系统中已安装页面文件的迭代器
+func PageFiles() iter.Seq2[PageFile, error] {
+	pageSize := uint64(os.Getpagesize())
+	return enumSeq(func(fn func(PageFile) bool) error {
+		return EnumPageFilesWFunc(func(info *ENUM_PAGE_FILE_INFORMATION, filename string) bool {
+			return fn(PageFile{
+				Name:       filename,
+				TotalSize:  uint64(info.TotalSize) * pageSize,
+				TotalInUse: uint64(info.TotalInUse) * pageSize,
+				PeakUsage:  uint64(info.PeakUsage) * pageSize,
+			})
+		})
+	})
+}
+
+// LoadedModules 返回指定进程已加载模块的迭代器
+func LoadedModules(process windows.Handle) iter.Seq2[LoadedModule, error] {
+	return enumSeq(func(fn func(LoadedModule) bool) error {
+		return EnumerateLoadedModulesFunc(process, func(name string, base uint64, size uint32) bool {
+			return fn(LoadedModule{Name: name, Base: base, Size: size})
+		})
+	})
+}
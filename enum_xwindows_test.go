@@ -0,0 +1,47 @@
+package xwindows
+
+import "testing"
+
+func TestSystemLocales(t *testing.T) {
+	tests := []struct {
+		name  string
+		limit int
+	}{
+		{name: "break after one", limit: 1},
+		{name: "break after ten", limit: 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := 0
+			for name, err := range SystemLocales(LOCALE_WINDOWS) {
+				if err != nil {
+					t.Fatalf("SystemLocales() error = %v", err)
+				}
+				if name == "" {
+					t.Errorf("SystemLocales() yielded empty name")
+				}
+				got++
+				if got == tt.limit {
+					break
+				}
+			}
+			if got != tt.limit {
+				t.Errorf("SystemLocales() yielded %d, want %d", got, tt.limit)
+			}
+		})
+	}
+}
+
+func TestWindowsPanicPropagates(t *testing.T) {
+	ran := false
+	defer func() {
+		if r := recover(); ran && r != "boom" {
+			t.Errorf("recover() = %v, want boom", r)
+		}
+	}()
+	for range Windows() {
+		ran = true
+		panic("boom")
+	}
+	t.Skip("no top-level windows")
+}
@@ -1,6 +1,9 @@
 package xwindows
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	// 内存操作类错误
@@ -41,4 +44,42 @@ var (
 	// 系统状态错误
 	ErrInsufficientBuffer = errors.New("buffer size insufficient")
 	ErrNotReady           = errors.New("system not in ready state")
+
+	// 同步对象错误
+	ErrAbandoned = errors.New("wait abandoned: mutex owner exited without releasing it")
+
+	// 回调相关错误
+	ErrCallbackPanic = errors.New("callback panicked")
+
+	// 注册表错误
+	ErrUnexpectedType = errors.New("unexpected registry value type")
 )
+
+// MMError 是 winmm 函数返回的 MMRESULT 错误码
+type MMError uint32
+
+var mmErrors = map[MMError]string{
+	MMSYSERR_ERROR:        "unspecified multimedia error",
+	MMSYSERR_BADDEVICEID:  "device ID out of range",
+	MMSYSERR_NOTENABLED:   "driver failed enable",
+	MMSYSERR_ALLOCATED:    "device already allocated",
+	MMSYSERR_INVALHANDLE:  "invalid multimedia handle",
+	MMSYSERR_NODRIVER:     "no device driver present",
+	MMSYSERR_NOMEM:        "multimedia memory allocation error",
+	MMSYSERR_NOTSUPPORTED: "function not supported by driver",
+	MMSYSERR_INVALFLAG:    "invalid multimedia flag",
+	MMSYSERR_INVALPARAM:   "invalid multimedia parameter",
+	WAVERR_BADFORMAT:      "unsupported wave format",
+	WAVERR_STILLPLAYING:   "still something playing",
+	WAVERR_UNPREPARED:     "wave header not prepared",
+	WAVERR_SYNC:           "device is synchronous",
+	TIMERR_NOCANDO:        "timer request not supported",
+	TIMERR_STRUCT:         "timer structure size error",
+}
+
+func (e MMError) Error() string {
+	if s, ok := mmErrors[e]; ok {
+		return s
+	}
+	return fmt.Sprintf("MMRESULT %d", uint32(e))
+}
@@ -0,0 +1,158 @@
+// Package guid 解析、格式化和比较 Windows GUID (UUID)，不依赖 Windows API。
+//
+// GUID 的布局与 Windows 的 GUID 结构和 windows.GUID 相同，可以直接转换：
+//
+//	g := guid.GUID(windowsGUID)
+//
+// 注意内存中的 Data1、Data2 和 Data3 是小端序，而 RFC 4122 的字节形式是大端序，
+// 两者之间的转换使用 Bytes 和 FromBytes。
+package guid
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalid 表示字符串不是有效的 GUID
+var ErrInvalid = errors.New("guid: invalid GUID string")
+
+// GUID 是 128 位全局唯一标识符
+type GUID struct {
+	Data1 uint32
+	Data2 uint16
+	Data3 uint16
+	Data4 [8]byte
+}
+
+// Nil 是全零的 GUID
+var Nil GUID
+
+// Parse 解析 "xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx" 或带花括号的 "{xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx}"，
+// 十六进制数字不区分大小写。不带花括号的形式与 UuidFromString 接受的字符串相同。
+func Parse(s string) (GUID, error) {
+	str := s
+	if len(str) == 38 && str[0] == '{' && str[37] == '}' {
+		str = str[1:37]
+	}
+	if len(str) != 36 || str[8] != '-' || str[13] != '-' || str[18] != '-' || str[23] != '-' {
+		return Nil, fmt.Errorf("%w: %q", ErrInvalid, s)
+	}
+	var b [16]byte
+	j := 0
+	for i := 0; i < 36; i += 2 {
+		if i == 8 || i == 13 || i == 18 || i == 23 {
+			i++
+		}
+		hi, ok1 := fromHex(str[i])
+		lo, ok2 := fromHex(str[i+1])
+		if !ok1 || !ok2 {
+			return Nil, fmt.Errorf("%w: %q", ErrInvalid, s)
+		}
+		b[j] = hi<<4 | lo
+		j++
+	}
+	return FromBytes(b), nil
+}
+
+// MustParse 与 Parse 相同，但在字符串无效时 panic，用于初始化常量 GUID
+func MustParse(s string) GUID {
+	g, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return g
+}
+
+func fromHex(c byte) (byte, bool) {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0', true
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10, true
+	case 'A' <= c && c <= 'F':
+		return c - 'A' + 10, true
+	}
+	return 0, false
+}
+
+// FromBytes 从 RFC 4122 的 16 字节形式（大端序）构造 GUID
+func FromBytes(b [16]byte) GUID {
+	g := GUID{
+		Data1: binary.BigEndian.Uint32(b[0:4]),
+		Data2: binary.BigEndian.Uint16(b[4:6]),
+		Data3: binary.BigEndian.Uint16(b[6:8]),
+	}
+	copy(g.Data4[:], b[8:])
+	return g
+}
+
+// Bytes 返回 RFC 4122 的 16 字节形式（大端序），与字符串形式中十六进制数字的顺序一致
+func (g GUID) Bytes() [16]byte {
+	var b [16]byte
+	binary.BigEndian.PutUint32(b[0:4], g.Data1)
+	binary.BigEndian.PutUint16(b[4:6], g.Data2)
+	binary.BigEndian.PutUint16(b[6:8], g.Data3)
+	copy(b[8:], g.Data4[:])
+	return b
+}
+
+// String 返回小写、不带花括号的形式，与 UuidToString 相同，如 "6b29fc40-ca47-1067-b31d-00dd010662da"
+func (g GUID) String() string {
+	return g.format(false)
+}
+
+// Braced 返回大写、带花括号的形式，与 StringFromGUID2 和注册表中的形式相同，
+// 如 "{6B29FC40-CA47-1067-B31D-00DD010662DA}"
+func (g GUID) Braced() string {
+	return "{" + g.format(true) + "}"
+}
+
+func (g GUID) format(upper bool) string {
+	digits := "0123456789abcdef"
+	if upper {
+		digits = "0123456789ABCDEF"
+	}
+	b := g.Bytes()
+	buf := make([]byte, 0, 36)
+	for i, c := range b {
+		if i == 4 || i == 6 || i == 8 || i == 10 {
+			buf = append(buf, '-')
+		}
+		buf = append(buf, digits[c>>4], digits[c&0xF])
+	}
+	return string(buf)
+}
+
+// MarshalText 实现 encoding.TextMarshaler，使用 String 的形式
+func (g GUID) MarshalText() ([]byte, error) {
+	return []byte(g.String()), nil
+}
+
+// UnmarshalText 实现 encoding.TextUnmarshaler，接受 Parse 接受的所有形式
+func (g *GUID) UnmarshalText(text []byte) error {
+	v, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*g = v
+	return nil
+}
+
+// IsNil 报告 g 是否为全零的 GUID
+func (g GUID) IsNil() bool {
+	return g == Nil
+}
+
+// Compare 比较两个 GUID，返回 -1、0 或 1，顺序与 UuidCompare 相同：
+// 依次按无符号数比较 Data1、Data2、Data3 和 Data4 的每个字节，即 RFC 4122 字节形式的字典序
+func (g GUID) Compare(other GUID) int {
+	a, b := g.Bytes(), other.Bytes()
+	return bytes.Compare(a[:], b[:])
+}
+
+// Version 返回 RFC 4122 版本号（Data3 的高 4 位），UuidCreate 生成的 GUID 为 4，UuidCreateSequential 为 1
+func (g GUID) Version() int {
+	return int(g.Data3 >> 12)
+}
@@ -0,0 +1,144 @@
+package guid
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type corpusEntry struct {
+	input string
+	want  string // 小写规范形式，无效时为空
+}
+
+func readCorpus(t *testing.T) []corpusEntry {
+	t.Helper()
+	f, err := os.Open("testdata/corpus.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var entries []corpusEntry
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		line := s.Text()
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		i := strings.LastIndexByte(line, ' ')
+		if i < 0 {
+			t.Fatalf("malformed corpus line %q", line)
+		}
+		input, err := strconv.Unquote(line[:i])
+		if err != nil {
+			t.Fatalf("malformed corpus line %q: %v", line, err)
+		}
+		want := line[i+1:]
+		if want == "invalid" {
+			want = ""
+		}
+		entries = append(entries, corpusEntry{input, want})
+	}
+	return entries
+}
+
+func TestParseCorpus(t *testing.T) {
+	for _, e := range readCorpus(t) {
+		g, err := Parse(e.input)
+		if e.want == "" {
+			if !errors.Is(err, ErrInvalid) {
+				t.Errorf("Parse(%q) = %v, %v; want ErrInvalid", e.input, g, err)
+			}
+			continue
+		}
+		if err != nil || g.String() != e.want {
+			t.Errorf("Parse(%q) = %v, %v; want %s", e.input, g, err, e.want)
+		}
+	}
+}
+
+func TestLayout(t *testing.T) {
+	// IID_IUnknown
+	g := MustParse("00000000-0000-0000-C000-000000000046")
+	want := GUID{Data1: 0, Data2: 0, Data3: 0, Data4: [8]byte{0xC0, 0, 0, 0, 0, 0, 0, 0x46}}
+	if g != want {
+		t.Errorf("IID_IUnknown = %+v", g)
+	}
+	g = MustParse("6b29fc40-ca47-1067-b31d-00dd010662da")
+	want = GUID{0x6b29fc40, 0xca47, 0x1067, [8]byte{0xb3, 0x1d, 0x00, 0xdd, 0x01, 0x06, 0x62, 0xda}}
+	if g != want {
+		t.Fatalf("Parse = %+v, want %+v", g, want)
+	}
+	b := g.Bytes()
+	if b != [16]byte{0x6b, 0x29, 0xfc, 0x40, 0xca, 0x47, 0x10, 0x67, 0xb3, 0x1d, 0x00, 0xdd, 0x01, 0x06, 0x62, 0xda} {
+		t.Errorf("Bytes = % x", b)
+	}
+	if FromBytes(b) != g {
+		t.Errorf("FromBytes(Bytes()) = %v", FromBytes(b))
+	}
+	if got := g.Braced(); got != "{6B29FC40-CA47-1067-B31D-00DD010662DA}" {
+		t.Errorf("Braced = %s", got)
+	}
+	if g.Version() != 1 {
+		t.Errorf("Version = %d", g.Version())
+	}
+}
+
+func TestText(t *testing.T) {
+	type doc struct {
+		ID  GUID  `json:"id"`
+		Ptr *GUID `json:"ptr"`
+	}
+	g := MustParse("{6B29FC40-CA47-1067-B31D-00DD010662DA}")
+	out, err := json.Marshal(doc{ID: g, Ptr: &g})
+	if err != nil {
+		t.Fatal(err)
+	}
+	const want = `{"id":"6b29fc40-ca47-1067-b31d-00dd010662da","ptr":"6b29fc40-ca47-1067-b31d-00dd010662da"}`
+	if string(out) != want {
+		t.Errorf("Marshal = %s", out)
+	}
+	var d doc
+	if err := json.Unmarshal([]byte(`{"id":"{6B29FC40-CA47-1067-B31D-00DD010662DA}"}`), &d); err != nil || d.ID != g {
+		t.Errorf("Unmarshal = %+v, %v", d, err)
+	}
+	if err := json.Unmarshal([]byte(`{"id":"nope"}`), &d); !errors.Is(err, ErrInvalid) {
+		t.Errorf("Unmarshal invalid: %v", err)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	// 按 RFC 4122 字节序排列；Data1 按数值而不是内存中的小端字节比较
+	ordered := []GUID{
+		Nil,
+		MustParse("00000000-0000-0000-0000-000000000001"),
+		MustParse("00000000-0000-0000-0100-000000000000"),
+		MustParse("00000000-0000-0001-0000-000000000000"),
+		MustParse("00000000-0001-0000-0000-000000000000"),
+		MustParse("000000ff-0000-0000-0000-000000000000"),
+		MustParse("00000100-0000-0000-0000-000000000000"),
+		MustParse("80000000-0000-0000-0000-000000000000"),
+		MustParse("ffffffff-ffff-ffff-ffff-ffffffffffff"),
+	}
+	for i, a := range ordered {
+		for j, b := range ordered {
+			want := 0
+			switch {
+			case i < j:
+				want = -1
+			case i > j:
+				want = 1
+			}
+			if got := a.Compare(b); got != want {
+				t.Errorf("%v.Compare(%v) = %d, want %d", a, b, got, want)
+			}
+		}
+	}
+	if !Nil.IsNil() || ordered[1].IsNil() {
+		t.Error("IsNil")
+	}
+}
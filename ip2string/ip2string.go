@@ -0,0 +1,295 @@
+// Package ip2string 按 ntdll 中 RtlIpv4StringToAddress、RtlIpv6StringToAddress 和
+// RtlEthernetStringToAddress 系列函数的规则解析地址字符串，不依赖 Windows API。
+//
+// 这些规则比 net/netip 宽松：非严格模式下的 IPv4 地址可以使用八进制（前导 0）和十六进制（前导 0x），
+// 也可以省略部分字节（"10.1" 为 10.0.0.1，"0x7f000001" 为 127.0.0.1），
+// 因此在其他平台上校验将由 Windows 解析的配置时应使用本包而不是 netip.ParseAddr。
+// 规则参考 ReactOS 的实现，与 Windows 的一致性由 Windows 上的差分测试保证。
+//
+// 与 Windows 函数不同，本包要求整个字符串被解析，剩余字符视为错误。
+package ip2string
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/netip"
+	"strings"
+)
+
+// ErrInvalid 表示字符串不是有效的地址
+var ErrInvalid = errors.New("ip2string: invalid address")
+
+func invalid(s string) error {
+	return fmt.Errorf("%w: %q", ErrInvalid, s)
+}
+
+// ParseIPv4 解析 IPv4 地址 (RtlIpv4StringToAddress)。
+// strict 为 true 时只接受四部分的点分十进制形式，且不允许前导 0；
+// 否则还接受 "a.b.c"、"a.b"、"a" 形式（最后一部分填充剩余的字节）以及八进制和十六进制的各部分。
+func ParseIPv4(s string, strict bool) (netip.Addr, error) {
+	addr, rest, ok := parseIPv4(s, strict)
+	if !ok || rest != "" {
+		return netip.Addr{}, invalid(s)
+	}
+	return addr, nil
+}
+
+// ParseIPv4Port 解析带可选端口的 IPv4 地址，如 "192.168.1.1:8080" (RtlIpv4StringToAddressEx)。
+// 地址的规则与 ParseIPv4 相同，端口总是允许八进制和十六进制，没有端口时为 0。
+func ParseIPv4Port(s string, strict bool) (netip.AddrPort, error) {
+	addr, rest, ok := parseIPv4(s, strict)
+	if !ok {
+		return netip.AddrPort{}, invalid(s)
+	}
+	port, ok := parsePort(rest)
+	if !ok {
+		return netip.AddrPort{}, invalid(s)
+	}
+	return netip.AddrPortFrom(addr, port), nil
+}
+
+// parsePort 解析空字符串或 ":port"
+func parsePort(s string) (uint16, bool) {
+	if s == "" {
+		return 0, true
+	}
+	if s[0] != ':' {
+		return 0, false
+	}
+	v, rest, ok := parseUlong(s[1:], false)
+	if !ok || rest != "" || v > math.MaxUint16 {
+		return 0, false
+	}
+	return uint16(v), true
+}
+
+// ParseIPv6 解析 IPv6 地址 (RtlIpv6StringToAddress)，接受 "::" 缩写和末尾 32 位的点分十进制形式，
+// 不接受范围 ID、端口和方括号
+func ParseIPv6(s string) (netip.Addr, error) {
+	addr, rest, ok := parseIPv6(s)
+	if !ok || rest != "" {
+		return netip.Addr{}, invalid(s)
+	}
+	return addr, nil
+}
+
+// ParseIPv6Ex 解析带可选范围 ID 和端口的 IPv6 地址 (RtlIpv6StringToAddressEx)，形式为
+// "addr"、"addr%scope"、"[addr]"、"[addr%scope]" 或 "[addr%scope]:port"。
+// 范围 ID 是十进制数，非零时作为地址的区域返回；端口只能与方括号一起使用，允许八进制和十六进制，没有端口时为 0。
+func ParseIPv6Ex(s string) (netip.AddrPort, error) {
+	str, bracket := strings.CutPrefix(s, "[")
+	addr, rest, ok := parseIPv6(str)
+	if !ok {
+		return netip.AddrPort{}, invalid(s)
+	}
+	if rest != "" && rest[0] == '%' {
+		var scope uint32
+		if scope, rest, ok = parseUlongBase(rest[1:], 10); !ok {
+			return netip.AddrPort{}, invalid(s)
+		}
+		if scope != 0 {
+			addr = addr.WithZone(fmt.Sprint(scope))
+		}
+	}
+	var port uint16
+	if bracket {
+		if rest == "" || rest[0] != ']' {
+			return netip.AddrPort{}, invalid(s)
+		}
+		if port, ok = parsePort(rest[1:]); !ok {
+			return netip.AddrPort{}, invalid(s)
+		}
+	} else if rest != "" {
+		return netip.AddrPort{}, invalid(s)
+	}
+	return netip.AddrPortFrom(addr, port), nil
+}
+
+// ParseMAC 解析 "xx-xx-xx-xx-xx-xx" 形式的以太网地址 (RtlEthernetStringToAddress)，十六进制数字不区分大小写
+func ParseMAC(s string) (net.HardwareAddr, error) {
+	if len(s) != 17 {
+		return nil, invalid(s)
+	}
+	mac := make(net.HardwareAddr, 6)
+	for i := range mac {
+		hi, ok1 := hexDigit(s[i*3])
+		lo, ok2 := hexDigit(s[i*3+1])
+		if !ok1 || !ok2 || i < 5 && s[i*3+2] != '-' {
+			return nil, invalid(s)
+		}
+		mac[i] = byte(hi<<4 | lo)
+	}
+	return mac, nil
+}
+
+func hexDigit(c byte) (uint32, bool) {
+	switch {
+	case '0' <= c && c <= '9':
+		return uint32(c - '0'), true
+	case 'a' <= c && c <= 'f':
+		return uint32(c-'a') + 10, true
+	case 'A' <= c && c <= 'F':
+		return uint32(c-'A') + 10, true
+	}
+	return 0, false
+}
+
+// parseUlong 解析 s 开头的数字 (RtlpStringToUlong)，非严格模式下前导 0x 表示十六进制，前导 0 后跟数字表示八进制
+func parseUlong(s string, strict bool) (uint32, string, bool) {
+	base := uint32(10)
+	if len(s) >= 2 && s[0] == '0' {
+		switch {
+		case s[1] == 'x' || s[1] == 'X':
+			if strict {
+				return 0, s, false
+			}
+			base, s = 16, s[2:]
+		case '0' <= s[1] && s[1] <= '9':
+			if strict {
+				return 0, s, false
+			}
+			base, s = 8, s[1:]
+		}
+	}
+	return parseUlongBase(s, base)
+}
+
+// parseUlongBase 解析 s 开头的 base 进制数字，至少需要一位数字，超出 32 位视为错误
+func parseUlongBase(s string, base uint32) (uint32, string, bool) {
+	var v uint32
+	i := 0
+	for ; i < len(s); i++ {
+		d, ok := hexDigit(s[i])
+		if !ok || d >= base {
+			break
+		}
+		if v > (math.MaxUint32-d)/base {
+			return 0, s, false
+		}
+		v = v*base + d
+	}
+	if i == 0 {
+		return 0, s, false
+	}
+	return v, s[i:], true
+}
+
+// parseIPv4 解析 s 开头的 IPv4 地址，返回未解析的部分
+func parseIPv4(s string, strict bool) (netip.Addr, string, bool) {
+	var parts [4]uint32
+	n := 0
+	for {
+		v, rest, ok := parseUlong(s, strict)
+		if !ok {
+			return netip.Addr{}, s, false
+		}
+		parts[n] = v
+		n++
+		s = rest
+		if s == "" || s[0] != '.' {
+			break
+		}
+		// 已经有四部分，后面还有 "."
+		if n == 4 {
+			return netip.Addr{}, s, false
+		}
+		s = s[1:]
+	}
+	if strict && n < 4 {
+		return netip.Addr{}, s, false
+	}
+	// 最后一部分填充剩余的字节，前面的每部分占一个字节
+	v := parts[n-1]
+	if v > math.MaxUint32>>(8*(n-1)) {
+		return netip.Addr{}, s, false
+	}
+	for i := range n - 1 {
+		if parts[i] > 0xFF {
+			return netip.Addr{}, s, false
+		}
+		v |= parts[i] << (8 * (3 - i))
+	}
+	return netip.AddrFrom4([4]byte{byte(v >> 24), byte(v >> 16), byte(v >> 8), byte(v)}), s, true
+}
+
+// parseIPv6 解析 s 开头的 IPv6 地址，返回未解析的部分
+func parseIPv6(s string) (netip.Addr, string, bool) {
+	var groups []uint16
+	ellipsis := -1 // "::" 之前的组数
+	if strings.HasPrefix(s, "::") {
+		ellipsis, s = 0, s[2:]
+	}
+	for s != "" && len(groups) < 8 {
+		if _, ok := hexDigit(s[0]); !ok {
+			// "::" 之后可以没有组；单个 ":" 之后必须有组
+			if ellipsis == len(groups) {
+				break
+			}
+			return netip.Addr{}, s, false
+		}
+		i := 0
+		var v uint32
+		for ; i < len(s); i++ {
+			d, ok := hexDigit(s[i])
+			if !ok {
+				break
+			}
+			v = v<<4 | d
+		}
+		if i < len(s) && s[i] == '.' {
+			// 末尾 32 位的点分十进制形式
+			if len(groups) > 6 {
+				return netip.Addr{}, s, false
+			}
+			v4, rest, ok := parseIPv4(s, true)
+			if !ok {
+				return netip.Addr{}, s, false
+			}
+			b := v4.As4()
+			groups = append(groups, uint16(b[0])<<8|uint16(b[1]), uint16(b[2])<<8|uint16(b[3]))
+			s = rest
+			break
+		}
+		if i > 4 {
+			return netip.Addr{}, s, false
+		}
+		groups = append(groups, uint16(v))
+		s = s[i:]
+		switch {
+		case strings.HasPrefix(s, "::"):
+			if ellipsis >= 0 {
+				return netip.Addr{}, s, false
+			}
+			ellipsis, s = len(groups), s[2:]
+		case strings.HasPrefix(s, ":"):
+			if len(groups) == 8 {
+				return netip.Addr{}, s, false
+			}
+			s = s[1:]
+			if s == "" {
+				return netip.Addr{}, s, false
+			}
+		default:
+			return expand(groups, ellipsis, s)
+		}
+	}
+	return expand(groups, ellipsis, s)
+}
+
+// expand 将 "::" 展开为零组，"::" 至少代表一组
+func expand(groups []uint16, ellipsis int, rest string) (netip.Addr, string, bool) {
+	if ellipsis < 0 && len(groups) != 8 || ellipsis >= 0 && len(groups) > 7 {
+		return netip.Addr{}, rest, false
+	}
+	var b [16]byte
+	for i, g := range groups {
+		j := i
+		if ellipsis >= 0 && i >= ellipsis {
+			j += 8 - len(groups)
+		}
+		b[2*j], b[2*j+1] = byte(g>>8), byte(g)
+	}
+	return netip.AddrFrom16(b), rest, true
+}
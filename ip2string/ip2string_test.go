@@ -0,0 +1,102 @@
+package ip2string
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// entry 是语料中的一行
+type entry struct {
+	kind  string
+	input string
+	want  string // 规范形式，无效时为空
+}
+
+func readCorpus(t *testing.T) []entry {
+	t.Helper()
+	f, err := os.Open("testdata/corpus.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var entries []entry
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		line := s.Text()
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		kind, rest, _ := strings.Cut(line, " ")
+		i := strings.LastIndexByte(rest, ' ')
+		if i < 0 {
+			t.Fatalf("malformed corpus line %q", line)
+		}
+		input, err := strconv.Unquote(rest[:i])
+		if err != nil {
+			t.Fatalf("malformed corpus line %q: %v", line, err)
+		}
+		want := rest[i+1:]
+		if want == "invalid" {
+			want = ""
+		}
+		entries = append(entries, entry{kind, input, want})
+	}
+	return entries
+}
+
+// parsers 按语料中的函数名调用本包的解析函数，结果格式化为规范形式
+var parsers = map[string]func(string) (fmt.Stringer, error){
+	"v4":           func(s string) (fmt.Stringer, error) { return ParseIPv4(s, false) },
+	"v4strict":     func(s string) (fmt.Stringer, error) { return ParseIPv4(s, true) },
+	"v4port":       func(s string) (fmt.Stringer, error) { return ParseIPv4Port(s, false) },
+	"v4portstrict": func(s string) (fmt.Stringer, error) { return ParseIPv4Port(s, true) },
+	"v6":           func(s string) (fmt.Stringer, error) { return ParseIPv6(s) },
+	"v6ex":         func(s string) (fmt.Stringer, error) { return ParseIPv6Ex(s) },
+	"mac":          func(s string) (fmt.Stringer, error) { return ParseMAC(s) },
+}
+
+func TestCorpus(t *testing.T) {
+	entries := readCorpus(t)
+	if len(entries) < 50 {
+		t.Fatalf("corpus has %d entries", len(entries))
+	}
+	for _, e := range entries {
+		parse, ok := parsers[e.kind]
+		if !ok {
+			t.Fatalf("unknown function %q", e.kind)
+		}
+		got, err := parse(e.input)
+		if e.want == "" {
+			if !errors.Is(err, ErrInvalid) {
+				t.Errorf("%s(%q) = %v, %v; want ErrInvalid", e.kind, e.input, got, err)
+			}
+			continue
+		}
+		if err != nil || got.String() != e.want {
+			t.Errorf("%s(%q) = %v, %v; want %s", e.kind, e.input, got, err, e.want)
+		}
+	}
+}
+
+func TestParseIPv6ExZone(t *testing.T) {
+	ap, err := ParseIPv6Ex("[fe80::1%12]:8080")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ap.Addr().Zone() != "12" || ap.Port() != 8080 || !ap.Addr().Is6() {
+		t.Errorf("ParseIPv6Ex = %v", ap)
+	}
+}
+
+func TestParseIPv4Is4(t *testing.T) {
+	// 返回的地址是 4 字节形式，不是 IPv4 映射的 IPv6 地址
+	addr, err := ParseIPv4("0x7f000001", false)
+	if err != nil || !addr.Is4() {
+		t.Errorf("ParseIPv4 = %v, %v", addr, err)
+	}
+}
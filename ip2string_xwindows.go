@@ -0,0 +1,163 @@
+package xwindows
+
+import (
+	"math/bits"
+	"net"
+	"net/netip"
+	"runtime"
+	"strconv"
+
+	"golang.org/x/sys/windows"
+)
+
+// 以下函数用 ntdll 的 Rtl*StringToAddress / Rtl*AddressToString 系列在 net/netip 类型和字符串之间转换。
+// 其他平台上可使用 ip2string 包中规则相同的纯 Go 实现，两者的一致性由差分测试保证。
+//
+// 与原始函数不同，解析函数要求整个字符串被解析，剩余字符视为错误。
+
+// ParseIPv4 解析 IPv4 地址。strict 为 true 时只接受四部分的点分十进制形式；
+// 否则还接受八进制、十六进制以及 "a.b.c"、"a.b"、"a" 等缩写形式。
+func ParseIPv4(s string, strict bool) (netip.Addr, error) {
+	addr, err := RtlIpv4StringToAddress(s, strict)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	return netip.AddrFrom4(addr), nil
+}
+
+// ParseIPv4Port 解析带可选端口的 IPv4 地址，如 "192.168.1.1:8080"，没有端口时为 0
+func ParseIPv4Port(s string, strict bool) (netip.AddrPort, error) {
+	var p runtime.Pinner
+	defer p.Unpin()
+	str, err := windows.ByteSliceFromString(s)
+	if err != nil {
+		return netip.AddrPort{}, err
+	}
+	var _p0 uintptr
+	if strict {
+		_p0 = 1
+	}
+	var addr [4]byte
+	var port uint16
+	status, _ := RtlIpv4StringToAddressExA(pinSlice(&p, str), _p0, pinPtr(&p, &addr), pinPtr(&p, &port))
+	if status != windows.STATUS_SUCCESS {
+		return netip.AddrPort{}, status
+	}
+	return netip.AddrPortFrom(netip.AddrFrom4(addr), bits.ReverseBytes16(port)), nil
+}
+
+// ParseIPv6 解析 IPv6 地址，不接受范围 ID、端口和方括号
+func ParseIPv6(s string) (netip.Addr, error) {
+	var p runtime.Pinner
+	defer p.Unpin()
+	str, err := windows.ByteSliceFromString(s)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	base := pinSlice(&p, str)
+	var terminator uintptr
+	var addr [16]byte
+	if err := RtlIpv6StringToAddressA(&str[0], &terminator, &addr); err != nil {
+		return netip.Addr{}, err
+	}
+	if terminator != base+uintptr(len(s)) {
+		return netip.Addr{}, windows.STATUS_INVALID_PARAMETER
+	}
+	return netip.AddrFrom16(addr), nil
+}
+
+// ParseIPv6Ex 解析 "[addr%scope]:port" 形式的 IPv6 地址，范围 ID 和端口都是可选的。
+// 非零的范围 ID 作为地址的区域返回，没有端口时为 0。
+func ParseIPv6Ex(s string) (netip.AddrPort, error) {
+	str, err := windows.ByteSliceFromString(s)
+	if err != nil {
+		return netip.AddrPort{}, err
+	}
+	var addr [16]byte
+	var scope uint32
+	var port uint16
+	if err := RtlIpv6StringToAddressExA(&str[0], &addr, &scope, &port); err != nil {
+		return netip.AddrPort{}, err
+	}
+	ip := netip.AddrFrom16(addr)
+	if scope != 0 {
+		ip = ip.WithZone(strconv.FormatUint(uint64(scope), 10))
+	}
+	return netip.AddrPortFrom(ip, bits.ReverseBytes16(port)), nil
+}
+
+// ParseMAC 解析 "xx-xx-xx-xx-xx-xx" 形式的以太网地址
+func ParseMAC(s string) (net.HardwareAddr, error) {
+	addr, err := RtlEthernetStringToAddress(s)
+	if err != nil {
+		return nil, err
+	}
+	return net.HardwareAddr(addr[:]), nil
+}
+
+// FormatIPv4 将 IPv4 地址（包括映射到 IPv6 的 IPv4 地址）格式化为点分十进制形式
+func FormatIPv4(addr netip.Addr) (string, error) {
+	addr = addr.Unmap()
+	if !addr.Is4() {
+		return "", ErrInvalidParameter
+	}
+	return RtlIpv4AddressToString(addr.As4()), nil
+}
+
+// FormatIPv4Port 将 IPv4 地址和端口格式化为 "a.b.c.d:port"，端口为 0 时省略
+func FormatIPv4Port(ap netip.AddrPort) (string, error) {
+	addr := ap.Addr().Unmap()
+	if !addr.Is4() {
+		return "", ErrInvalidParameter
+	}
+	a := addr.As4()
+	buf := make([]byte, 22) // INET_ADDRSTRLEN + ":65535"
+	n := uint32(len(buf))
+	if err := RtlIpv4AddressToStringExA(&a, bits.ReverseBytes16(ap.Port()), &buf[0], &n); err != nil {
+		return "", err
+	}
+	return windows.ByteSliceToString(buf), nil
+}
+
+// FormatIPv6 将地址格式化为 IPv6 形式，IPv4 地址按映射地址 (::ffff:a.b.c.d) 处理，区域被忽略
+func FormatIPv6(addr netip.Addr) (string, error) {
+	if !addr.IsValid() {
+		return "", ErrInvalidParameter
+	}
+	a := addr.As16()
+	buf := make([]byte, 46) // INET6_ADDRSTRLEN
+	RtlIpv6AddressToStringA(&a, &buf[0])
+	return windows.ByteSliceToString(buf), nil
+}
+
+// FormatIPv6Ex 将 IPv6 地址、区域和端口格式化为 "[addr%scope]:port"。
+// 区域必须是十进制的范围 ID；区域和端口都为空时与 FormatIPv6 相同。
+func FormatIPv6Ex(ap netip.AddrPort) (string, error) {
+	addr := ap.Addr()
+	if !addr.IsValid() {
+		return "", ErrInvalidParameter
+	}
+	var scope uint32
+	if zone := addr.Zone(); zone != "" {
+		v, err := strconv.ParseUint(zone, 10, 32)
+		if err != nil {
+			return "", ErrInvalidParameter
+		}
+		scope = uint32(v)
+	}
+	a := addr.As16()
+	buf := make([]byte, 65) // INET6_ADDRSTRLEN + "[" + "%scope" + "]:port"
+	n := uint32(len(buf))
+	if err := RtlIpv6AddressToStringExA(&a, scope, bits.ReverseBytes16(ap.Port()), &buf[0], &n); err != nil {
+		return "", err
+	}
+	return windows.ByteSliceToString(buf), nil
+}
+
+// FormatMAC 将 6 字节的以太网地址格式化为 "XX-XX-XX-XX-XX-XX"
+func FormatMAC(mac net.HardwareAddr) (string, error) {
+	if len(mac) != 6 {
+		return "", ErrInvalidParameter
+	}
+	return RtlEthernetAddressToString([6]byte(mac)), nil
+}
@@ -0,0 +1,129 @@
+package xwindows
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"net/netip"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/C1ph3rX13/xwindows/ip2string"
+)
+
+// TestIp2stringCorpus 检查 ip2string 包与 ntdll 对黄金语料的结果一致
+func TestIp2stringCorpus(t *testing.T) {
+	type parser func(string) (fmt.Stringer, error)
+	wrap := func(f func(string) (netip.Addr, error)) parser {
+		return func(s string) (fmt.Stringer, error) { return f(s) }
+	}
+	wrapPort := func(f func(string) (netip.AddrPort, error)) parser {
+		return func(s string) (fmt.Stringer, error) { return f(s) }
+	}
+	wrapMAC := func(f func(string) (net.HardwareAddr, error)) parser {
+		return func(s string) (fmt.Stringer, error) { return f(s) }
+	}
+	strict := func(f func(string, bool) (netip.Addr, error), strict bool) func(string) (netip.Addr, error) {
+		return func(s string) (netip.Addr, error) { return f(s, strict) }
+	}
+	strictPort := func(f func(string, bool) (netip.AddrPort, error), strict bool) func(string) (netip.AddrPort, error) {
+		return func(s string) (netip.AddrPort, error) { return f(s, strict) }
+	}
+	parsers := map[string][2]parser{
+		"v4":           {wrap(strict(ParseIPv4, false)), wrap(strict(ip2string.ParseIPv4, false))},
+		"v4strict":     {wrap(strict(ParseIPv4, true)), wrap(strict(ip2string.ParseIPv4, true))},
+		"v4port":       {wrapPort(strictPort(ParseIPv4Port, false)), wrapPort(strictPort(ip2string.ParseIPv4Port, false))},
+		"v4portstrict": {wrapPort(strictPort(ParseIPv4Port, true)), wrapPort(strictPort(ip2string.ParseIPv4Port, true))},
+		"v6":           {wrap(ParseIPv6), wrap(ip2string.ParseIPv6)},
+		"v6ex":         {wrapPort(ParseIPv6Ex), wrapPort(ip2string.ParseIPv6Ex)},
+		"mac":          {wrapMAC(ParseMAC), wrapMAC(ip2string.ParseMAC)},
+	}
+
+	f, err := os.Open("ip2string/testdata/corpus.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		line := s.Text()
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		kind, rest, _ := strings.Cut(line, " ")
+		i := strings.LastIndexByte(rest, ' ')
+		input, err := strconv.Unquote(rest[:max(i, 0)])
+		p, ok := parsers[kind]
+		if err != nil || !ok {
+			t.Fatalf("malformed corpus line %q", line)
+		}
+		got, err := p[0](input)
+		want, wantErr := p[1](input)
+		if (err != nil) != (wantErr != nil) || err == nil && got.String() != want.String() {
+			t.Errorf("%s %q: ntdll = %v, %v; ip2string = %v, %v", kind, input, got, err, want, wantErr)
+		}
+	}
+}
+
+func TestIp2stringFormat(t *testing.T) {
+	v4 := []string{"0.0.0.0", "1.2.3.4", "255.255.255.255"}
+	for _, s := range v4 {
+		addr := netip.MustParseAddr(s)
+		if got, err := FormatIPv4(addr); err != nil || got != s {
+			t.Errorf("FormatIPv4(%v) = %q, %v", addr, got, err)
+		}
+		for _, port := range []uint16{0, 80, 65535} {
+			ap := netip.AddrPortFrom(addr, port)
+			got, err := FormatIPv4Port(ap)
+			if err != nil {
+				t.Errorf("FormatIPv4Port(%v): %v", ap, err)
+				continue
+			}
+			if back, err := ParseIPv4Port(got, true); err != nil || back != ap {
+				t.Errorf("ParseIPv4Port(%q) = %v, %v; want %v", got, back, err, ap)
+			}
+		}
+	}
+
+	v6 := []string{"::", "::1", "2001:db8::1", "fe80::1:2:3:4", "1:2:3:4:5:6:7:8", "::ffff:1.2.3.4"}
+	for _, s := range v6 {
+		addr := netip.MustParseAddr(s)
+		got, err := FormatIPv6(addr)
+		if err != nil {
+			t.Errorf("FormatIPv6(%v): %v", addr, err)
+			continue
+		}
+		if back, err := ParseIPv6(got); err != nil || back != addr {
+			t.Errorf("ParseIPv6(%q) = %v, %v; want %v", got, back, err, addr)
+		}
+		for _, ap := range []netip.AddrPort{
+			netip.AddrPortFrom(addr, 0),
+			netip.AddrPortFrom(addr, 443),
+			netip.AddrPortFrom(addr.WithZone("5"), 0),
+			netip.AddrPortFrom(addr.WithZone("12"), 8080),
+		} {
+			got, err := FormatIPv6Ex(ap)
+			if err != nil {
+				t.Errorf("FormatIPv6Ex(%v): %v", ap, err)
+				continue
+			}
+			if back, err := ParseIPv6Ex(got); err != nil || back != ap {
+				t.Errorf("ParseIPv6Ex(%q) = %v, %v; want %v", got, back, err, ap)
+			}
+		}
+	}
+	if _, err := FormatIPv6Ex(netip.AddrPortFrom(netip.MustParseAddr("fe80::1%eth0"), 0)); err == nil {
+		t.Error("FormatIPv6Ex accepted a non-numeric zone")
+	}
+
+	mac := net.HardwareAddr{0x00, 0x1a, 0x2b, 0x3c, 0x4d, 0xff}
+	got, err := FormatMAC(mac)
+	if err != nil || !strings.EqualFold(got, "00-1a-2b-3c-4d-ff") {
+		t.Errorf("FormatMAC(%v) = %q, %v", mac, got, err)
+	}
+	if _, err := FormatMAC(mac[:4]); err == nil {
+		t.Error("FormatMAC accepted a 4-byte address")
+	}
+}
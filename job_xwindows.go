@@ -0,0 +1,324 @@
+package xwindows
+
+import (
+	"errors"
+	"sync"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// JobLimits 是作业对象的限制，零值表示没有限制
+type JobLimits struct {
+	// KillOnClose 在作业的最后一个句柄关闭时终止作业中的所有进程，包括监督进程异常退出时
+	KillOnClose bool
+	// DieOnUnhandledException 使出现未处理异常的进程直接退出，而不是显示 Windows 错误报告对话框
+	DieOnUnhandledException bool
+	// ProcessMemory 是每个进程可提交的内存上限（字节）
+	ProcessMemory uintptr
+	// JobMemory 是作业中所有进程可提交的内存总量上限（字节）
+	JobMemory uintptr
+	// ActiveProcesses 是作业中同时运行的进程数上限，超过时新进程无法加入作业并被终止
+	ActiveProcesses uint32
+	// CPURate 是作业可使用的 CPU 时间百分比（0 到 100，精度 0.01），作为硬上限执行，需要 Windows 8 及以上版本
+	CPURate float64
+	// UIRestrictions 是 JOB_OBJECT_UILIMIT_* 标志的组合，如 JOB_OBJECT_UILIMIT_READCLIPBOARD
+	UIRestrictions uint32
+}
+
+// JobEventKind 是作业通知的类型，取值为 JOB_OBJECT_MSG_*
+type JobEventKind uint32
+
+const (
+	JobEndOfJobTime       JobEventKind = JOB_OBJECT_MSG_END_OF_JOB_TIME
+	JobEndOfProcessTime   JobEventKind = JOB_OBJECT_MSG_END_OF_PROCESS_TIME
+	JobActiveProcessLimit JobEventKind = JOB_OBJECT_MSG_ACTIVE_PROCESS_LIMIT
+	JobActiveProcessZero  JobEventKind = JOB_OBJECT_MSG_ACTIVE_PROCESS_ZERO
+	JobNewProcess         JobEventKind = JOB_OBJECT_MSG_NEW_PROCESS
+	JobExitProcess        JobEventKind = JOB_OBJECT_MSG_EXIT_PROCESS
+	JobAbnormalExit       JobEventKind = JOB_OBJECT_MSG_ABNORMAL_EXIT_PROCESS
+	JobProcessMemoryLimit JobEventKind = JOB_OBJECT_MSG_PROCESS_MEMORY_LIMIT
+	JobMemoryLimit        JobEventKind = JOB_OBJECT_MSG_JOB_MEMORY_LIMIT
+	JobNotificationLimit  JobEventKind = JOB_OBJECT_MSG_NOTIFICATION_LIMIT
+)
+
+var jobEventNames = []namedFlag{
+	{JOB_OBJECT_MSG_END_OF_JOB_TIME, "END_OF_JOB_TIME"},
+	{JOB_OBJECT_MSG_END_OF_PROCESS_TIME, "END_OF_PROCESS_TIME"},
+	{JOB_OBJECT_MSG_ACTIVE_PROCESS_LIMIT, "ACTIVE_PROCESS_LIMIT"},
+	{JOB_OBJECT_MSG_ACTIVE_PROCESS_ZERO, "ACTIVE_PROCESS_ZERO"},
+	{JOB_OBJECT_MSG_NEW_PROCESS, "NEW_PROCESS"},
+	{JOB_OBJECT_MSG_EXIT_PROCESS, "EXIT_PROCESS"},
+	{JOB_OBJECT_MSG_ABNORMAL_EXIT_PROCESS, "ABNORMAL_EXIT_PROCESS"},
+	{JOB_OBJECT_MSG_PROCESS_MEMORY_LIMIT, "PROCESS_MEMORY_LIMIT"},
+	{JOB_OBJECT_MSG_JOB_MEMORY_LIMIT, "JOB_MEMORY_LIMIT"},
+	{JOB_OBJECT_MSG_NOTIFICATION_LIMIT, "NOTIFICATION_LIMIT"},
+}
+
+func (k JobEventKind) String() string {
+	return flagName(uint32(k), jobEventNames)
+}
+
+// JobEvent 是作业通过完成端口发送的通知
+type JobEvent struct {
+	Kind JobEventKind
+	// PID 是相关进程的 ID，只对 JobEndOfProcessTime、JobNewProcess、JobExitProcess、
+	// JobAbnormalExit、JobProcessMemoryLimit 有意义
+	PID uint32
+}
+
+// JobAccounting 是作业的资源使用统计，包括已退出的进程
+type JobAccounting struct {
+	UserTime            time.Duration
+	KernelTime          time.Duration
+	PageFaults          uint32
+	TotalProcesses      uint32 // 曾经加入作业的进程数
+	ActiveProcesses     uint32
+	TerminatedProcesses uint32 // 因超过限制而被终止的进程数
+	IO                  windows.IO_COUNTERS
+	PeakProcessMemory   uintptr // 单个进程提交内存的峰值
+	PeakJobMemory       uintptr // 作业提交内存总量的峰值
+}
+
+// jobPortKey 是作业关联到完成端口时的完成键，Close 以键 0 投递停止消息
+const jobPortKey = 1
+
+// Job 是作业对象，用于将子进程作为一组进行限制、统计和终止。
+// 进程加入作业后，其创建的子进程默认也属于该作业。
+//
+//	job, err := xwindows.NewJob()
+//	if err != nil {
+//		return err
+//	}
+//	defer job.Close()
+//	if err := job.SetLimits(xwindows.JobLimits{KillOnClose: true}); err != nil {
+//		return err
+//	}
+//	// 以挂起状态启动，加入作业后再恢复，避免子进程在加入前创建孙进程
+//	p, err := xwindows.NewProcessSpec(worker).WithCreationFlags(windows.CREATE_SUSPENDED).Start()
+//	if err != nil {
+//		return err
+//	}
+//	if err := job.Assign(p.Handle()); err != nil {
+//		p.Kill()
+//		return err
+//	}
+//	p.Resume()
+type Job struct {
+	handle  windows.Handle
+	mu      sync.Mutex
+	port    windows.Handle
+	events  chan JobEvent
+	done    chan struct{}
+	exited  chan struct{} // pump 退出后关闭
+	cpuRate bool          // 是否设置过 CPU 速率限制，由 mu 保护
+}
+
+// NewJob 创建未命名的作业对象
+func NewJob() (*Job, error) {
+	h, err := windows.CreateJobObject(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &Job{handle: h}, nil
+}
+
+// Handle 返回作业对象的句柄
+func (j *Job) Handle() windows.Handle {
+	return j.handle
+}
+
+// Assign 将进程加入作业，process 需要 PROCESS_SET_QUOTA 和 PROCESS_TERMINATE 访问权限
+func (j *Job) Assign(process windows.Handle) error {
+	return windows.AssignProcessToJobObject(j.handle, process)
+}
+
+// Terminate 以 exitCode 终止作业中的所有进程
+func (j *Job) Terminate(exitCode uint32) error {
+	return windows.TerminateJobObject(j.handle, exitCode)
+}
+
+// SetLimits 替换作业的所有限制
+func (j *Job) SetLimits(limits JobLimits) error {
+	if limits.CPURate < 0 || limits.CPURate > 100 {
+		return ErrInvalidParameter
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	var info windows.JOBOBJECT_EXTENDED_LIMIT_INFORMATION
+	basic := &info.BasicLimitInformation
+	if limits.KillOnClose {
+		basic.LimitFlags |= windows.JOB_OBJECT_LIMIT_KILL_ON_JOB_CLOSE
+	}
+	if limits.DieOnUnhandledException {
+		basic.LimitFlags |= windows.JOB_OBJECT_LIMIT_DIE_ON_UNHANDLED_EXCEPTION
+	}
+	if limits.ProcessMemory > 0 {
+		basic.LimitFlags |= windows.JOB_OBJECT_LIMIT_PROCESS_MEMORY
+		info.ProcessMemoryLimit = limits.ProcessMemory
+	}
+	if limits.JobMemory > 0 {
+		basic.LimitFlags |= windows.JOB_OBJECT_LIMIT_JOB_MEMORY
+		info.JobMemoryLimit = limits.JobMemory
+	}
+	if limits.ActiveProcesses > 0 {
+		basic.LimitFlags |= windows.JOB_OBJECT_LIMIT_ACTIVE_PROCESS
+		basic.ActiveProcessLimit = limits.ActiveProcesses
+	}
+	if err := SetInformationJobObject(j.handle, windows.JobObjectExtendedLimitInformation,
+		unsafe.Pointer(&info), uint32(unsafe.Sizeof(info))); err != nil {
+		return err
+	}
+
+	ui := windows.JOBOBJECT_BASIC_UI_RESTRICTIONS{UIRestrictionsClass: limits.UIRestrictions}
+	if err := SetInformationJobObject(j.handle, windows.JobObjectBasicUIRestrictions,
+		unsafe.Pointer(&ui), uint32(unsafe.Sizeof(ui))); err != nil {
+		return err
+	}
+
+	// 未使用 CPU 速率限制时不调用，以便在 Windows 7 上设置其他限制
+	if limits.CPURate == 0 && !j.cpuRate {
+		return nil
+	}
+	var rate JOBOBJECT_CPU_RATE_CONTROL_INFORMATION
+	if limits.CPURate > 0 {
+		// CpuRate 是每 10000 个周期中可使用的周期数
+		rate.ControlFlags = JOB_OBJECT_CPU_RATE_CONTROL_ENABLE | JOB_OBJECT_CPU_RATE_CONTROL_HARD_CAP
+		rate.Value = max(1, uint32(limits.CPURate*100))
+	}
+	if err := SetInformationJobObject(j.handle, windows.JobObjectCpuRateControlInformation,
+		unsafe.Pointer(&rate), uint32(unsafe.Sizeof(rate))); err != nil {
+		return err
+	}
+	j.cpuRate = limits.CPURate > 0
+	return nil
+}
+
+// Accounting 返回作业的资源使用统计
+func (j *Job) Accounting() (JobAccounting, error) {
+	var acct JOBOBJECT_BASIC_AND_IO_ACCOUNTING_INFORMATION
+	if err := QueryInformationJobObject(j.handle, windows.JobObjectBasicAndIoAccountingInformation,
+		unsafe.Pointer(&acct), uint32(unsafe.Sizeof(acct)), nil); err != nil {
+		return JobAccounting{}, err
+	}
+	var limits windows.JOBOBJECT_EXTENDED_LIMIT_INFORMATION
+	if err := QueryInformationJobObject(j.handle, windows.JobObjectExtendedLimitInformation,
+		unsafe.Pointer(&limits), uint32(unsafe.Sizeof(limits)), nil); err != nil {
+		return JobAccounting{}, err
+	}
+	basic := acct.BasicInfo
+	return JobAccounting{
+		UserTime:            time.Duration(basic.TotalUserTime) * 100,
+		KernelTime:          time.Duration(basic.TotalKernelTime) * 100,
+		PageFaults:          basic.TotalPageFaultCount,
+		TotalProcesses:      basic.TotalProcesses,
+		ActiveProcesses:     basic.ActiveProcesses,
+		TerminatedProcesses: basic.TotalTerminatedProcesses,
+		IO:                  acct.IoInfo,
+		PeakProcessMemory:   limits.PeakProcessMemoryUsed,
+		PeakJobMemory:       limits.PeakJobMemoryUsed,
+	}, nil
+}
+
+// Processes 返回作业中正在运行的进程 ID
+func (j *Job) Processes() ([]uint32, error) {
+	const header = unsafe.Offsetof(JOBOBJECT_BASIC_PROCESS_ID_LIST{}.ProcessIdList)
+	n := uint32(16)
+	for range 16 {
+		buf := alignedBuffer(uint32(header) + n*uint32(unsafe.Sizeof(uintptr(0))))
+		list := (*JOBOBJECT_BASIC_PROCESS_ID_LIST)(unsafe.Pointer(&buf[0]))
+		err := QueryInformationJobObject(j.handle, windows.JobObjectBasicProcessIdList,
+			unsafe.Pointer(&buf[0]), uint32(len(buf)), nil)
+		if errors.Is(err, windows.ERROR_MORE_DATA) {
+			// 查询期间可能有新进程加入，多留一些余量
+			n = list.NumberOfAssignedProcesses + 16
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		ids := unsafe.Slice(&list.ProcessIdList[0], list.NumberOfProcessIdsInList)
+		pids := make([]uint32, len(ids))
+		for i, id := range ids {
+			pids[i] = uint32(id)
+		}
+		return pids, nil
+	}
+	return nil, ErrInsufficientBuffer
+}
+
+// Notify 将作业关联到完成端口并返回接收通知的通道，重复调用返回同一个通道。
+// 在 Assign 之前调用才能收到对应的 JobNewProcess。Close 后通道被关闭。
+// 调用方应持续读取通道，未读取的通知在内核中排队。
+func (j *Job) Notify() (<-chan JobEvent, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.events != nil {
+		return j.events, nil
+	}
+	if j.handle == 0 {
+		return nil, windows.ERROR_INVALID_HANDLE
+	}
+	port, err := windows.CreateIoCompletionPort(windows.InvalidHandle, 0, 0, 1)
+	if err != nil {
+		return nil, err
+	}
+	assoc := JOBOBJECT_ASSOCIATE_COMPLETION_PORT{CompletionKey: jobPortKey, CompletionPort: port}
+	if err := SetInformationJobObject(j.handle, windows.JobObjectAssociateCompletionPortInformation,
+		unsafe.Pointer(&assoc), uint32(unsafe.Sizeof(assoc))); err != nil {
+		CloseHandle(port)
+		return nil, err
+	}
+	j.port = port
+	j.events = make(chan JobEvent)
+	j.done = make(chan struct{})
+	j.exited = make(chan struct{})
+	go j.pump(port, j.events, j.done, j.exited)
+	return j.events, nil
+}
+
+// pump 从完成端口读取通知并发送到 events，收到停止消息或 done 关闭后关闭通道并退出。
+// 端口由 Close 在 pump 退出后关闭。
+func (j *Job) pump(port windows.Handle, events chan<- JobEvent, done <-chan struct{}, exited chan<- struct{}) {
+	defer close(exited)
+	defer close(events)
+	for {
+		var (
+			msg uint32
+			key uintptr
+			ov  *windows.Overlapped
+		)
+		if err := windows.GetQueuedCompletionStatus(port, &msg, &key, &ov, windows.INFINITE); err != nil || key != jobPortKey {
+			return
+		}
+		// 对进程相关的消息，lpOverlapped 的值就是进程 ID
+		pid := *(*uintptr)(unsafe.Pointer(&ov))
+		select {
+		case events <- JobEvent{Kind: JobEventKind(msg), PID: uint32(pid)}:
+		case <-done:
+			return
+		}
+	}
+}
+
+// Close 停止通知并关闭作业句柄。设置了 KillOnClose 且没有其他句柄时，作业中的进程被终止。重复调用返回 nil。
+func (j *Job) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.handle == 0 {
+		return nil
+	}
+	if j.port != 0 {
+		// 先投递停止消息唤醒 GetQueuedCompletionStatus，再关闭 done 唤醒阻塞在发送上的 pump；
+		// 等 pump 退出后才关闭端口，避免关闭正在等待或即将被投递的句柄
+		windows.PostQueuedCompletionStatus(j.port, 0, 0, nil)
+		close(j.done)
+		<-j.exited
+		CloseHandle(j.port)
+		j.port = 0
+	}
+	err := CloseHandle(j.handle)
+	j.handle = 0
+	return err
+}
@@ -0,0 +1,135 @@
+package xwindows
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"golang.org/x/sys/windows"
+)
+
+// nextJobEvent 等待下一个类型为 kind 的通知，跳过其他通知
+func nextJobEvent(t *testing.T, events <-chan JobEvent, kind JobEventKind) JobEvent {
+	t.Helper()
+	timeout := time.After(10 * time.Second)
+	for {
+		select {
+		case ev, ok := <-events:
+			if !ok {
+				t.Fatalf("events closed while waiting for %v", kind)
+			}
+			if ev.Kind == kind {
+				return ev
+			}
+		case <-timeout:
+			t.Fatalf("timed out waiting for %v", kind)
+		}
+	}
+}
+
+func startInJob(t *testing.T, job *Job, mode string) *Process {
+	t.Helper()
+	p, err := helperSpec(t, mode).WithCreationFlags(windows.CREATE_SUSPENDED).Start()
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	t.Cleanup(func() { p.Close() })
+	if err := job.Assign(p.Handle()); err != nil {
+		p.Kill()
+		t.Fatalf("Assign() error = %v", err)
+	}
+	if err := p.Resume(); err != nil {
+		t.Fatalf("Resume() error = %v", err)
+	}
+	return p
+}
+
+func TestJob(t *testing.T) {
+	job, err := NewJob()
+	if err != nil {
+		t.Fatalf("NewJob() error = %v", err)
+	}
+	defer job.Close()
+	limits := JobLimits{DieOnUnhandledException: true, ProcessMemory: 1 << 30, ActiveProcesses: 4,
+		UIRestrictions: windows.JOB_OBJECT_UILIMIT_EXITWINDOWS}
+	if err := job.SetLimits(limits); err != nil {
+		t.Fatalf("SetLimits() error = %v", err)
+	}
+	events, err := job.Notify()
+	if err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+
+	p := startInJob(t, job, "sleep")
+	if ev := nextJobEvent(t, events, JobNewProcess); ev.PID != p.Pid {
+		t.Errorf("JobNewProcess PID = %d, want %d", ev.PID, p.Pid)
+	}
+	pids, err := job.Processes()
+	if err != nil || !slices.Contains(pids, p.Pid) {
+		t.Errorf("Processes() = %v, %v, want to contain %d", pids, err, p.Pid)
+	}
+
+	if err := job.Terminate(3); err != nil {
+		t.Fatalf("Terminate() error = %v", err)
+	}
+	if ev := nextJobEvent(t, events, JobAbnormalExit); ev.PID != p.Pid {
+		t.Errorf("JobAbnormalExit PID = %d, want %d", ev.PID, p.Pid)
+	}
+	nextJobEvent(t, events, JobActiveProcessZero)
+	if code, err := p.ExitCode(); err != nil || code != 3 {
+		t.Errorf("ExitCode() = %d, %v, want 3", code, err)
+	}
+
+	acct, err := job.Accounting()
+	if err != nil {
+		t.Fatalf("Accounting() error = %v", err)
+	}
+	if acct.TotalProcesses != 1 || acct.ActiveProcesses != 0 || acct.PeakProcessMemory == 0 {
+		t.Errorf("Accounting() = %+v", acct)
+	}
+
+	if err := job.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+	if _, ok := <-events; ok {
+		t.Error("events not closed after Close")
+	}
+}
+
+func TestJobKillOnClose(t *testing.T) {
+	job, err := NewJob()
+	if err != nil {
+		t.Fatalf("NewJob() error = %v", err)
+	}
+	if err := job.SetLimits(JobLimits{KillOnClose: true}); err != nil {
+		job.Close()
+		t.Fatalf("SetLimits() error = %v", err)
+	}
+	p := startInJob(t, job, "sleep")
+	job.Close()
+
+	event, err := windows.WaitForSingleObject(p.Handle(), 10_000)
+	if err != nil || event != windows.WAIT_OBJECT_0 {
+		t.Fatalf("process still running after job closed: %d, %v", event, err)
+	}
+}
+
+func TestJobSetLimitsInvalid(t *testing.T) {
+	job, err := NewJob()
+	if err != nil {
+		t.Fatalf("NewJob() error = %v", err)
+	}
+	defer job.Close()
+	for _, rate := range []float64{-1, 100.5} {
+		if err := job.SetLimits(JobLimits{CPURate: rate}); !errors.Is(err, ErrInvalidParameter) {
+			t.Errorf("SetLimits(CPURate: %v) error = %v, want %v", rate, err, ErrInvalidParameter)
+		}
+	}
+	if err := job.SetLimits(JobLimits{CPURate: 50}); err != nil {
+		t.Errorf("SetLimits(CPURate: 50) error = %v", err)
+	}
+	if err := job.SetLimits(JobLimits{}); err != nil {
+		t.Errorf("SetLimits() clearing CPU rate error = %v", err)
+	}
+}
@@ -0,0 +1,91 @@
+// Package lcid 在 Windows 区域设置标识符 (LCID) 与 BCP-47 区域设置名称之间转换。
+//
+// 映射表嵌入在包中，不依赖 Windows API，可以在任何平台上解码日志中的 LCID。
+// 表中只包含 [MS-LCID] 分配的固定 LCID；自定义区域设置使用的临时 LCID（0x2000、0x2400 等）
+// 在不同计算机上含义不同，无法离线解码。
+package lcid
+
+import (
+	"bufio"
+	_ "embed"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// 特殊的 LCID
+const (
+	Invariant     = 0x007F // 固定区域设置，名称为空字符串
+	UserDefault   = 0x0400 // LOCALE_USER_DEFAULT
+	SystemDefault = 0x0800 // LOCALE_SYSTEM_DEFAULT
+	CustomDefault = 0x0C00 // LOCALE_CUSTOM_DEFAULT
+	Unspecified   = 0x1000 // LOCALE_CUSTOM_UNSPECIFIED，没有分配 LCID 的区域设置
+)
+
+//go:embed lcid.txt
+var table string
+
+type mapping struct {
+	names map[uint32]string
+	ids   map[string]uint32 // 键为小写名称
+}
+
+var load = sync.OnceValue(func() *mapping {
+	m := &mapping{names: make(map[uint32]string), ids: make(map[string]uint32)}
+	s := bufio.NewScanner(strings.NewReader(table))
+	for s.Scan() {
+		line := s.Text()
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		hex, name, ok := strings.Cut(line, " ")
+		id, err := strconv.ParseUint(hex, 16, 32)
+		if !ok || err != nil {
+			panic("lcid: malformed table line: " + line)
+		}
+		m.names[uint32(id)] = name
+		m.ids[strings.ToLower(name)] = uint32(id)
+	}
+	return m
+})
+
+// Name 返回 LCID 对应的区域设置名称，如 0x0409 返回 "en-US"。
+// 带排序标识符的 LCID 返回带排序后缀的名称（如 0x10407 返回 "de-DE_phoneb"），
+// 表中没有的排序标识符回退到语言标识符本身的名称。
+func Name(id uint32) (string, bool) {
+	if id == Invariant {
+		return "", true
+	}
+	m := load()
+	if name, ok := m.names[id]; ok {
+		return name, true
+	}
+	// LCID 的位 16 到 19 是排序标识符，位 0 到 15 是语言标识符
+	name, ok := m.names[id&0xFFFF]
+	return name, ok
+}
+
+// ID 返回区域设置名称对应的 LCID，名称不区分大小写，也接受 POSIX 形式的 "en_US"。
+// 空字符串返回 Invariant。
+func ID(name string) (uint32, bool) {
+	if name == "" {
+		return Invariant, true
+	}
+	key := strings.ToLower(name)
+	// "de-DE_phoneb" 中的 "_" 是排序后缀，只有不含 "-" 的名称才按 POSIX 形式处理
+	if !strings.Contains(key, "-") {
+		key = strings.ReplaceAll(key, "_", "-")
+	}
+	id, ok := load().ids[key]
+	return id, ok
+}
+
+// LangID 返回 LCID 的语言标识符部分
+func LangID(id uint32) uint16 {
+	return uint16(id)
+}
+
+// SortID 返回 LCID 的排序标识符部分
+func SortID(id uint32) uint8 {
+	return uint8(id >> 16 & 0xF)
+}
@@ -0,0 +1,99 @@
+package lcid
+
+import (
+	"bufio"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestName(t *testing.T) {
+	tests := []struct {
+		id   uint32
+		want string
+		ok   bool
+	}{
+		{0x0409, "en-US", true},
+		{0x0804, "zh-CN", true},
+		{0x0004, "zh-Hans", true},
+		{0x7c04, "zh-Hant", true},
+		{0x241a, "sr-Latn-RS", true},
+		{0x7804, "zh", true},
+		{0x0c51, "dz-BT", true},
+		{0x105f, "tzm-Tfng-MA", true},
+		{0x0859, "sd-Arab-PK", true},
+		{0x10407, "de-DE_phoneb", true},
+		{0x50409, "en-US", true}, // 未知排序标识符回退到语言标识符
+		{Invariant, "", true},
+		{0x2000, "", false}, // 临时 LCID
+		{UserDefault, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(strconv.FormatUint(uint64(tt.id), 16), func(t *testing.T) {
+			got, ok := Name(tt.id)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("Name(%#x) = %q, %v; want %q, %v", tt.id, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestID(t *testing.T) {
+	tests := []struct {
+		name string
+		want uint32
+		ok   bool
+	}{
+		{"en-US", 0x0409, true},
+		{"EN-us", 0x0409, true},
+		{"en_US", 0x0409, true},
+		{"de-DE_phoneb", 0x10407, true},
+		{"de-de_PHONEB", 0x10407, true},
+		{"nb-NO", 0x0414, true},
+		{"", Invariant, true},
+		{"xx-YY", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ID(tt.name)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("ID(%q) = %#x, %v; want %#x, %v", tt.name, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+// TestTableUnique 检查表中的 LCID 和名称都没有重复，从而 Name 与 ID 互为逆映射
+func TestTableUnique(t *testing.T) {
+	ids := make(map[uint32]string)
+	names := make(map[string]uint32)
+	s := bufio.NewScanner(strings.NewReader(table))
+	for s.Scan() {
+		line := s.Text()
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		hex, name, _ := strings.Cut(line, " ")
+		id, err := strconv.ParseUint(hex, 16, 32)
+		if err != nil {
+			t.Fatalf("bad line %q", line)
+		}
+		if prev, ok := ids[uint32(id)]; ok {
+			t.Errorf("LCID %#x maps to both %q and %q", id, prev, name)
+		}
+		key := strings.ToLower(name)
+		if prev, ok := names[key]; ok {
+			t.Errorf("name %q maps to both %#x and %#x", name, prev, id)
+		}
+		ids[uint32(id)], names[key] = name, uint32(id)
+		if got, _ := ID(name); got != uint32(id) {
+			t.Errorf("ID(Name(%#x)) = %#x", id, got)
+		}
+	}
+}
+
+func TestParts(t *testing.T) {
+	if LangID(0x40411) != 0x0411 || SortID(0x40411) != 4 {
+		t.Errorf("LangID/SortID(0x40411) = %#x, %d", LangID(0x40411), SortID(0x40411))
+	}
+}
@@ -0,0 +1,161 @@
+package xwindows
+
+import (
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// Locale 是由名称（如 "zh-CN"）标识的区域设置，属性通过 GetLocaleInfoEx 查询。
+// 零值表示固定区域设置 (LOCALE_NAME_INVARIANT)。
+//
+//	l, err := xwindows.UserLocale()
+//	if err != nil {
+//		return err
+//	}
+//	sep, _ := l.DecimalSeparator()
+//	fmt.Println(l.Name(), sep)
+type Locale struct {
+	name string
+}
+
+// UserLocale 返回当前用户的默认区域设置
+func UserLocale() (Locale, error) {
+	buf := make([]uint16, LOCALE_NAME_MAX_LENGTH)
+	n, err := GetUserDefaultLocaleName(&buf[0], int32(len(buf)))
+	if err != nil {
+		return Locale{}, err
+	}
+	return Locale{windows.UTF16ToString(buf[:n])}, nil
+}
+
+// SystemLocale 返回系统默认区域设置
+func SystemLocale() (Locale, error) {
+	return LocaleByName(LOCALE_NAME_SYSTEM_DEFAULT)
+}
+
+// LocaleByName 按名称查找区域设置，返回的 Locale 使用规范名称（如 "en-us" 规范化为 "en-US"）。
+// 系统不支持该名称时返回 ERROR_INVALID_PARAMETER。
+func LocaleByName(name string) (Locale, error) {
+	canonical, err := Locale{name}.Info(LOCALE_SNAME)
+	if err != nil {
+		return Locale{}, err
+	}
+	return Locale{canonical}, nil
+}
+
+// LocaleByLCID 按区域设置标识符查找区域设置，接受非特定区域设置（如 0x0009 "en"）
+func LocaleByLCID(lcid uint32) (Locale, error) {
+	buf := make([]uint16, LOCALE_NAME_MAX_LENGTH)
+	n, err := LCIDToLocaleName(lcid, &buf[0], int32(len(buf)), LOCALE_ALLOW_NEUTRAL_NAMES)
+	if err != nil {
+		return Locale{}, err
+	}
+	return Locale{windows.UTF16ToString(buf[:n])}, nil
+}
+
+// Name 返回区域设置名称
+func (l Locale) Name() string {
+	return l.name
+}
+
+func (l Locale) String() string {
+	return l.name
+}
+
+// LCID 返回区域设置标识符，没有分配 LCID 的区域设置返回 LOCALE_CUSTOM_UNSPECIFIED (0x1000)
+func (l Locale) LCID() (uint32, error) {
+	return LocaleNameToLCID(l.name, LOCALE_ALLOW_NEUTRAL_NAMES)
+}
+
+// namePtr 返回传给 GetLocaleInfoEx 的名称，空名称传递空字符串（固定区域设置）而不是 NULL（用户默认）
+func (l Locale) namePtr() (*uint16, error) {
+	return windows.UTF16PtrFromString(l.name)
+}
+
+// Info 返回字符串类型的区域设置信息，lctype 为 LOCALE_S* 常量
+func (l Locale) Info(lctype uint32) (string, error) {
+	name, err := l.namePtr()
+	if err != nil {
+		return "", err
+	}
+	n, err := GetLocaleInfoEx(name, lctype, nil, 0)
+	if err != nil {
+		return "", err
+	}
+	buf := make([]uint16, n)
+	if n, err = GetLocaleInfoEx(name, lctype, &buf[0], n); err != nil {
+		return "", err
+	}
+	return windows.UTF16ToString(buf[:n]), nil
+}
+
+// Number 返回数值类型的区域设置信息，lctype 为 LOCALE_I* 常量
+func (l Locale) Number(lctype uint32) (uint32, error) {
+	name, err := l.namePtr()
+	if err != nil {
+		return 0, err
+	}
+	var v uint32
+	// 缓冲区大小以字符为单位，一个 DWORD 占两个字符
+	if _, err := GetLocaleInfoEx(name, lctype|LOCALE_RETURN_NUMBER, (*uint16)(unsafe.Pointer(&v)), 2); err != nil {
+		return 0, err
+	}
+	return v, nil
+}
+
+// DisplayName 返回以用户界面语言表示的名称，如 "中文(简体，中国)"
+func (l Locale) DisplayName() (string, error) {
+	return l.Info(LOCALE_SLOCALIZEDDISPLAYNAME)
+}
+
+// EnglishName 返回英语名称，如 "Chinese (Simplified, China)"
+func (l Locale) EnglishName() (string, error) {
+	return l.Info(LOCALE_SENGLISHDISPLAYNAME)
+}
+
+// NativeName 返回以区域设置自身语言表示的名称，如 "中文(中国)"
+func (l Locale) NativeName() (string, error) {
+	return l.Info(LOCALE_SNATIVEDISPLAYNAME)
+}
+
+// DecimalSeparator 返回小数分隔符
+func (l Locale) DecimalSeparator() (string, error) {
+	return l.Info(LOCALE_SDECIMAL)
+}
+
+// ThousandSeparator 返回千位分隔符
+func (l Locale) ThousandSeparator() (string, error) {
+	return l.Info(LOCALE_STHOUSAND)
+}
+
+// ListSeparator 返回列表分隔符
+func (l Locale) ListSeparator() (string, error) {
+	return l.Info(LOCALE_SLIST)
+}
+
+// FirstDayOfWeek 返回一周的第一天
+func (l Locale) FirstDayOfWeek() (time.Weekday, error) {
+	v, err := l.Number(LOCALE_IFIRSTDAYOFWEEK)
+	if err != nil {
+		return 0, err
+	}
+	// LOCALE_IFIRSTDAYOFWEEK 以星期一为 0，time.Weekday 以星期日为 0
+	return time.Weekday((v + 1) % 7), nil
+}
+
+// ANSICodePage 返回默认 ANSI 代码页，没有 ANSI 代码页的区域设置（仅 Unicode）返回 0 (CP_ACP)
+func (l Locale) ANSICodePage() (uint32, error) {
+	return l.Number(LOCALE_IDEFAULTANSICODEPAGE)
+}
+
+// OEMCodePage 返回默认 OEM 代码页，仅 Unicode 的区域设置返回 1 (CP_OEMCP)
+func (l Locale) OEMCodePage() (uint32, error) {
+	return l.Number(LOCALE_IDEFAULTCODEPAGE)
+}
+
+// Calendar 返回默认日历 CAL_*
+func (l Locale) Calendar() (uint32, error) {
+	return l.Number(LOCALE_ICALENDARTYPE)
+}
@@ -0,0 +1,129 @@
+package xwindows
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/C1ph3rX13/xwindows/lcid"
+	"golang.org/x/sys/windows"
+)
+
+func TestLocaleInfo(t *testing.T) {
+	l, err := LocaleByName("en-us")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.Name() != "en-US" {
+		t.Errorf("Name() = %q, want en-US", l.Name())
+	}
+	str := []struct {
+		name string
+		get  func() (string, error)
+		want string
+	}{
+		{"DecimalSeparator", l.DecimalSeparator, "."},
+		{"ThousandSeparator", l.ThousandSeparator, ","},
+		{"ListSeparator", l.ListSeparator, ","},
+		{"EnglishName", l.EnglishName, "English (United States)"},
+	}
+	for _, tt := range str {
+		if got, err := tt.get(); err != nil || got != tt.want {
+			t.Errorf("%s() = %q, %v; want %q", tt.name, got, err, tt.want)
+		}
+	}
+	num := []struct {
+		name string
+		get  func() (uint32, error)
+		want uint32
+	}{
+		{"ANSICodePage", l.ANSICodePage, 1252},
+		{"OEMCodePage", l.OEMCodePage, 437},
+		{"Calendar", l.Calendar, CAL_GREGORIAN},
+		{"LCID", l.LCID, 0x0409},
+	}
+	for _, tt := range num {
+		if got, err := tt.get(); err != nil || got != tt.want {
+			t.Errorf("%s() = %d, %v; want %d", tt.name, got, err, tt.want)
+		}
+	}
+	if got, err := l.FirstDayOfWeek(); err != nil || got != time.Sunday {
+		t.Errorf("FirstDayOfWeek() = %v, %v; want Sunday", got, err)
+	}
+	if got, err := (Locale{"de-DE"}).FirstDayOfWeek(); err != nil || got != time.Monday {
+		t.Errorf("de-DE FirstDayOfWeek() = %v, %v; want Monday", got, err)
+	}
+}
+
+func TestLocaleByNameInvalid(t *testing.T) {
+	if _, err := LocaleByName("not a locale!"); !errors.Is(err, windows.ERROR_INVALID_PARAMETER) {
+		t.Fatalf("LocaleByName() error = %v, want ERROR_INVALID_PARAMETER", err)
+	}
+}
+
+func TestUserLocale(t *testing.T) {
+	l, err := UserLocale()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LocaleByName(l.Name()); err != nil {
+		t.Fatalf("LocaleByName(%q) = %v", l.Name(), err)
+	}
+}
+
+// TestLCIDTable 将离线 LCID 表与系统的转换结果对比，系统不再支持的 LCID 跳过
+func TestLCIDTable(t *testing.T) {
+	for _, id := range []uint32{0x0409, 0x0804, 0x0004, 0x7c04, 0x0c0a, 0x241a, 0x0814, 0x10407, 0x0492} {
+		want, ok := lcid.Name(id)
+		if !ok {
+			t.Fatalf("lcid.Name(%#x) not found", id)
+		}
+		l, err := LocaleByLCID(id)
+		if err != nil {
+			t.Logf("LocaleByLCID(%#x): %v", id, err)
+			continue
+		}
+		if !strings.EqualFold(l.Name(), want) {
+			t.Errorf("LCID %#x: system %q, table %q", id, l.Name(), want)
+		}
+		if got, err := l.LCID(); err == nil && got != id {
+			t.Errorf("LCID(%q) = %#x, want %#x", l.Name(), got, id)
+		}
+	}
+}
+
+// TestLCIDTableSystem 遍历系统的所有区域设置（包括备用排序），检查分配了固定 LCID 的区域设置都在离线表中，
+// 且 lcid.Name 和 lcid.ID 与 LCIDToLocaleName 的结果一致
+func TestLCIDTableSystem(t *testing.T) {
+	for _, flags := range []uint32{LOCALE_ALL, LOCALE_ALTERNATE_SORTS} {
+		for name, err := range SystemLocales(flags) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			if name == "" {
+				continue
+			}
+			id, err := LocaleNameToLCID(name, LOCALE_ALLOW_NEUTRAL_NAMES)
+			if err != nil {
+				t.Errorf("LocaleNameToLCID(%q): %v", name, err)
+				continue
+			}
+			// 没有分配 LCID 的区域设置和自定义区域设置的临时 LCID (0x2000-0x3C00) 不在表中
+			if lang := id & 0xFFFF; lang == lcid.Unspecified || lang&0x3FF == 0 && lang >= 0x2000 && lang <= 0x3C00 {
+				continue
+			}
+			l, err := LocaleByLCID(id)
+			if err != nil {
+				t.Errorf("LocaleByLCID(%#x) for %q: %v", id, name, err)
+				continue
+			}
+			if got, ok := lcid.Name(id); !ok || !strings.EqualFold(got, l.Name()) {
+				t.Errorf("lcid.Name(%#x) = %q, %v; system %q", id, got, ok, l.Name())
+			}
+			if got, ok := lcid.ID(l.Name()); !ok || got != id {
+				t.Errorf("lcid.ID(%q) = %#x, %v; system %#x", l.Name(), got, ok, id)
+			}
+		}
+	}
+}
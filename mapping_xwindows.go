@@ -0,0 +1,285 @@
+package xwindows
+
+import (
+	"errors"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// MapMode 是 MapFile 的访问方式
+type MapMode int
+
+const (
+	MapReadOnly    MapMode = iota // 只读映射，写入视图会触发访问冲突
+	MapReadWrite                  // 读写映射，写入通过 Flush 或系统回写到文件
+	MapCopyOnWrite                // 写时复制，写入只对当前进程可见，不会回写到文件
+)
+
+// MapOption 是文件映射的可选设置
+type MapOption func(*mapConfig)
+
+type mapConfig struct {
+	size       int64
+	largePages bool
+	readOnly   bool
+	createNew  bool
+	inherit    bool
+}
+
+// MapSize 指定映射大小。
+// MapFile 以 MapReadWrite 打开时，文件小于 size 会被扩展；OpenSharedMemory 只映射前 size 字节。
+func MapSize(size int64) MapOption {
+	return func(c *mapConfig) { c.size = size }
+}
+
+// MapLargePages 使用大页创建共享内存，大小向上取整到 GetLargePageMinimum 的倍数。
+// 调用方的令牌必须已启用 SeLockMemoryPrivilege，否则返回 ErrPrivilegeRequired。
+func MapLargePages() MapOption {
+	return func(c *mapConfig) { c.largePages = true }
+}
+
+// MapReadOnlyView 以只读方式映射共享内存的视图
+func MapReadOnlyView() MapOption {
+	return func(c *mapConfig) { c.readOnly = true }
+}
+
+// MapCreateNew 要求 CreateSharedMemory 创建新对象，同名对象已存在时返回 ErrResourceExists
+func MapCreateNew() MapOption {
+	return func(c *mapConfig) { c.createNew = true }
+}
+
+// MapInheritable 使文件映射句柄可被子进程继承
+func MapInheritable() MapOption {
+	return func(c *mapConfig) { c.inherit = true }
+}
+
+// Mapping 是映射到当前进程地址空间的文件或共享内存视图
+type Mapping struct {
+	file     windows.Handle // MapFile 打开的文件，共享内存为 0
+	section  windows.Handle
+	addr     uintptr
+	data     []byte
+	writable bool
+}
+
+// Bytes 返回映射视图。Close 之后访问返回的切片会触发访问冲突。
+func (m *Mapping) Bytes() []byte {
+	return m.data
+}
+
+// Len 返回映射视图的字节数
+func (m *Mapping) Len() int {
+	return len(m.data)
+}
+
+// Handle 返回文件映射对象的句柄，可用于 DuplicateHandle 或子进程继承
+func (m *Mapping) Handle() windows.Handle {
+	return m.section
+}
+
+// Flush 将视图中修改过的页面写入文件，对文件映射还会等待数据写入磁盘。只读映射直接返回 nil。
+func (m *Mapping) Flush() error {
+	if m.addr == 0 || !m.writable {
+		return nil
+	}
+	if err := FlushViewOfFile(m.addr, 0); err != nil {
+		return err
+	}
+	if m.file != 0 {
+		return windows.FlushFileBuffers(m.file)
+	}
+	return nil
+}
+
+// Close 取消映射视图并关闭句柄，不会刷新文件，需要时先调用 Flush。重复调用返回 nil。
+func (m *Mapping) Close() error {
+	var errs []error
+	if m.addr != 0 {
+		errs = append(errs, UnmapViewOfFile(m.addr))
+		m.addr, m.data = 0, nil
+	}
+	if m.section != 0 {
+		errs = append(errs, CloseHandle(m.section))
+		m.section = 0
+	}
+	if m.file != 0 {
+		errs = append(errs, CloseHandle(m.file))
+		m.file = 0
+	}
+	return errors.Join(errs...)
+}
+
+// mapView 映射整个文件映射对象的 size 字节，size 为 0 时映射到对象末尾并通过 VirtualQueryEx 获取大小
+func (m *Mapping) mapView(access uint32, size uintptr) error {
+	addr, err := MapViewOfFile(m.section, access, 0, 0, size)
+	if err != nil {
+		return err
+	}
+	m.addr = addr
+	if size == 0 {
+		var mbi windows.MemoryBasicInformation
+		if _, err := VirtualQueryEx(windows.CurrentProcess(), addr, &mbi, unsafe.Sizeof(mbi)); err != nil {
+			return err
+		}
+		size = mbi.RegionSize
+	}
+	m.data = unsafe.Slice(*(**byte)(unsafe.Pointer(&m.addr)), size)
+	return nil
+}
+
+func newMapConfig(opts []MapOption) mapConfig {
+	var c mapConfig
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+func securityAttributes(inherit bool) *windows.SecurityAttributes {
+	if !inherit {
+		return nil
+	}
+	sa := &windows.SecurityAttributes{InheritHandle: 1}
+	sa.Length = uint32(unsafe.Sizeof(*sa))
+	return sa
+}
+
+// MapFile 将文件映射到内存，映射大小为文件大小（或 MapSize 指定的大小）。
+// 空文件无法映射，返回的 Mapping 的 Bytes 为 nil。
+//
+//	m, err := xwindows.MapFile(`D:\index\words.idx`, xwindows.MapReadOnly)
+//	if err != nil {
+//		return err
+//	}
+//	defer m.Close()
+//	data := m.Bytes()
+func MapFile(path string, mode MapMode, opts ...MapOption) (*Mapping, error) {
+	c := newMapConfig(opts)
+	var access, share, protect, view uint32
+	switch mode {
+	case MapReadOnly:
+		access, share, protect, view = windows.GENERIC_READ, windows.FILE_SHARE_READ, windows.PAGE_READONLY, windows.FILE_MAP_READ
+	case MapReadWrite:
+		access, share, protect, view = windows.GENERIC_READ|windows.GENERIC_WRITE, windows.FILE_SHARE_READ, windows.PAGE_READWRITE, windows.FILE_MAP_WRITE
+	case MapCopyOnWrite:
+		access, share, protect, view = windows.GENERIC_READ, windows.FILE_SHARE_READ, windows.PAGE_WRITECOPY, windows.FILE_MAP_COPY
+	default:
+		return nil, ErrInvalidParameter
+	}
+	if c.size < 0 || (c.size > 0 && mode != MapReadWrite) {
+		return nil, ErrInvalidSize
+	}
+
+	name, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return nil, err
+	}
+	// 只有 MapReadWrite 会在文件不存在时创建文件
+	disposition := uint32(windows.OPEN_EXISTING)
+	if mode == MapReadWrite {
+		disposition = windows.OPEN_ALWAYS
+	}
+	file, err := windows.CreateFile(name, access, share, securityAttributes(c.inherit), disposition, windows.FILE_ATTRIBUTE_NORMAL, 0)
+	if err != nil {
+		return nil, err
+	}
+	m := &Mapping{file: file, writable: mode == MapReadWrite}
+
+	var info windows.ByHandleFileInformation
+	if err := windows.GetFileInformationByHandle(file, &info); err != nil {
+		m.Close()
+		return nil, err
+	}
+	size := max(int64(info.FileSizeHigh)<<32|int64(info.FileSizeLow), c.size)
+	if size == 0 {
+		return m, nil
+	}
+	m.section, err = CreateFileMappingW(file, securityAttributes(c.inherit), protect, uint32(size>>32), uint32(size), nil)
+	if err != nil {
+		m.Close()
+		return nil, err
+	}
+	if err := m.mapView(view, uintptr(size)); err != nil {
+		m.Close()
+		return nil, err
+	}
+	return m, nil
+}
+
+// CreateSharedMemory 创建或打开由页面文件支持的命名共享内存，name 为空时创建未命名对象。
+// 名称可以带 Local\ 或 Global\ 前缀；创建 Global\ 对象需要 SeCreateGlobalPrivilege。
+// 同名对象已存在时打开现有对象（其大小不变），指定 MapCreateNew 时返回 ErrResourceExists。
+func CreateSharedMemory(name string, size int64, opts ...MapOption) (*Mapping, error) {
+	c := newMapConfig(opts)
+	if size <= 0 {
+		return nil, ErrInvalidSize
+	}
+	protect := uint32(windows.PAGE_READWRITE)
+	view := uint32(windows.FILE_MAP_WRITE)
+	if c.largePages {
+		minimum := int64(GetLargePageMinimum())
+		if minimum == 0 {
+			return nil, ErrNotImplemented
+		}
+		size = (size + minimum - 1) / minimum * minimum
+		protect |= SEC_COMMIT | SEC_LARGE_PAGES
+		view |= FILE_MAP_LARGE_PAGES
+	}
+	if c.readOnly {
+		view = view&^windows.FILE_MAP_WRITE | windows.FILE_MAP_READ
+	}
+
+	var namep *uint16
+	if name != "" {
+		var err error
+		if namep, err = windows.UTF16PtrFromString(name); err != nil {
+			return nil, err
+		}
+	}
+	section, err := CreateFileMappingW(windows.InvalidHandle, securityAttributes(c.inherit), protect, uint32(size>>32), uint32(size), namep)
+	switch {
+	case errors.Is(err, windows.ERROR_ALREADY_EXISTS):
+		if c.createNew {
+			CloseHandle(section)
+			return nil, ErrResourceExists
+		}
+		// 现有对象的大小可能与 size 不同，映射整个对象
+		size = 0
+	case errors.Is(err, windows.ERROR_PRIVILEGE_NOT_HELD):
+		return nil, ErrPrivilegeRequired
+	case err != nil:
+		return nil, err
+	}
+	m := &Mapping{section: section, writable: !c.readOnly}
+	if err := m.mapView(view, uintptr(size)); err != nil {
+		m.Close()
+		return nil, err
+	}
+	return m, nil
+}
+
+// OpenSharedMemory 打开 CreateSharedMemory 创建的命名共享内存并映射整个对象（或 MapSize 指定的前几个字节）
+func OpenSharedMemory(name string, opts ...MapOption) (*Mapping, error) {
+	c := newMapConfig(opts)
+	access := uint32(windows.FILE_MAP_WRITE)
+	if c.readOnly {
+		access = windows.FILE_MAP_READ
+	}
+	view := access
+	if c.largePages {
+		view |= FILE_MAP_LARGE_PAGES
+	}
+	section, err := OpenFileMappingW(access, c.inherit, name)
+	if errors.Is(err, windows.ERROR_FILE_NOT_FOUND) {
+		return nil, ErrResourceNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	m := &Mapping{section: section, writable: !c.readOnly}
+	if err := m.mapView(view, uintptr(c.size)); err != nil {
+		m.Close()
+		return nil, err
+	}
+	return m, nil
+}
@@ -0,0 +1,102 @@
+package xwindows
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMapFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "index.dat")
+	want := bytes.Repeat([]byte("xwindows"), 1024)
+	if err := os.WriteFile(path, want, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		mode MapMode
+	}{
+		{"read only", MapReadOnly},
+		{"read write", MapReadWrite},
+		{"copy on write", MapCopyOnWrite},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := MapFile(path, tt.mode)
+			if err != nil {
+				t.Fatalf("MapFile() error = %v", err)
+			}
+			defer m.Close()
+			if !bytes.Equal(m.Bytes(), want) {
+				t.Fatalf("MapFile() Bytes() differ from file contents")
+			}
+			if err := m.Flush(); err != nil {
+				t.Errorf("Flush() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestMapFileReadWriteGrows(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "grow.dat")
+	m, err := MapFile(path, MapReadWrite, MapSize(4096))
+	if err != nil {
+		t.Fatalf("MapFile() error = %v", err)
+	}
+	copy(m.Bytes(), "hello")
+	if err := m.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if err := m.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if err := m.Close(); err != nil {
+		t.Errorf("second Close() error = %v", err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 4096 || !bytes.HasPrefix(got, []byte("hello")) {
+		t.Errorf("file = %d bytes %q..., want 4096 bytes starting with hello", len(got), got[:min(len(got), 5)])
+	}
+}
+
+func TestSharedMemory(t *testing.T) {
+	name := fmt.Sprintf(`Local\xwindows-test-%d`, os.Getpid())
+	owner, err := CreateSharedMemory(name, 1000, MapCreateNew())
+	if err != nil {
+		t.Fatalf("CreateSharedMemory() error = %v", err)
+	}
+	defer owner.Close()
+	if owner.Len() != 1000 {
+		t.Errorf("Len() = %d, want 1000", owner.Len())
+	}
+
+	if _, err := CreateSharedMemory(name, 1000, MapCreateNew()); !errors.Is(err, ErrResourceExists) {
+		t.Errorf("CreateSharedMemory(MapCreateNew) on existing name error = %v, want ErrResourceExists", err)
+	}
+
+	reader, err := OpenSharedMemory(name, MapReadOnlyView())
+	if err != nil {
+		t.Fatalf("OpenSharedMemory() error = %v", err)
+	}
+	defer reader.Close()
+	// 打开时映射到对象末尾，大小按页向上取整
+	if reader.Len() < owner.Len() {
+		t.Errorf("OpenSharedMemory() Len() = %d, want >= %d", reader.Len(), owner.Len())
+	}
+	copy(owner.Bytes(), "shared")
+	if !bytes.HasPrefix(reader.Bytes(), []byte("shared")) {
+		t.Errorf("reader does not observe writes through owner view")
+	}
+
+	if _, err := OpenSharedMemory(name + "-missing"); !errors.Is(err, ErrResourceNotFound) {
+		t.Errorf("OpenSharedMemory(missing) error = %v, want ErrResourceNotFound", err)
+	}
+}
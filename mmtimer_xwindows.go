@@ -0,0 +1,229 @@
+package xwindows
+
+import (
+	"errors"
+	"sync"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// TimerCaps 返回系统计时器支持的最小和最大周期 (timeGetDevCaps)
+func TimerCaps() (minPeriod, maxPeriod time.Duration, err error) {
+	var caps TIMECAPS
+	if err := TimeGetDevCaps(&caps, uint32(unsafe.Sizeof(caps))); err != nil {
+		return 0, 0, err
+	}
+	return time.Duration(caps.PeriodMin) * time.Millisecond, time.Duration(caps.PeriodMax) * time.Millisecond, nil
+}
+
+// BeginTimerPeriod 请求系统计时器分辨率不低于 period（向上取整到毫秒），影响 Sleep 和等待函数的精度。
+// 系统使用所有进程请求中最小的周期，调用返回的 end 撤销本次请求后即恢复之前的分辨率，重复调用 end 返回 nil。
+//
+//	end, err := xwindows.BeginTimerPeriod(time.Millisecond)
+//	if err != nil {
+//		return err
+//	}
+//	defer end()
+func BeginTimerPeriod(period time.Duration) (end func() error, err error) {
+	if period <= 0 {
+		return nil, ErrInvalidParameter
+	}
+	ms := uint32((period + time.Millisecond - 1) / time.Millisecond)
+	if err := TimeBeginPeriod(ms); err != nil {
+		return nil, err
+	}
+	var once sync.Once
+	return func() error {
+		var err error
+		once.Do(func() { err = TimeEndPeriod(ms) })
+		return err
+	}, nil
+}
+
+// Tick 是 Ticker 的一次触发
+type Tick struct {
+	Time   time.Time     // 处理本次触发的时间
+	Drift  time.Duration // 相对计划时间的偏差，为负表示提前
+	Missed int           // 因延迟超过一个周期而跳过的周期数
+}
+
+// TickerStats 是 Ticker 的漂移统计
+type TickerStats struct {
+	Ticks     uint64        // 已处理的触发次数
+	Missed    uint64        // 跳过的周期总数
+	Dropped   uint64        // C 未被及时接收而丢弃的触发次数
+	MeanDrift time.Duration // 偏差的平均值
+	MaxDrift  time.Duration // 偏差绝对值的最大值
+}
+
+// Ticker 以固定周期在 C 上发送 Tick，周期按启动时间对齐，不会累积漂移。
+// 优先使用高精度可等待计时器（Windows 10 1803 及以上版本），否则回退到 timeSetEvent 多媒体计时器，
+// 此时周期取整到毫秒，并在 Ticker 运行期间将系统计时器分辨率提高到最小周期。
+//
+// 与 time.Ticker 相同，C 的缓冲区为 1，接收不及时的触发被丢弃并计入 Dropped。
+//
+//	t, err := xwindows.NewTicker(time.Millisecond)
+//	if err != nil {
+//		return err
+//	}
+//	defer t.Stop()
+//	for tick := range t.C {
+//		render(tick.Time)
+//	}
+type Ticker struct {
+	C <-chan Tick
+
+	period    time.Duration
+	timer     windows.Handle // 高精度可等待计时器，或 timeSetEvent 触发的自动重置事件
+	mmID      uint32         // timeSetEvent 返回的标识符，使用可等待计时器时为 0
+	endPeriod func() error
+	stop      windows.Handle
+	done      chan struct{}
+	stopOnce  sync.Once
+	stopErr   error
+
+	mu       sync.Mutex
+	stats    TickerStats
+	driftSum time.Duration
+}
+
+// NewTicker 创建周期为 period 的 Ticker，使用完毕后调用 Stop
+func NewTicker(period time.Duration) (*Ticker, error) {
+	return newTicker(period, true)
+}
+
+func newTicker(period time.Duration, highResolution bool) (*Ticker, error) {
+	if period <= 0 {
+		return nil, ErrInvalidParameter
+	}
+	stop, err := windows.CreateEvent(nil, 1, 0, nil)
+	if err != nil {
+		return nil, err
+	}
+	c := make(chan Tick, 1)
+	t := &Ticker{C: c, period: period, stop: stop, done: make(chan struct{})}
+	if highResolution {
+		// 不支持高精度标志的系统返回 ERROR_INVALID_PARAMETER
+		t.timer, err = CreateWaitableTimerExW(nil, "", CREATE_WAITABLE_TIMER_HIGH_RESOLUTION, windows.TIMER_ALL_ACCESS)
+	}
+	start := time.Now()
+	if t.timer == 0 {
+		if start, err = t.startMultimedia(); err != nil {
+			CloseHandle(stop)
+			return nil, err
+		}
+	}
+	go t.run(c, start)
+	return t, nil
+}
+
+// startMultimedia 启动 timeSetEvent 周期计时器，返回周期的起始时间
+func (t *Ticker) startMultimedia() (time.Time, error) {
+	ms := (t.period + time.Millisecond/2) / time.Millisecond
+	minPeriod, _, err := TimerCaps()
+	if err != nil {
+		return time.Time{}, err
+	}
+	t.period = max(ms*time.Millisecond, minPeriod)
+	if t.endPeriod, err = BeginTimerPeriod(minPeriod); err != nil {
+		return time.Time{}, err
+	}
+	if t.timer, err = windows.CreateEvent(nil, 0, 0, nil); err != nil {
+		t.endPeriod()
+		return time.Time{}, err
+	}
+	start := time.Now()
+	t.mmID, err = TimeSetEvent(uint32(t.period/time.Millisecond), uint32(minPeriod/time.Millisecond), uintptr(t.timer), 0,
+		TIME_PERIODIC|TIME_CALLBACK_EVENT_SET|TIME_KILL_SYNCHRONOUS)
+	if err != nil {
+		CloseHandle(t.timer)
+		t.endPeriod()
+		return time.Time{}, err
+	}
+	return start, nil
+}
+
+// Period 返回实际使用的周期，回退到多媒体计时器时可能与请求的周期不同
+func (t *Ticker) Period() time.Duration {
+	return t.period
+}
+
+// HighResolution 报告是否使用高精度可等待计时器
+func (t *Ticker) HighResolution() bool {
+	return t.mmID == 0
+}
+
+func (t *Ticker) run(c chan<- Tick, start time.Time) {
+	defer close(t.done)
+	handles := []windows.Handle{t.timer, t.stop}
+	// n 是下一个周期的序号，第 n 个周期计划在 start + n*period 触发
+	for n := time.Duration(1); ; n++ {
+		if t.mmID == 0 {
+			// 每次按计划时间重新设置，而不是使用计时器自身的周期（精度仅为毫秒）
+			due := -max(1, int64(time.Until(start.Add(n*t.period))/100))
+			if err := SetWaitableTimer(t.timer, &due, 0, 0, 0, false); err != nil {
+				return
+			}
+		}
+		event, err := windows.WaitForMultipleObjects(handles, false, windows.INFINITE)
+		if err != nil || event != windows.WAIT_OBJECT_0 {
+			return
+		}
+		now := time.Now()
+		drift := now.Sub(start.Add(n * t.period))
+		missed := 0
+		if drift >= t.period {
+			skip := drift / t.period
+			n += skip
+			drift -= skip * t.period
+			missed = int(skip)
+		}
+		tick := Tick{Time: now, Drift: drift, Missed: missed}
+		select {
+		case c <- tick:
+			t.record(tick, false)
+		default:
+			t.record(tick, true)
+		}
+	}
+}
+
+func (t *Ticker) record(tick Tick, dropped bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	s := &t.stats
+	s.Ticks++
+	s.Missed += uint64(tick.Missed)
+	if dropped {
+		s.Dropped++
+	}
+	t.driftSum += tick.Drift
+	s.MeanDrift = t.driftSum / time.Duration(s.Ticks)
+	s.MaxDrift = max(s.MaxDrift, tick.Drift, -tick.Drift)
+}
+
+// Stats 返回当前的漂移统计
+func (t *Ticker) Stats() TickerStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.stats
+}
+
+// Stop 停止 Ticker 并释放计时器，不会关闭 C。重复调用返回第一次的结果。
+func (t *Ticker) Stop() error {
+	t.stopOnce.Do(func() {
+		windows.SetEvent(t.stop)
+		<-t.done
+		var errs []error
+		if t.mmID != 0 {
+			errs = append(errs, TimeKillEvent(t.mmID), t.endPeriod())
+		} else {
+			errs = append(errs, CancelWaitableTimer(t.timer))
+		}
+		errs = append(errs, CloseHandle(t.timer), CloseHandle(t.stop))
+		t.stopErr = errors.Join(errs...)
+	})
+	return t.stopErr
+}
@@ -0,0 +1,75 @@
+package xwindows
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimerCaps(t *testing.T) {
+	minPeriod, maxPeriod, err := TimerCaps()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if minPeriod <= 0 || maxPeriod < minPeriod {
+		t.Fatalf("TimerCaps() = %v, %v", minPeriod, maxPeriod)
+	}
+	end, err := BeginTimerPeriod(minPeriod)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := end(); err != nil {
+		t.Fatal(err)
+	}
+	if err := end(); err != nil {
+		t.Fatalf("second end() = %v, want nil", err)
+	}
+}
+
+func TestTicker(t *testing.T) {
+	tests := []struct {
+		name           string
+		highResolution bool
+	}{
+		{"waitable timer", true},
+		{"multimedia timer", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tk, err := newTicker(2*time.Millisecond, tt.highResolution)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !tt.highResolution && tk.HighResolution() {
+				t.Fatal("HighResolution() = true for multimedia timer")
+			}
+			const want = 20
+			timeout := time.After(5 * time.Second)
+			for i := 0; i < want; i++ {
+				select {
+				case tick := <-tk.C:
+					if tick.Time.IsZero() {
+						t.Fatal("zero tick time")
+					}
+				case <-timeout:
+					t.Fatalf("received %d ticks before timeout", i)
+				}
+			}
+			if err := tk.Stop(); err != nil {
+				t.Fatal(err)
+			}
+			s := tk.Stats()
+			if s.Ticks < want || s.MaxDrift < 0 {
+				t.Errorf("Stats() = %+v", s)
+			}
+			if err := tk.Stop(); err != nil {
+				t.Fatalf("second Stop() = %v", err)
+			}
+		})
+	}
+}
+
+func TestTickerInvalidPeriod(t *testing.T) {
+	if _, err := NewTicker(0); err != ErrInvalidParameter {
+		t.Fatalf("NewTicker(0) error = %v, want ErrInvalidParameter", err)
+	}
+}
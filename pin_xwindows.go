@@ -0,0 +1,39 @@
+package xwindows
+
+import (
+	"runtime"
+	"unsafe"
+)
+
+// Out 是系统调用的输出参数。
+// Value 接收 API 写入的数据，ReturnLength 接收 API 报告的实际长度（如果该 API 提供）。
+// 调用期间 Out 的内存由 runtime.Pinner 固定，调用方无需自行转换 uintptr。
+type Out[T any] struct {
+	Value        T
+	ReturnLength uint32
+}
+
+// Size 返回 Value 的字节大小
+func (o *Out[T]) Size() uint32 {
+	return uint32(unsafe.Sizeof(o.Value))
+}
+
+// pinPtr 固定 v 所在的对象并返回其地址，v 为 nil 时返回 0。
+// 返回的地址只在 p.Unpin 之前有效。
+func pinPtr[T any](p *runtime.Pinner, v *T) uintptr {
+	if v == nil {
+		return 0
+	}
+	p.Pin(v)
+	return uintptr(unsafe.Pointer(v))
+}
+
+// pinSlice 固定切片的底层数组并返回首元素地址，空切片返回 0。
+// 返回的地址只在 p.Unpin 之前有效。
+func pinSlice[T any](p *runtime.Pinner, s []T) uintptr {
+	if len(s) == 0 {
+		return 0
+	}
+	p.Pin(&s[0])
+	return uintptr(unsafe.Pointer(&s[0]))
+}
@@ -0,0 +1,303 @@
+package xwindows
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// PipeInherit 指定匿名管道的哪一端可被子进程继承
+type PipeInherit uint32
+
+const (
+	PipeInheritRead  PipeInherit = 1 << iota // 读取端可继承，通常作为子进程的标准输入
+	PipeInheritWrite                         // 写入端可继承，通常作为子进程的标准输出或标准错误
+)
+
+// Pipe 创建匿名管道并以 *os.File 返回两端，inherit 为 0 时两端均不可继承。
+// 只让交给子进程的一端可继承，否则子进程持有的另一端会使管道在父进程关闭后仍不结束。
+func Pipe(inherit PipeInherit) (r, w *os.File, err error) {
+	var rh, wh windows.Handle
+	if err = CreatePipe(&rh, &wh, nil, 0); err != nil {
+		return nil, nil, err
+	}
+	ends := []struct {
+		handle windows.Handle
+		flag   PipeInherit
+	}{{rh, PipeInheritRead}, {wh, PipeInheritWrite}}
+	for _, end := range ends {
+		if inherit&end.flag == 0 {
+			continue
+		}
+		if err = windows.SetHandleInformation(end.handle, windows.HANDLE_FLAG_INHERIT, windows.HANDLE_FLAG_INHERIT); err != nil {
+			CloseHandle(rh)
+			CloseHandle(wh)
+			return nil, nil, err
+		}
+	}
+	return os.NewFile(uintptr(rh), "|0"), os.NewFile(uintptr(wh), "|1"), nil
+}
+
+// PipeConfig 是命名管道服务器的设置，零值表示字节模式、默认安全描述符且拒绝远程客户端
+type PipeConfig struct {
+	// SecurityDescriptor 是管道的 SDDL 安全描述符，如 "D:P(A;;GA;;;SY)(A;;GA;;;BA)(A;;GRGW;;;AU)"。
+	// 为空时使用默认安全描述符：LocalSystem、管理员和创建者拥有完全控制，Everyone 只读。
+	SecurityDescriptor string
+	// MessageMode 以消息模式创建管道，每次 Write 是一条消息，Read 一次读取一条消息
+	MessageMode bool
+	// InputBufferSize 和 OutputBufferSize 是建议的缓冲区大小，0 表示使用系统默认值
+	InputBufferSize  uint32
+	OutputBufferSize uint32
+	// RemoteClients 允许通过 SMB 连接的远程客户端
+	RemoteClients bool
+}
+
+var (
+	_ net.Conn     = (*PipeConn)(nil)
+	_ net.Listener = (*PipeListener)(nil)
+)
+
+type pipeAddr string
+
+func (a pipeAddr) Network() string { return "pipe" }
+func (a pipeAddr) String() string  { return string(a) }
+
+// PipeConn 是命名管道的一端，实现 net.Conn。
+// 句柄以重叠 I/O 打开并关联到 Go 运行时的完成端口，读写不占用系统线程，支持 SetDeadline（需要 Go 1.25 及以上版本）。
+type PipeConn struct {
+	*os.File
+	addr    pipeAddr
+	message bool
+}
+
+func newPipeConn(h windows.Handle, path string, message bool) *PipeConn {
+	return &PipeConn{File: os.NewFile(uintptr(h), path), addr: pipeAddr(path), message: message}
+}
+
+// Read 读取数据。消息模式下缓冲区小于消息时返回消息的前一部分，剩余部分由后续 Read 返回。
+func (c *PipeConn) Read(b []byte) (int, error) {
+	n, err := c.File.Read(b)
+	switch {
+	case err == nil:
+	case c.message && errors.Is(err, windows.ERROR_MORE_DATA):
+		err = nil
+	case errors.Is(err, windows.ERROR_PIPE_NOT_CONNECTED), errors.Is(err, windows.ERROR_BROKEN_PIPE):
+		err = io.EOF
+	}
+	return n, err
+}
+
+// LocalAddr 返回管道路径
+func (c *PipeConn) LocalAddr() net.Addr { return c.addr }
+
+// RemoteAddr 返回管道路径
+func (c *PipeConn) RemoteAddr() net.Addr { return c.addr }
+
+// MessageMode 报告管道是否为消息模式
+func (c *PipeConn) MessageMode() bool { return c.message }
+
+// PipeListener 是命名管道服务器，实现 net.Listener
+type PipeListener struct {
+	path    string
+	config  PipeConfig
+	sa      *windows.SecurityAttributes
+	mu      sync.Mutex     // 串行化 Accept，并在 Close 时等待进行中的 Accept 返回
+	pending windows.Handle // 尚未被 Accept 取走的管道实例
+	stop    windows.Handle // Close 时设置的手动重置事件
+	closed  atomic.Bool
+}
+
+// ListenPipe 在 path（如 \\.\pipe\myservice）上创建命名管道服务器，config 为 nil 时使用零值。
+// 第一个实例立即创建，客户端在第一次 Accept 之前即可连接。
+// 同名管道已被其他服务器创建时返回 ErrResourceExists。
+//
+//	l, err := xwindows.ListenPipe(`\\.\pipe\myservice`, nil)
+//	if err != nil {
+//		return err
+//	}
+//	return http.Serve(l, handler)
+func ListenPipe(path string, config *PipeConfig) (*PipeListener, error) {
+	l := &PipeListener{path: path}
+	if config != nil {
+		l.config = *config
+	}
+	if l.config.SecurityDescriptor != "" {
+		sd, err := windows.SecurityDescriptorFromString(l.config.SecurityDescriptor)
+		if err != nil {
+			return nil, err
+		}
+		l.sa = &windows.SecurityAttributes{SecurityDescriptor: sd}
+		l.sa.Length = uint32(unsafe.Sizeof(*l.sa))
+	}
+
+	first, err := l.createInstance(true)
+	if errors.Is(err, windows.ERROR_ACCESS_DENIED) {
+		return nil, ErrResourceExists
+	} else if err != nil {
+		return nil, err
+	}
+	if l.stop, err = CreateEventW(nil, true, false, ""); err != nil {
+		CloseHandle(first)
+		return nil, err
+	}
+	l.pending = first
+	return l, nil
+}
+
+func (l *PipeListener) createInstance(first bool) (windows.Handle, error) {
+	openMode := uint32(windows.PIPE_ACCESS_DUPLEX | windows.FILE_FLAG_OVERLAPPED)
+	if first {
+		openMode |= windows.FILE_FLAG_FIRST_PIPE_INSTANCE
+	}
+	pipeMode := uint32(windows.PIPE_TYPE_BYTE | windows.PIPE_READMODE_BYTE)
+	if l.config.MessageMode {
+		pipeMode = windows.PIPE_TYPE_MESSAGE | windows.PIPE_READMODE_MESSAGE
+	}
+	if !l.config.RemoteClients {
+		pipeMode |= windows.PIPE_REJECT_REMOTE_CLIENTS
+	}
+	return CreateNamedPipeW(l.path, openMode, pipeMode, windows.PIPE_UNLIMITED_INSTANCES,
+		l.config.OutputBufferSize, l.config.InputBufferSize, 0, l.sa)
+}
+
+// connect 以重叠方式等待客户端连接到实例 h，Close 时取消等待
+func (l *PipeListener) connect(h windows.Handle) error {
+	event, err := CreateEventW(nil, true, false, "")
+	if err != nil {
+		return err
+	}
+	defer CloseHandle(event)
+
+	// 内核在操作完成前一直写入 OVERLAPPED，固定其内存
+	var pinner runtime.Pinner
+	defer pinner.Unpin()
+	ov := &windows.Overlapped{HEvent: event}
+	pinner.Pin(ov)
+
+	switch err := ConnectNamedPipe(h, ov); {
+	case err == nil, errors.Is(err, windows.ERROR_PIPE_CONNECTED):
+		return nil
+	case !errors.Is(err, windows.ERROR_IO_PENDING):
+		return err
+	}
+
+	var n uint32
+	signaled, err := windows.WaitForMultipleObjects([]windows.Handle{event, l.stop}, false, windows.INFINITE)
+	if err != nil || signaled != windows.WAIT_OBJECT_0 {
+		windows.CancelIoEx(h, ov)
+		windows.GetOverlappedResult(h, ov, &n, true)
+		if err == nil {
+			err = net.ErrClosed
+		}
+		return err
+	}
+	return windows.GetOverlappedResult(h, ov, &n, false)
+}
+
+// Accept 等待下一个客户端连接
+func (l *PipeListener) Accept() (net.Conn, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed.Load() {
+		return nil, net.ErrClosed
+	}
+
+	h := l.pending
+	l.pending = 0
+	if h == 0 {
+		var err error
+		if h, err = l.createInstance(false); err != nil {
+			return nil, err
+		}
+	}
+	for {
+		err := l.connect(h)
+		if err == nil {
+			break
+		}
+		// 客户端在 ConnectNamedPipe 之前连接又断开时返回 ERROR_NO_DATA（等待期间断开时为 ERROR_BROKEN_PIPE），
+		// 这不是监听器的错误：断开实例后在同一实例上继续等待，避免 http.Serve 等调用方因此停止服务
+		if (errors.Is(err, windows.ERROR_NO_DATA) || errors.Is(err, windows.ERROR_BROKEN_PIPE)) &&
+			windows.DisconnectNamedPipe(h) == nil {
+			continue
+		}
+		CloseHandle(h)
+		return nil, err
+	}
+	// 立即创建下一个实例，使客户端在两次 Accept 之间连接时得到 ERROR_PIPE_BUSY 并重试，
+	// 而不是因为没有实例而得到 ERROR_FILE_NOT_FOUND。失败时推迟到下一次 Accept。
+	if next, err := l.createInstance(false); err == nil {
+		l.pending = next
+	}
+	return newPipeConn(h, l.path, l.config.MessageMode), nil
+}
+
+// Close 停止监听，进行中的 Accept 返回 net.ErrClosed，已接受的连接不受影响
+func (l *PipeListener) Close() error {
+	if l.closed.Swap(true) {
+		return nil
+	}
+	SetEvent(l.stop)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.pending != 0 {
+		CloseHandle(l.pending)
+		l.pending = 0
+	}
+	return CloseHandle(l.stop)
+}
+
+// Addr 返回管道路径
+func (l *PipeListener) Addr() net.Addr {
+	return pipeAddr(l.path)
+}
+
+// DialPipe 连接到命名管道，所有实例都忙时重试直到 ctx 结束。
+// 签名与 grpc.WithContextDialer 和 http.Transport.DialContext（忽略 network 参数后）兼容。
+func DialPipe(ctx context.Context, path string) (net.Conn, error) {
+	name, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return nil, err
+	}
+	var h windows.Handle
+	for {
+		// SECURITY_IDENTIFICATION 只允许服务器识别而不能模拟客户端
+		h, err = windows.CreateFile(name, windows.GENERIC_READ|windows.GENERIC_WRITE, 0, nil, windows.OPEN_EXISTING,
+			windows.FILE_FLAG_OVERLAPPED|windows.SECURITY_SQOS_PRESENT|windows.SECURITY_IDENTIFICATION, 0)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, windows.ERROR_PIPE_BUSY) {
+			return nil, &net.OpError{Op: "dial", Net: "pipe", Addr: pipeAddr(path), Err: err}
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	var flags uint32
+	if err := windows.GetNamedPipeInfo(h, &flags, nil, nil, nil); err != nil {
+		CloseHandle(h)
+		return nil, err
+	}
+	message := flags&windows.PIPE_TYPE_MESSAGE != 0
+	if message {
+		mode := uint32(windows.PIPE_READMODE_MESSAGE)
+		if err := windows.SetNamedPipeHandleState(h, &mode, nil, nil); err != nil {
+			CloseHandle(h)
+			return nil, err
+		}
+	}
+	return newPipeConn(h, path, message), nil
+}
@@ -0,0 +1,180 @@
+package xwindows
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"golang.org/x/sys/windows"
+)
+
+func TestPipe(t *testing.T) {
+	r, w, err := Pipe(PipeInheritWrite)
+	if err != nil {
+		t.Fatalf("Pipe() error = %v", err)
+	}
+	defer r.Close()
+
+	inherits := func(f *os.File) bool {
+		var flags uint32
+		if err := GetHandleInformation(windows.Handle(f.Fd()), &flags); err != nil {
+			t.Fatal(err)
+		}
+		return flags&windows.HANDLE_FLAG_INHERIT != 0
+	}
+	if inherits(r) || !inherits(w) {
+		t.Errorf("inherit flags: read = %v, write = %v, want false, true", inherits(r), inherits(w))
+	}
+
+	go func() {
+		w.Write([]byte("hello"))
+		w.Close()
+	}()
+	got, err := io.ReadAll(r)
+	if err != nil || string(got) != "hello" {
+		t.Errorf("ReadAll() = %q, %v, want hello", got, err)
+	}
+}
+
+func testPipePath(t *testing.T) string {
+	return fmt.Sprintf(`\\.\pipe\xwindows-test-%d-%s`, os.Getpid(), t.Name())
+}
+
+func TestNamedPipe(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *PipeConfig
+	}{
+		{"byte mode", nil},
+		{"message mode", &PipeConfig{MessageMode: true}},
+		{"sddl", &PipeConfig{SecurityDescriptor: "D:P(A;;GA;;;SY)(A;;GA;;;BA)(A;;GA;;;OW)"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := testPipePath(t)
+			l, err := ListenPipe(path, tt.config)
+			if err != nil {
+				t.Fatalf("ListenPipe() error = %v", err)
+			}
+			defer l.Close()
+			if _, err := ListenPipe(path, nil); !errors.Is(err, ErrResourceExists) {
+				t.Errorf("second ListenPipe() error = %v, want ErrResourceExists", err)
+			}
+
+			go func() {
+				c, err := l.Accept()
+				if err != nil {
+					return
+				}
+				defer c.Close()
+				io.Copy(c, c)
+			}()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			c, err := DialPipe(ctx, path)
+			if err != nil {
+				t.Fatalf("DialPipe() error = %v", err)
+			}
+			defer c.Close()
+			if got := c.(*PipeConn).MessageMode(); got != (tt.config != nil && tt.config.MessageMode) {
+				t.Errorf("MessageMode() = %v", got)
+			}
+
+			c.SetDeadline(time.Now().Add(5 * time.Second))
+			if _, err := c.Write([]byte("ping")); err != nil {
+				t.Fatalf("Write() error = %v", err)
+			}
+			buf := make([]byte, 16)
+			n, err := c.Read(buf)
+			if err != nil || string(buf[:n]) != "ping" {
+				t.Errorf("Read() = %q, %v, want ping", buf[:n], err)
+			}
+		})
+	}
+}
+
+func TestPipeListenerClose(t *testing.T) {
+	l, err := ListenPipe(testPipePath(t), nil)
+	if err != nil {
+		t.Fatalf("ListenPipe() error = %v", err)
+	}
+	done := make(chan error, 1)
+	go func() {
+		_, err := l.Accept()
+		done <- err
+	}()
+	time.Sleep(50 * time.Millisecond)
+	l.Close()
+	select {
+	case err := <-done:
+		if !errors.Is(err, net.ErrClosed) {
+			t.Errorf("Accept() after Close error = %v, want net.ErrClosed", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Accept() did not return after Close")
+	}
+}
+
+// TestPipeAcceptAfterClientGone 检查在 Accept 之前连接又断开的客户端不会使 Accept 失败
+func TestPipeAcceptAfterClientGone(t *testing.T) {
+	path := testPipePath(t)
+	l, err := ListenPipe(path, nil)
+	if err != nil {
+		t.Fatalf("ListenPipe() error = %v", err)
+	}
+	defer l.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	gone, err := DialPipe(ctx, path)
+	if err != nil {
+		t.Fatalf("DialPipe() error = %v", err)
+	}
+	gone.Close()
+
+	accepted := make(chan error, 1)
+	go func() {
+		c, err := l.Accept()
+		if err == nil {
+			defer c.Close()
+			_, err = io.Copy(c, c)
+		}
+		accepted <- err
+	}()
+
+	c, err := DialPipe(ctx, path)
+	if err != nil {
+		t.Fatalf("second DialPipe() error = %v", err)
+	}
+	c.SetDeadline(time.Now().Add(5 * time.Second))
+	if _, err := c.Write([]byte("ping")); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	buf := make([]byte, 16)
+	n, err := c.Read(buf)
+	if err != nil || string(buf[:n]) != "ping" {
+		t.Errorf("Read() = %q, %v, want ping", buf[:n], err)
+	}
+	c.Close()
+	select {
+	case err := <-accepted:
+		if err != nil {
+			t.Errorf("Accept() error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Accept() did not return")
+	}
+}
+
+func TestDialPipeNotFound(t *testing.T) {
+	_, err := DialPipe(context.Background(), testPipePath(t))
+	if !errors.Is(err, windows.ERROR_FILE_NOT_FOUND) {
+		t.Errorf("DialPipe() error = %v, want ERROR_FILE_NOT_FOUND", err)
+	}
+}
@@ -0,0 +1,151 @@
+package xwindows
+
+import (
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// InformationClass 将 NtQueryInformationProcess/NtQueryInformationThread 的信息类编号与其结果类型绑定
+type InformationClass[T any] struct {
+	Class  uint32
+	size   uint32 // 初始缓冲区大小
+	decode func(buf []byte) (T, error)
+}
+
+// fixedClass 描述结果为定长结构 R 的信息类，convert 将 R 转换为 Go 表示
+func fixedClass[R, T any](class uint32, convert func(*R) T) InformationClass[T] {
+	var r R
+	size := uint32(unsafe.Sizeof(r))
+	return InformationClass[T]{
+		Class: class,
+		size:  size,
+		decode: func(buf []byte) (T, error) {
+			if uint32(len(buf)) < size {
+				var zero T
+				return zero, ErrInsufficientBuffer
+			}
+			return convert((*R)(unsafe.Pointer(&buf[0]))), nil
+		},
+	}
+}
+
+// unicodeStringClass 描述结果为 UNICODE_STRING 且字符串数据紧随其后的信息类
+func unicodeStringClass(class uint32) InformationClass[string] {
+	c := fixedClass(class, func(s *windows.NTUnicodeString) string {
+		return s.String()
+	})
+	c.size += windows.MAX_PATH * 2
+	return c
+}
+
+// alignedBuffer 分配按 8 字节对齐的缓冲区，以便按结构体解释其内容
+func alignedBuffer(size uint32) []byte {
+	if size == 0 {
+		return nil
+	}
+	words := make([]uint64, (size+7)/8)
+	return unsafe.Slice((*byte)(unsafe.Pointer(&words[0])), size)
+}
+
+// queryInformation 以 initial 为初始大小调用 query。
+// 返回 STATUS_INFO_LENGTH_MISMATCH、STATUS_BUFFER_TOO_SMALL 或 STATUS_BUFFER_OVERFLOW 时
+// 按函数报告的长度扩大缓冲区重试。
+func queryInformation(initial uint32, query func(buf []byte) (uint32, error)) ([]byte, error) {
+	size := initial
+	for range 16 {
+		buf := alignedBuffer(size)
+		n, err := query(buf)
+		switch err {
+		case nil:
+			return buf, nil
+		case windows.STATUS_INFO_LENGTH_MISMATCH, windows.STATUS_BUFFER_TOO_SMALL, windows.STATUS_BUFFER_OVERFLOW:
+			size = max(n, size*2)
+		default:
+			return nil, err
+		}
+	}
+	return nil, ErrInsufficientBuffer
+}
+
+// ProcessBasicInfo 是 PROCESS_BASIC_INFORMATION 的 Go 表示
+type ProcessBasicInfo struct {
+	ExitStatus      windows.NTStatus // 进程仍在运行时为 STATUS_PENDING
+	PebBaseAddress  uintptr
+	AffinityMask    uintptr
+	BasePriority    int32
+	ProcessID       uint32
+	ParentProcessID uint32
+}
+
+// KernelUserTimes 是 KERNEL_USER_TIMES 的 Go 表示，用于进程和线程
+type KernelUserTimes struct {
+	CreateTime time.Time
+	ExitTime   time.Time // 仍在运行时为零值
+	KernelTime time.Duration
+	UserTime   time.Duration
+}
+
+func newKernelUserTimes(t *KERNEL_USER_TIMES) KernelUserTimes {
+	return KernelUserTimes{
+		CreateTime: filetimeToTime(t.CreateTime),
+		ExitTime:   filetimeToTime(t.ExitTime),
+		KernelTime: time.Duration(t.KernelTime) * 100,
+		UserTime:   time.Duration(t.UserTime) * 100,
+	}
+}
+
+// filetimeToTime 将以 100 纳秒为单位的 FILETIME 值转换为 time.Time，0 转换为零值
+func filetimeToTime(ft int64) time.Time {
+	if ft == 0 {
+		return time.Time{}
+	}
+	f := windows.Filetime{LowDateTime: uint32(ft), HighDateTime: uint32(ft >> 32)}
+	return time.Unix(0, f.Nanoseconds())
+}
+
+// NtQueryInformationProcess 信息类
+var (
+	// ProcessBasicInformation 查询 PEB 地址、父进程 ID 和退出状态
+	ProcessBasicInformation = fixedClass(windows.ProcessBasicInformation, func(i *windows.PROCESS_BASIC_INFORMATION) ProcessBasicInfo {
+		return ProcessBasicInfo{
+			ExitStatus:      i.ExitStatus,
+			PebBaseAddress:  *(*uintptr)(unsafe.Pointer(&i.PebBaseAddress)),
+			AffinityMask:    i.AffinityMask,
+			BasePriority:    i.BasePriority,
+			ProcessID:       uint32(i.UniqueProcessId),
+			ParentProcessID: uint32(i.InheritedFromUniqueProcessId),
+		}
+	})
+
+	// ProcessTimes 查询进程的创建、退出时间及内核态、用户态时间
+	ProcessTimes = fixedClass(windows.ProcessTimes, newKernelUserTimes)
+
+	// ProcessHandleCount 查询进程打开的句柄数
+	ProcessHandleCount = fixedClass(windows.ProcessHandleCount, func(n *uint32) uint32 { return *n })
+
+	// ProcessWow64Information 查询 WOW64 进程的 32 位 PEB 地址，非 WOW64 进程为 0
+	ProcessWow64Information = fixedClass(windows.ProcessWow64Information, func(peb *uintptr) uintptr { return *peb })
+
+	// ProcessImageFileName 查询进程映像文件的 NT 设备路径，如 \Device\HarddiskVolume3\Windows\explorer.exe
+	ProcessImageFileName = unicodeStringClass(windows.ProcessImageFileName)
+
+	// ProcessCommandLineInformation 查询进程的命令行，需要 Windows 8.1 及以上版本
+	ProcessCommandLineInformation = unicodeStringClass(windows.ProcessCommandLineInformation)
+)
+
+// QueryProcessInformation 查询指定进程的一个信息类并返回类型化的结果。
+// 变长结果的缓冲区在返回 STATUS_INFO_LENGTH_MISMATCH 时自动扩大。
+//
+//	info, err := xwindows.QueryProcessInformation(process, xwindows.ProcessBasicInformation)
+func QueryProcessInformation[T any](process windows.Handle, class InformationClass[T]) (T, error) {
+	buf, err := queryInformation(class.size, func(buf []byte) (uint32, error) {
+		return QueryInformationProcessBuffer(process, class.Class, buf)
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return class.decode(buf)
+}
@@ -0,0 +1,53 @@
+package xwindows
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"golang.org/x/sys/windows"
+)
+
+func TestQueryProcessInformation(t *testing.T) {
+	process := windows.CurrentProcess()
+
+	basic, err := QueryProcessInformation(process, ProcessBasicInformation)
+	if err != nil {
+		t.Fatalf("QueryProcessInformation(ProcessBasicInformation) error = %v", err)
+	}
+	if basic.ProcessID != uint32(os.Getpid()) {
+		t.Errorf("ProcessID = %d, want %d", basic.ProcessID, os.Getpid())
+	}
+	if basic.ParentProcessID != uint32(os.Getppid()) {
+		t.Errorf("ParentProcessID = %d, want %d", basic.ParentProcessID, os.Getppid())
+	}
+
+	image, err := QueryProcessInformation(process, ProcessImageFileName)
+	if err != nil {
+		t.Fatalf("QueryProcessInformation(ProcessImageFileName) error = %v", err)
+	}
+	if !strings.HasPrefix(image, `\Device\`) {
+		t.Errorf("ProcessImageFileName = %q, want NT device path", image)
+	}
+
+	cmdline, err := QueryProcessInformation(process, ProcessCommandLineInformation)
+	if err != nil {
+		t.Fatalf("QueryProcessInformation(ProcessCommandLineInformation) error = %v", err)
+	}
+	if cmdline == "" {
+		t.Errorf("ProcessCommandLineInformation is empty")
+	}
+
+	times, err := QueryProcessInformation(process, ProcessTimes)
+	if err != nil {
+		t.Fatalf("QueryProcessInformation(ProcessTimes) error = %v", err)
+	}
+	if times.CreateTime.IsZero() || !times.ExitTime.IsZero() {
+		t.Errorf("ProcessTimes = %+v, want create time set and exit time zero", times)
+	}
+
+	handles, err := QueryProcessInformation(process, ProcessHandleCount)
+	if err != nil || handles == 0 {
+		t.Errorf("QueryProcessInformation(ProcessHandleCount) = %d, %v", handles, err)
+	}
+}
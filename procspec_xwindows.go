@@ -0,0 +1,311 @@
+package xwindows
+
+import (
+	"errors"
+	"os/exec"
+	"unsafe"
+
+	"github.com/C1ph3rX13/xwindows/cmdline"
+	"golang.org/x/sys/windows"
+)
+
+// ProcessSpec 描述要启动的进程，由 NewProcessSpec 创建，With 方法可以链式调用。
+// 子进程只继承通过 WithStdin、WithStdout、WithStderr 和 WithInheritedHandles 指定的句柄
+// （PROC_THREAD_ATTRIBUTE_HANDLE_LIST），父进程中其他可继承的句柄不会泄漏给子进程。
+//
+//	r, w, err := xwindows.Pipe(0)
+//	if err != nil {
+//		return err
+//	}
+//	p, err := xwindows.NewProcessSpec("git", "log", "--format=%an <%ae>").
+//		WithDir(repo).
+//		WithStdout(windows.Handle(w.Fd())).
+//		Start()
+//	w.Close()
+type ProcessSpec struct {
+	path    string
+	argv    []string
+	dir     string
+	env     []string
+	envSet  bool
+	std     [3]windows.Handle // 标准输入、标准输出、标准错误
+	inherit []windows.Handle
+	flags   uint32
+	attrs   []procAttribute
+}
+
+// procAttribute 是额外的 PROC_THREAD_ATTRIBUTE_*，value 必须在 Start 返回前保持有效
+type procAttribute struct {
+	attribute uintptr
+	value     unsafe.Pointer
+	size      uintptr
+}
+
+// NewProcessSpec 创建启动 path 的 ProcessSpec，args 是 argv[0] 之后的参数。
+// path 不含路径分隔符时在 PATH 中查找（与 os/exec.LookPath 相同，不搜索当前目录）。
+func NewProcessSpec(path string, args ...string) *ProcessSpec {
+	return &ProcessSpec{path: path, argv: append([]string{path}, args...)}
+}
+
+// WithDir 设置子进程的工作目录，默认与父进程相同
+func (s *ProcessSpec) WithDir(dir string) *ProcessSpec {
+	s.dir = dir
+	return s
+}
+
+// WithEnv 设置子进程的环境变量（"name=value" 列表），默认继承父进程的环境。
+// 传入空列表时子进程没有任何环境变量，多数程序至少需要 SYSTEMROOT。
+func (s *ProcessSpec) WithEnv(env []string) *ProcessSpec {
+	s.env, s.envSet = env, true
+	return s
+}
+
+// WithStdin 设置子进程的标准输入
+func (s *ProcessSpec) WithStdin(h windows.Handle) *ProcessSpec {
+	s.std[0] = h
+	return s
+}
+
+// WithStdout 设置子进程的标准输出
+func (s *ProcessSpec) WithStdout(h windows.Handle) *ProcessSpec {
+	s.std[1] = h
+	return s
+}
+
+// WithStderr 设置子进程的标准错误
+func (s *ProcessSpec) WithStderr(h windows.Handle) *ProcessSpec {
+	s.std[2] = h
+	return s
+}
+
+// WithInheritedHandles 添加子进程要继承的句柄。
+// 句柄无须可继承：Start 为子进程复制可继承的副本，子进程中的句柄值与父进程不同，
+// 由 Process.ChildHandle 返回，需要另行告知子进程（例如以 CREATE_SUSPENDED 启动后通过管道发送）。
+func (s *ProcessSpec) WithInheritedHandles(handles ...windows.Handle) *ProcessSpec {
+	s.inherit = append(s.inherit, handles...)
+	return s
+}
+
+// WithCreationFlags 添加 CreateProcess 的创建标志，如 CREATE_SUSPENDED、CREATE_NO_WINDOW、CREATE_NEW_PROCESS_GROUP
+func (s *ProcessSpec) WithCreationFlags(flags uint32) *ProcessSpec {
+	s.flags |= flags
+	return s
+}
+
+// withAttribute 添加额外的进程线程属性
+func (s *ProcessSpec) withAttribute(attribute uintptr, value unsafe.Pointer, size uintptr) *ProcessSpec {
+	s.attrs = append(s.attrs, procAttribute{attribute, value, size})
+	return s
+}
+
+// inheritedHandles 为子进程复制可继承的句柄，同一个句柄只复制一次，copies 是父进程句柄到副本的映射。
+// 标准句柄未指定时使用父进程的标准句柄，以便与指定的标准句柄一起传递。
+func (s *ProcessSpec) inheritedHandles() (std [3]windows.Handle, list []windows.Handle, copies map[windows.Handle]windows.Handle, err error) {
+	copies = make(map[windows.Handle]windows.Handle)
+	dup := func(h windows.Handle) (windows.Handle, error) {
+		if c, ok := copies[h]; ok {
+			return c, nil
+		}
+		var c windows.Handle
+		if err := windows.DuplicateHandle(windows.CurrentProcess(), h, windows.CurrentProcess(), &c,
+			0, true, windows.DUPLICATE_SAME_ACCESS); err != nil {
+			return 0, err
+		}
+		copies[h] = c
+		list = append(list, c)
+		return c, nil
+	}
+
+	if s.std != [3]windows.Handle{} {
+		parent := [3]uint32{windows.STD_INPUT_HANDLE, windows.STD_OUTPUT_HANDLE, windows.STD_ERROR_HANDLE}
+		for i, h := range s.std {
+			if h == 0 {
+				// 父进程没有对应的标准句柄时子进程也没有
+				if h, _ = windows.GetStdHandle(parent[i]); h == 0 || h == windows.InvalidHandle {
+					continue
+				}
+			}
+			if std[i], err = dup(h); err != nil {
+				closeHandles(list)
+				return std, nil, nil, err
+			}
+		}
+	}
+	for _, h := range s.inherit {
+		if _, err = dup(h); err != nil {
+			closeHandles(list)
+			return std, nil, nil, err
+		}
+	}
+	return std, list, copies, nil
+}
+
+func closeHandles(handles []windows.Handle) {
+	for _, h := range handles {
+		CloseHandle(h)
+	}
+}
+
+// Start 启动进程。指定 CREATE_SUSPENDED 时主线程处于挂起状态，需要调用 Process.Resume。
+func (s *ProcessSpec) Start() (*Process, error) {
+	path, err := exec.LookPath(s.path)
+	if err != nil {
+		return nil, err
+	}
+	app, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return nil, err
+	}
+	line, err := cmdline.Join(s.argv)
+	if err != nil {
+		return nil, err
+	}
+	// CreateProcessW 可能修改命令行缓冲区，不能使用只读的字符串常量
+	cmd, err := windows.UTF16FromString(line)
+	if err != nil {
+		return nil, err
+	}
+	var dir *uint16
+	if s.dir != "" {
+		if dir, err = windows.UTF16PtrFromString(s.dir); err != nil {
+			return nil, err
+		}
+	}
+	var env []uint16
+	if s.envSet {
+		if env, err = cmdline.EnvBlock(s.env); err != nil {
+			return nil, err
+		}
+	}
+
+	std, handles, copies, err := s.inheritedHandles()
+	if err != nil {
+		return nil, err
+	}
+	// 子进程创建后（或失败时）父进程不再需要这些副本
+	defer closeHandles(handles)
+
+	attrs, err := windows.NewProcThreadAttributeList(uint32(1 + len(s.attrs)))
+	if err != nil {
+		return nil, err
+	}
+	defer attrs.Delete()
+	// 空的句柄列表是无效参数，没有要继承的句柄时不设置该属性并禁止继承
+	if len(handles) > 0 {
+		if err := attrs.Update(windows.PROC_THREAD_ATTRIBUTE_HANDLE_LIST, unsafe.Pointer(&handles[0]),
+			uintptr(len(handles))*unsafe.Sizeof(handles[0])); err != nil {
+			return nil, err
+		}
+	}
+	for _, a := range s.attrs {
+		if err := attrs.Update(a.attribute, a.value, a.size); err != nil {
+			return nil, err
+		}
+	}
+
+	si := &windows.StartupInfoEx{ProcThreadAttributeList: attrs.List()}
+	si.Cb = uint32(unsafe.Sizeof(*si))
+	if std != [3]windows.Handle{} {
+		si.Flags |= windows.STARTF_USESTDHANDLES
+		si.StdInput, si.StdOutput, si.StdErr = std[0], std[1], std[2]
+	}
+	flags := s.flags | windows.CREATE_UNICODE_ENVIRONMENT | windows.EXTENDED_STARTUPINFO_PRESENT
+
+	var envp *uint16
+	if env != nil {
+		envp = &env[0]
+	}
+	var pi windows.ProcessInformation
+	if err := CreateProcessW(app, &cmd[0], nil, nil, len(handles) > 0, flags, envp, dir, &si.StartupInfo, &pi); err != nil {
+		return nil, err
+	}
+	// 继承的句柄在子进程中的值与父进程中的副本相同
+	inherited := make(map[windows.Handle]windows.Handle, len(s.inherit))
+	for _, h := range s.inherit {
+		inherited[h] = copies[h]
+	}
+	return &Process{Pid: pi.ProcessId, handle: pi.Process, thread: pi.Thread, inherited: inherited}, nil
+}
+
+// Process 是 ProcessSpec.Start 启动的进程，使用完毕后调用 Close 释放句柄
+type Process struct {
+	Pid       uint32
+	handle    windows.Handle
+	thread    windows.Handle                    // 主线程，用于 Resume
+	inherited map[windows.Handle]windows.Handle // WithInheritedHandles 指定的句柄到子进程中句柄值的映射
+}
+
+// Handle 返回进程句柄，具有 PROCESS_ALL_ACCESS 访问权限
+func (p *Process) Handle() windows.Handle {
+	return p.handle
+}
+
+// ChildHandle 返回通过 WithInheritedHandles 传递的句柄 h 在子进程中的值，h 未被传递时 ok 为 false
+func (p *Process) ChildHandle(h windows.Handle) (child windows.Handle, ok bool) {
+	child, ok = p.inherited[h]
+	return
+}
+
+// Resume 恢复以 CREATE_SUSPENDED 启动的进程的主线程
+func (p *Process) Resume() error {
+	_, err := ResumeThread(p.thread)
+	return err
+}
+
+// Wait 等待进程结束
+func (p *Process) Wait() error {
+	_, err := windows.WaitForSingleObject(p.handle, windows.INFINITE)
+	return err
+}
+
+// exited 报告进程是否已经结束
+func (p *Process) exited() (bool, error) {
+	event, err := windows.WaitForSingleObject(p.handle, 0)
+	if err != nil {
+		return false, err
+	}
+	return event == windows.WAIT_OBJECT_0, nil
+}
+
+// ExitCode 返回进程的退出代码，进程尚未结束时返回 ErrNotReady
+func (p *Process) ExitCode() (uint32, error) {
+	// 进程可能以 STILL_ACTIVE (259) 退出，不能只依赖 GetExitCodeProcess
+	done, err := p.exited()
+	if err != nil {
+		return 0, err
+	}
+	if !done {
+		return 0, ErrNotReady
+	}
+	var code uint32
+	if err := GetExitCodeProcess(p.handle, &code); err != nil {
+		return 0, err
+	}
+	return code, nil
+}
+
+// Kill 以退出代码 1 终止进程，进程已经结束时返回 nil
+func (p *Process) Kill() error {
+	err := TerminateProcess(p.handle, 1)
+	if errors.Is(err, windows.ERROR_ACCESS_DENIED) {
+		// 进程已经结束时 TerminateProcess 返回 ERROR_ACCESS_DENIED
+		if done, _ := p.exited(); done {
+			return nil
+		}
+	}
+	return err
+}
+
+// Close 关闭进程和主线程句柄，不会终止进程。重复调用返回 nil。
+func (p *Process) Close() error {
+	var errs []error
+	if p.thread != 0 {
+		errs = append(errs, CloseHandle(p.thread))
+		p.thread = 0
+	}
+	if p.handle != 0 {
+		errs = append(errs, CloseHandle(p.handle))
+		p.handle = 0
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,222 @@
+package xwindows
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/sys/windows"
+)
+
+// 以 XWINDOWS_PROCESS_HELPER 启动测试二进制文件时作为子进程运行
+func TestProcessHelper(t *testing.T) {
+	switch os.Getenv("XWINDOWS_PROCESS_HELPER") {
+	case "args":
+		// 只输出 "--" 之后由 helperSpec 传入的参数
+		i := slices.Index(os.Args, "--")
+		for _, arg := range os.Args[i+1:] {
+			fmt.Printf("%q\n", arg)
+		}
+	case "inherit":
+		// 从标准输入读取继承的句柄在本进程中的值，并写入该句柄
+		var h uintptr
+		if _, err := fmt.Scan(&h); err != nil {
+			os.Exit(2)
+		}
+		f := os.NewFile(h, "inherited")
+		fmt.Fprint(f, "inherited")
+		f.Close()
+	case "env":
+		fmt.Print(os.Getenv("XW_VALUE"))
+	case "sleep":
+		time.Sleep(time.Minute)
+	case "exit":
+		os.Exit(7)
+	default:
+		return
+	}
+	os.Exit(0)
+}
+
+// helperEnv 返回以 mode 运行 TestProcessHelper 所需的环境变量，extra 追加在后面
+func helperEnv(mode string, extra ...string) []string {
+	return append(append(os.Environ(), "XWINDOWS_PROCESS_HELPER="+mode), extra...)
+}
+
+// helperSpec 返回以 mode 运行 TestProcessHelper 的 ProcessSpec，args 放在 "--" 之后传给子进程
+func helperSpec(t *testing.T, mode string, args ...string) *ProcessSpec {
+	t.Helper()
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	argv := append([]string{"-test.run=^TestProcessHelper$", "--"}, args...)
+	return NewProcessSpec(exe, argv...).WithEnv(helperEnv(mode))
+}
+
+// runOutput 启动进程并返回标准输出和退出代码
+func runOutput(t *testing.T, spec *ProcessSpec) (string, uint32) {
+	t.Helper()
+	r, w, err := Pipe(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	p, err := spec.WithStdout(windows.Handle(w.Fd())).Start()
+	w.Close()
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer p.Close()
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Wait(); err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+	code, err := p.ExitCode()
+	if err != nil {
+		t.Fatalf("ExitCode() error = %v", err)
+	}
+	return string(out), code
+}
+
+func TestProcessSpecArgs(t *testing.T) {
+	args := []string{"", "a b", `a"b`, `C:\Program Files\`, `\\"`, "中文", "-x"}
+	out, code := runOutput(t, helperSpec(t, "args", args...))
+	if code != 0 {
+		t.Fatalf("exit code = %d, output %q", code, out)
+	}
+	var got []string
+	for line := range strings.Lines(out) {
+		var arg string
+		if _, err := fmt.Sscanf(line, "%q", &arg); err != nil {
+			t.Fatalf("Sscanf(%q) error = %v", line, err)
+		}
+		got = append(got, arg)
+	}
+	if !slices.Equal(got, args) {
+		t.Errorf("child args = %q, want %q", got, args)
+	}
+}
+
+func TestProcessSpecEnvAndDir(t *testing.T) {
+	spec := helperSpec(t, "env").WithEnv(helperEnv("env", "XW_VALUE=值 1"))
+	out, _ := runOutput(t, spec.WithDir(os.TempDir()))
+	if out != "值 1" {
+		t.Errorf("child XW_VALUE = %q, want %q", out, "值 1")
+	}
+}
+
+func TestProcessExitCode(t *testing.T) {
+	_, code := runOutput(t, helperSpec(t, "exit"))
+	if code != 7 {
+		t.Errorf("ExitCode() = %d, want 7", code)
+	}
+}
+
+func TestProcessKill(t *testing.T) {
+	p, err := helperSpec(t, "sleep").WithCreationFlags(windows.CREATE_SUSPENDED).Start()
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer p.Close()
+	if err := p.Resume(); err != nil {
+		t.Fatalf("Resume() error = %v", err)
+	}
+	if _, err := p.ExitCode(); !errors.Is(err, ErrNotReady) {
+		t.Errorf("ExitCode() error = %v, want %v", err, ErrNotReady)
+	}
+	if err := p.Kill(); err != nil {
+		t.Fatalf("Kill() error = %v", err)
+	}
+	if err := p.Wait(); err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+	if code, err := p.ExitCode(); err != nil || code != 1 {
+		t.Errorf("ExitCode() = %d, %v, want 1", code, err)
+	}
+	if err := p.Kill(); err != nil {
+		t.Errorf("Kill() after exit error = %v", err)
+	}
+}
+
+func TestProcessSpecInheritance(t *testing.T) {
+	// 可继承但未列出的句柄不会传递给子进程：子进程运行时关闭父进程的写入端，读取端立即得到 EOF
+	r, w, err := Pipe(PipeInheritWrite)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	p, err := helperSpec(t, "sleep").Start()
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer p.Close()
+	defer p.Kill()
+	w.Close()
+
+	done := make(chan struct{})
+	go func() {
+		io.ReadAll(r)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("pipe write end leaked to child process")
+	}
+}
+
+func TestProcessSpecInheritedHandles(t *testing.T) {
+	// 子进程通过标准输入得知句柄在子进程中的值，再向该句柄写入
+	inR, inW, err := Pipe(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer inW.Close()
+	r, w, err := Pipe(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	wh := windows.Handle(w.Fd())
+	p, err := helperSpec(t, "inherit").WithStdin(windows.Handle(inR.Fd())).WithInheritedHandles(wh).Start()
+	inR.Close()
+	if err != nil {
+		w.Close()
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer p.Close()
+	defer p.Kill()
+	child, ok := p.ChildHandle(wh)
+	w.Close()
+	if !ok {
+		t.Fatal("ChildHandle() returned false for an inherited handle")
+	}
+	if _, ok := p.ChildHandle(windows.Handle(r.Fd())); ok {
+		t.Error("ChildHandle() returned true for a handle that was not inherited")
+	}
+	fmt.Fprintf(inW, "%d\n", child)
+	inW.Close()
+
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != "inherited" {
+		t.Errorf("read %q from inherited pipe, want %q", out, "inherited")
+	}
+	if err := p.Wait(); err != nil {
+		t.Fatal(err)
+	}
+	if code, err := p.ExitCode(); err != nil || code != 0 {
+		t.Errorf("ExitCode() = %d, %v", code, err)
+	}
+}
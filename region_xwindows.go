@@ -0,0 +1,233 @@
+package xwindows
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"strings"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// Region 是 MEMORY_BASIC_INFORMATION 的 Go 表示，描述进程地址空间中属性相同的一段连续页面
+type Region struct {
+	Base              uintptr
+	AllocationBase    uintptr // VirtualAlloc 分配或映像、节视图映射的基址
+	AllocationProtect uint32  // 初始分配时的内存保护
+	Size              uintptr
+	State             uint32 // MEM_COMMIT、MEM_RESERVE 或 MEM_FREE
+	Type              uint32 // MEM_IMAGE、MEM_MAPPED 或 MEM_PRIVATE，空闲区域为 0
+	Protect           uint32 // 当前的内存保护，只对已提交的区域有意义
+	FileName          string // 映像和映射区域的文件设备路径，如 \Device\HarddiskVolume3\Windows\System32\ntdll.dll
+}
+
+// Committed 报告区域是否已提交
+func (r Region) Committed() bool {
+	return r.State == windows.MEM_COMMIT
+}
+
+// StateName 返回 State 的名称
+func (r Region) StateName() string {
+	return flagName(r.State, memStateNames)
+}
+
+// TypeName 返回 Type 的名称
+func (r Region) TypeName() string {
+	return flagName(r.Type, memTypeNames)
+}
+
+// ProtectName 返回 Protect 的名称，修饰标志以 | 连接，如 PAGE_READWRITE|PAGE_GUARD
+func (r Region) ProtectName() string {
+	return ProtectName(r.Protect)
+}
+
+type namedFlag struct {
+	value uint32
+	name  string
+}
+
+var memStateNames = []namedFlag{
+	{windows.MEM_COMMIT, "MEM_COMMIT"},
+	{windows.MEM_RESERVE, "MEM_RESERVE"},
+	{MEM_FREE, "MEM_FREE"},
+}
+
+var memTypeNames = []namedFlag{
+	{MEM_IMAGE, "MEM_IMAGE"},
+	{MEM_MAPPED, "MEM_MAPPED"},
+	{MEM_PRIVATE, "MEM_PRIVATE"},
+}
+
+// 基本保护值互斥，占用低 8 位
+var pageProtectNames = []namedFlag{
+	{windows.PAGE_NOACCESS, "PAGE_NOACCESS"},
+	{windows.PAGE_READONLY, "PAGE_READONLY"},
+	{windows.PAGE_READWRITE, "PAGE_READWRITE"},
+	{windows.PAGE_WRITECOPY, "PAGE_WRITECOPY"},
+	{windows.PAGE_EXECUTE, "PAGE_EXECUTE"},
+	{windows.PAGE_EXECUTE_READ, "PAGE_EXECUTE_READ"},
+	{windows.PAGE_EXECUTE_READWRITE, "PAGE_EXECUTE_READWRITE"},
+	{windows.PAGE_EXECUTE_WRITECOPY, "PAGE_EXECUTE_WRITECOPY"},
+}
+
+var pageModifierNames = []namedFlag{
+	{windows.PAGE_GUARD, "PAGE_GUARD"},
+	{windows.PAGE_NOCACHE, "PAGE_NOCACHE"},
+	{windows.PAGE_WRITECOMBINE, "PAGE_WRITECOMBINE"},
+}
+
+// flagName 返回与 v 完全相同的取值的名称，未知取值以十六进制表示，0 返回空字符串
+func flagName(v uint32, names []namedFlag) string {
+	if v == 0 {
+		return ""
+	}
+	for _, f := range names {
+		if f.value == v {
+			return f.name
+		}
+	}
+	return fmt.Sprintf("0x%X", v)
+}
+
+// ProtectName 返回 PAGE_* 内存保护常量的名称，修饰标志以 | 连接，0 返回空字符串
+func ProtectName(protect uint32) string {
+	if protect == 0 {
+		return ""
+	}
+	var parts []string
+	if base := protect & 0xFF; base != 0 {
+		parts = append(parts, flagName(base, pageProtectNames))
+	}
+	rest := protect &^ 0xFF
+	for _, f := range pageModifierNames {
+		if rest&f.value != 0 {
+			parts = append(parts, f.name)
+			rest &^= f.value
+		}
+	}
+	if rest != 0 {
+		parts = append(parts, flagName(rest, nil))
+	}
+	return strings.Join(parts, "|")
+}
+
+// Regions 返回指定进程整个用户地址空间中所有区域（包括空闲区域）的迭代器。
+// process 需要 PROCESS_QUERY_INFORMATION 访问权限。
+// 同一次分配的映像或映射区域只查询一次文件名，无法获取文件名时 FileName 为空。
+func Regions(process windows.Handle) iter.Seq2[Region, error] {
+	return regions(process, true)
+}
+
+func regions(process windows.Handle, withFileNames bool) iter.Seq2[Region, error] {
+	return func(yield func(Region, error) bool) {
+		var (
+			mbi      windows.MemoryBasicInformation
+			lastBase uintptr
+			lastName string
+		)
+		for addr := uintptr(0); ; {
+			_, err := VirtualQueryEx(process, addr, &mbi, unsafe.Sizeof(mbi))
+			if err != nil {
+				// 超出最高用户地址时返回 ERROR_INVALID_PARAMETER，表示遍历结束
+				if addr != 0 && errors.Is(err, windows.ERROR_INVALID_PARAMETER) {
+					return
+				}
+				yield(Region{}, err)
+				return
+			}
+			r := Region{
+				Base:              mbi.BaseAddress,
+				AllocationBase:    mbi.AllocationBase,
+				AllocationProtect: mbi.AllocationProtect,
+				Size:              mbi.RegionSize,
+				State:             mbi.State,
+				Type:              mbi.Type,
+				Protect:           mbi.Protect,
+			}
+			if withFileNames && (r.Type == MEM_IMAGE || r.Type == MEM_MAPPED) {
+				if r.AllocationBase != lastBase || lastName == "" {
+					lastBase, lastName = r.AllocationBase, mappedFileName(process, r.Base)
+				}
+				r.FileName = lastName
+			}
+			if !yield(r, nil) {
+				return
+			}
+			next := r.Base + r.Size
+			if next <= addr {
+				return
+			}
+			addr = next
+		}
+	}
+}
+
+// mappedFileName 返回地址所在映射文件的设备路径，失败时返回空字符串
+func mappedFileName(process windows.Handle, addr uintptr) string {
+	for size := uint32(windows.MAX_PATH); size <= windows.MAX_LONG_PATH; size *= 2 {
+		buf := make([]uint16, size)
+		n, err := GetMappedFileNameW(process, addr, &buf[0], size)
+		if err != nil {
+			return ""
+		}
+		// 缓冲区不足时返回值等于 nSize，字符串被截断
+		if n < size {
+			return windows.UTF16ToString(buf[:n])
+		}
+	}
+	return ""
+}
+
+// RegionTotals 是一类区域的合计，大小以字节为单位
+type RegionTotals struct {
+	Size      uint64 // 保留和已提交的总大小
+	Committed uint64 // 已提交的大小
+	Regions   int    // 区域数
+}
+
+func (t *RegionTotals) add(r Region) {
+	t.Size += uint64(r.Size)
+	if r.Committed() {
+		t.Committed += uint64(r.Size)
+	}
+	t.Regions++
+}
+
+// RegionSummary 按类型汇总进程的地址空间，与 VMMap 的摘要视图对应
+type RegionSummary struct {
+	Total   RegionTotals // 除空闲区域之外的所有区域
+	Image   RegionTotals // 映像文件（EXE、DLL）
+	Mapped  RegionTotals // 映射文件和共享内存
+	Private RegionTotals // 私有内存（堆、栈、VirtualAlloc）
+	Free    RegionTotals
+}
+
+// Add 将一个区域计入摘要
+func (s *RegionSummary) Add(r Region) {
+	if r.State == MEM_FREE {
+		s.Free.add(r)
+		return
+	}
+	s.Total.add(r)
+	switch r.Type {
+	case MEM_IMAGE:
+		s.Image.add(r)
+	case MEM_MAPPED:
+		s.Mapped.add(r)
+	case MEM_PRIVATE:
+		s.Private.add(r)
+	}
+}
+
+// SummarizeRegions 遍历指定进程的地址空间并按类型汇总，不查询映射文件名
+func SummarizeRegions(process windows.Handle) (RegionSummary, error) {
+	var s RegionSummary
+	for r, err := range regions(process, false) {
+		if err != nil {
+			return s, err
+		}
+		s.Add(r)
+	}
+	return s, nil
+}
@@ -0,0 +1,69 @@
+package xwindows
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/sys/windows"
+)
+
+func TestProtectName(t *testing.T) {
+	tests := []struct {
+		name    string
+		protect uint32
+		want    string
+	}{
+		{"zero", 0, ""},
+		{"read write", windows.PAGE_READWRITE, "PAGE_READWRITE"},
+		{"guard", windows.PAGE_READWRITE | windows.PAGE_GUARD, "PAGE_READWRITE|PAGE_GUARD"},
+		{"modifiers", windows.PAGE_EXECUTE_READ | windows.PAGE_NOCACHE | windows.PAGE_WRITECOMBINE, "PAGE_EXECUTE_READ|PAGE_NOCACHE|PAGE_WRITECOMBINE"},
+		{"unknown", windows.PAGE_READONLY | 0x40000000, "PAGE_READONLY|0x40000000"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ProtectName(tt.protect); got != tt.want {
+				t.Errorf("ProtectName(%#x) = %q, want %q", tt.protect, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegions(t *testing.T) {
+	var module windows.Handle
+	if err := windows.GetModuleHandleEx(0, nil, &module); err != nil {
+		t.Fatalf("GetModuleHandleEx() error = %v", err)
+	}
+	exe := uintptr(module)
+	var (
+		prev  uintptr
+		found bool
+		sum   RegionSummary
+	)
+	for r, err := range Regions(windows.CurrentProcess()) {
+		if err != nil {
+			t.Fatalf("Regions() error = %v", err)
+		}
+		if r.Base < prev {
+			t.Fatalf("region %#x is below previous end %#x", r.Base, prev)
+		}
+		prev = r.Base + r.Size
+		sum.Add(r)
+		if exe >= r.Base && exe < r.Base+r.Size {
+			found = true
+			if r.Type != MEM_IMAGE || !strings.HasSuffix(strings.ToLower(r.FileName), ".exe") {
+				t.Errorf("test binary region = %+v (%s), want MEM_IMAGE backed by .exe", r, r.TypeName())
+			}
+		}
+	}
+	if !found {
+		t.Errorf("Regions() did not cover the test binary")
+	}
+
+	got, err := SummarizeRegions(windows.CurrentProcess())
+	if err != nil {
+		t.Fatalf("SummarizeRegions() error = %v", err)
+	}
+	if got.Image.Committed == 0 || got.Private.Committed == 0 || got.Total.Regions == 0 {
+		t.Errorf("SummarizeRegions() = %+v", got)
+	}
+}
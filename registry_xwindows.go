@@ -0,0 +1,498 @@
+package xwindows
+
+import (
+	"encoding/binary"
+	"iter"
+	"runtime"
+	"sync"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// Key 是打开的注册表项句柄 (HKEY)。
+// access 参数是 KEY_* 访问权限的组合，32 位进程访问 64 位视图（或相反）时加上 KEY_WOW64_64KEY 或 KEY_WOW64_32KEY。
+//
+//	k, err := xwindows.OpenKey(xwindows.HKLM, `SOFTWARE\Agent`, windows.KEY_READ|windows.KEY_WOW64_64KEY)
+//	if err != nil {
+//		return err
+//	}
+//	defer k.Close()
+//	dir, err := k.String("InstallDir") // REG_EXPAND_SZ 中的环境变量被展开
+type Key windows.Handle
+
+// 预定义的根键，不需要关闭
+const (
+	HKCR = Key(windows.HKEY_CLASSES_ROOT)
+	HKCU = Key(windows.HKEY_CURRENT_USER)
+	HKLM = Key(windows.HKEY_LOCAL_MACHINE)
+	HKU  = Key(windows.HKEY_USERS)
+	HKCC = Key(windows.HKEY_CURRENT_CONFIG)
+)
+
+// OpenKey 打开 parent 下的子项 path，path 为空时打开 parent 本身的新句柄
+func OpenKey(parent Key, path string, access uint32) (Key, error) {
+	p, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return 0, err
+	}
+	var h windows.Handle
+	if err := windows.RegOpenKeyEx(windows.Handle(parent), p, 0, access, &h); err != nil {
+		return 0, err
+	}
+	return Key(h), nil
+}
+
+// CreateKey 打开 parent 下的子项 path，不存在时创建，包括路径中间缺少的项。created 报告是否新建了该项。
+func CreateKey(parent Key, path string, access uint32) (k Key, created bool, err error) {
+	p, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return 0, false, err
+	}
+	var h windows.Handle
+	var disposition uint32
+	if err := RegCreateKeyExW(windows.Handle(parent), p, nil, REG_OPTION_NON_VOLATILE, access, nil, &h, &disposition); err != nil {
+		return 0, false, err
+	}
+	return Key(h), disposition == REG_CREATED_NEW_KEY, nil
+}
+
+// DeleteKey 删除 parent 下没有子项的子项 path。access 为 KEY_WOW64_32KEY 或 KEY_WOW64_64KEY 时从对应的视图删除，否则为 0。
+func DeleteKey(parent Key, path string, access uint32) error {
+	p, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return err
+	}
+	return RegDeleteKeyExW(windows.Handle(parent), p, access)
+}
+
+// DeleteTree 递归删除 parent 下的子项 path 及其所有子项和值。
+// path 为空时只删除 parent 的子项和值，parent 本身保留。
+// parent 需要 DELETE、KEY_ENUMERATE_SUB_KEYS 和 KEY_QUERY_VALUE 访问权限。
+func DeleteTree(parent Key, path string) error {
+	p, err := optionalUTF16Ptr(path)
+	if err != nil {
+		return err
+	}
+	return RegDeleteTreeW(windows.Handle(parent), p)
+}
+
+// CopyTree 将 src 下的子项 path（为空时为 src 本身）的所有子项和值复制到 dst，
+// dst 中已有的同名值被覆盖。src 需要 KEY_READ，dst 需要 KEY_CREATE_SUB_KEY 和 KEY_SET_VALUE 访问权限。
+func CopyTree(src Key, path string, dst Key) error {
+	p, err := optionalUTF16Ptr(path)
+	if err != nil {
+		return err
+	}
+	return RegCopyTreeW(windows.Handle(src), p, windows.Handle(dst))
+}
+
+// optionalUTF16Ptr 将空字符串转换为 NULL
+func optionalUTF16Ptr(s string) (*uint16, error) {
+	if s == "" {
+		return nil, nil
+	}
+	return windows.UTF16PtrFromString(s)
+}
+
+// Handle 返回注册表项的句柄
+func (k Key) Handle() windows.Handle {
+	return windows.Handle(k)
+}
+
+// Close 关闭注册表项，对预定义的根键没有作用
+func (k Key) Close() error {
+	return windows.RegCloseKey(windows.Handle(k))
+}
+
+// Value 返回值 name 的类型 (REG_*) 和原始数据，name 为空时返回默认值
+func (k Key) Value(name string) (valueType uint32, data []byte, err error) {
+	p, err := windows.UTF16PtrFromString(name)
+	if err != nil {
+		return 0, nil, err
+	}
+	n := uint32(64)
+	for range 16 {
+		// 多分配 2 个字节，供没有结尾 NULL 的字符串使用
+		buf := make([]byte, n+2)
+		err := windows.RegQueryValueEx(windows.Handle(k), p, nil, &valueType, &buf[0], &n)
+		if err == windows.ERROR_MORE_DATA {
+			// 两次查询之间值可能变大，n 是最新的大小
+			continue
+		} else if err != nil {
+			return 0, nil, err
+		}
+		return valueType, buf[:n], nil
+	}
+	return 0, nil, ErrInsufficientBuffer
+}
+
+// String 返回 REG_SZ 或 REG_EXPAND_SZ 类型的值，REG_EXPAND_SZ 中的环境变量（如 %ProgramFiles%）被展开
+func (k Key) String(name string) (string, error) {
+	t, data, err := k.Value(name)
+	if err != nil {
+		return "", err
+	}
+	switch t {
+	case windows.REG_SZ:
+		return windows.UTF16ToString(bytesToUTF16(data)), nil
+	case windows.REG_EXPAND_SZ:
+		return expandString(bytesToUTF16(data))
+	}
+	return "", ErrUnexpectedType
+}
+
+// RawString 返回 REG_SZ 或 REG_EXPAND_SZ 类型的值和实际类型，不展开环境变量
+func (k Key) RawString(name string) (s string, valueType uint32, err error) {
+	t, data, err := k.Value(name)
+	if err != nil {
+		return "", 0, err
+	}
+	if t != windows.REG_SZ && t != windows.REG_EXPAND_SZ {
+		return "", t, ErrUnexpectedType
+	}
+	return windows.UTF16ToString(bytesToUTF16(data)), t, nil
+}
+
+// Strings 返回 REG_MULTI_SZ 类型的值
+func (k Key) Strings(name string) ([]string, error) {
+	t, data, err := k.Value(name)
+	if err != nil {
+		return nil, err
+	}
+	if t != windows.REG_MULTI_SZ {
+		return nil, ErrUnexpectedType
+	}
+	// 字符串以 NULL 分隔，以空字符串结束；数据可能缺少最后的 NULL
+	var list []string
+	u := bytesToUTF16(data)
+	for len(u) > 0 && u[0] != 0 {
+		i := 0
+		for i < len(u) && u[i] != 0 {
+			i++
+		}
+		list = append(list, windows.UTF16ToString(u[:i]))
+		u = u[min(i+1, len(u)):]
+	}
+	return list, nil
+}
+
+// DWORD 返回 REG_DWORD 类型的值
+func (k Key) DWORD(name string) (uint32, error) {
+	t, data, err := k.Value(name)
+	if err != nil {
+		return 0, err
+	}
+	if t != windows.REG_DWORD || len(data) != 4 {
+		return 0, ErrUnexpectedType
+	}
+	return binary.LittleEndian.Uint32(data), nil
+}
+
+// QWORD 返回 REG_QWORD 类型的值
+func (k Key) QWORD(name string) (uint64, error) {
+	t, data, err := k.Value(name)
+	if err != nil {
+		return 0, err
+	}
+	if t != windows.REG_QWORD || len(data) != 8 {
+		return 0, ErrUnexpectedType
+	}
+	return binary.LittleEndian.Uint64(data), nil
+}
+
+// Binary 返回 REG_BINARY 类型的值
+func (k Key) Binary(name string) ([]byte, error) {
+	t, data, err := k.Value(name)
+	if err != nil {
+		return nil, err
+	}
+	if t != windows.REG_BINARY {
+		return nil, ErrUnexpectedType
+	}
+	return data, nil
+}
+
+// SetValue 以类型 valueType (REG_*) 设置值 name 的原始数据，name 为空时设置默认值
+func (k Key) SetValue(name string, valueType uint32, data []byte) error {
+	p, err := windows.UTF16PtrFromString(name)
+	if err != nil {
+		return err
+	}
+	var d *byte
+	if len(data) > 0 {
+		d = &data[0]
+	}
+	return RegSetValueExW(windows.Handle(k), p, valueType, d, uint32(len(data)))
+}
+
+// SetString 将值 name 设置为 REG_SZ 类型的字符串
+func (k Key) SetString(name, value string) error {
+	return k.setString(name, windows.REG_SZ, value)
+}
+
+// SetExpandString 将值 name 设置为 REG_EXPAND_SZ 类型的字符串，读取时其中的 %name% 被展开
+func (k Key) SetExpandString(name, value string) error {
+	return k.setString(name, windows.REG_EXPAND_SZ, value)
+}
+
+func (k Key) setString(name string, valueType uint32, value string) error {
+	u, err := windows.UTF16FromString(value)
+	if err != nil {
+		return err
+	}
+	return k.SetValue(name, valueType, utf16ToBytes(u))
+}
+
+// SetStrings 将值 name 设置为 REG_MULTI_SZ 类型的字符串列表，列表中不能有空字符串
+func (k Key) SetStrings(name string, value []string) error {
+	var u []uint16
+	for _, s := range value {
+		if s == "" {
+			return ErrInvalidParameter
+		}
+		v, err := windows.UTF16FromString(s)
+		if err != nil {
+			return err
+		}
+		u = append(u, v...)
+	}
+	u = append(u, 0)
+	return k.SetValue(name, windows.REG_MULTI_SZ, utf16ToBytes(u))
+}
+
+// SetDWORD 将值 name 设置为 REG_DWORD 类型的 32 位整数
+func (k Key) SetDWORD(name string, value uint32) error {
+	return k.SetValue(name, windows.REG_DWORD, binary.LittleEndian.AppendUint32(nil, value))
+}
+
+// SetQWORD 将值 name 设置为 REG_QWORD 类型的 64 位整数
+func (k Key) SetQWORD(name string, value uint64) error {
+	return k.SetValue(name, windows.REG_QWORD, binary.LittleEndian.AppendUint64(nil, value))
+}
+
+// SetBinary 将值 name 设置为 REG_BINARY 类型的数据
+func (k Key) SetBinary(name string, value []byte) error {
+	return k.SetValue(name, windows.REG_BINARY, value)
+}
+
+// DeleteValue 删除值 name
+func (k Key) DeleteValue(name string) error {
+	p, err := windows.UTF16PtrFromString(name)
+	if err != nil {
+		return err
+	}
+	return RegDeleteValueW(windows.Handle(k), p)
+}
+
+// SubKeys 返回子项名称的迭代器，k 需要 KEY_ENUMERATE_SUB_KEYS 访问权限。
+// 迭代期间创建或删除子项时，结果可能遗漏或重复。
+func (k Key) SubKeys() iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		buf := make([]uint16, 256) // 子项名称最多 255 个字符
+		for i := uint32(0); ; i++ {
+			n := uint32(len(buf))
+			err := windows.RegEnumKeyEx(windows.Handle(k), i, &buf[0], &n, nil, nil, nil, nil)
+			if err == windows.ERROR_NO_MORE_ITEMS {
+				return
+			}
+			if err != nil {
+				yield("", err)
+				return
+			}
+			if !yield(windows.UTF16ToString(buf[:n]), nil) {
+				return
+			}
+		}
+	}
+}
+
+// Values 返回值名称的迭代器，默认值的名称为空字符串，k 需要 KEY_QUERY_VALUE 访问权限。
+// 迭代期间创建或删除值时，结果可能遗漏或重复。
+func (k Key) Values() iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		buf := make([]uint16, 16384) // 值名称最多 16383 个字符
+		for i := uint32(0); ; i++ {
+			n := uint32(len(buf))
+			err := RegEnumValueW(windows.Handle(k), i, &buf[0], &n, nil, nil, nil)
+			if err == windows.ERROR_NO_MORE_ITEMS {
+				return
+			}
+			if err != nil {
+				yield("", err)
+				return
+			}
+			if !yield(windows.UTF16ToString(buf[:n]), nil) {
+				return
+			}
+		}
+	}
+}
+
+// Security 返回注册表项的安全描述符（自相对格式），info 是 OWNER_SECURITY_INFORMATION、
+// DACL_SECURITY_INFORMATION 等的组合。读取 SACL 需要 ACCESS_SYSTEM_SECURITY 访问权限。
+func (k Key) Security(info windows.SECURITY_INFORMATION) (*windows.SECURITY_DESCRIPTOR, error) {
+	n := uint32(256)
+	for range 16 {
+		buf := alignedBuffer(n)
+		err := RegGetKeySecurity(windows.Handle(k), info, &buf[0], &n)
+		if err == windows.ERROR_INSUFFICIENT_BUFFER {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		return (*windows.SECURITY_DESCRIPTOR)(unsafe.Pointer(&buf[0])), nil
+	}
+	return nil, ErrInsufficientBuffer
+}
+
+// SetSecurity 设置注册表项的安全描述符中由 info 指定的部分。
+// 修改 DACL 需要 WRITE_DAC，修改所有者需要 WRITE_OWNER 访问权限。
+//
+//	sd, err := windows.SecurityDescriptorFromString("D:P(A;OICI;KA;;;SY)(A;OICI;KA;;;BA)")
+//	if err != nil {
+//		return err
+//	}
+//	err = k.SetSecurity(windows.DACL_SECURITY_INFORMATION, sd)
+func (k Key) SetSecurity(info windows.SECURITY_INFORMATION, sd *windows.SECURITY_DESCRIPTOR) error {
+	return RegSetKeySecurity(windows.Handle(k), info, sd)
+}
+
+// Watch 监视注册表项的修改，filter 是 REG_NOTIFY_CHANGE_* 的组合，subtree 为 true 时同时监视所有子项。
+// k 需要 KEY_NOTIFY 访问权限，并且在监视期间保持打开。
+//
+// 与 Ticker 相同，C 的缓冲区为 1，接收方处理期间发生的多次修改合并为一次通知。
+// 通知只说明有修改，接收方需要重新读取所关心的值。
+//
+//	w, err := k.Watch(false, windows.REG_NOTIFY_CHANGE_LAST_SET)
+//	if err != nil {
+//		return err
+//	}
+//	defer w.Stop()
+//	for range w.C {
+//		reload(k)
+//	}
+//	return w.Err()
+func (k Key) Watch(subtree bool, filter uint32) (*KeyWatcher, error) {
+	event, err := windows.CreateEvent(nil, 0, 0, nil)
+	if err != nil {
+		return nil, err
+	}
+	stop, err := windows.CreateEvent(nil, 1, 0, nil)
+	if err != nil {
+		CloseHandle(event)
+		return nil, err
+	}
+	c := make(chan struct{}, 1)
+	w := &KeyWatcher{C: c, stop: stop, done: make(chan struct{})}
+	ready := make(chan error, 1)
+	go w.run(k, subtree, filter, event, c, ready)
+	if err := <-ready; err != nil {
+		<-w.done
+		CloseHandle(stop)
+		return nil, err
+	}
+	return w, nil
+}
+
+// KeyWatcher 在注册表项被修改时在 C 上发送通知，由 Key.Watch 创建
+type KeyWatcher struct {
+	C <-chan struct{}
+
+	stop     windows.Handle
+	done     chan struct{}
+	err      error
+	stopOnce sync.Once
+	stopErr  error
+}
+
+// run 在锁定的线程上注册通知：注册线程退出时通知被取消，锁定线程保证监视期间线程存活
+func (w *KeyWatcher) run(k Key, subtree bool, filter uint32, event windows.Handle, c chan<- struct{}, ready chan<- error) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	defer close(w.done)
+	defer CloseHandle(event)
+	defer close(c)
+
+	err := windows.RegNotifyChangeKeyValue(windows.Handle(k), subtree, filter, event, true)
+	ready <- err
+	if err != nil {
+		return
+	}
+	handles := []windows.Handle{event, w.stop}
+	for {
+		e, err := windows.WaitForMultipleObjects(handles, false, windows.INFINITE)
+		if err != nil {
+			w.err = err
+			return
+		}
+		if e != windows.WAIT_OBJECT_0 {
+			return
+		}
+		// 先重新注册再通知，接收方读取期间的修改不会遗漏。
+		// 项被删除或句柄被关闭时事件也会触发，此时重新注册失败并停止监视。
+		if err := windows.RegNotifyChangeKeyValue(windows.Handle(k), subtree, filter, event, true); err != nil {
+			w.err = err
+			return
+		}
+		select {
+		case c <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Err 返回监视异常停止的原因（如 ERROR_KEY_DELETED），在 C 被关闭之前以及由 Stop 停止时为 nil
+func (w *KeyWatcher) Err() error {
+	select {
+	case <-w.done:
+		return w.err
+	default:
+		return nil
+	}
+}
+
+// Stop 停止监视并关闭 C，重复调用返回相同的结果
+func (w *KeyWatcher) Stop() error {
+	w.stopOnce.Do(func() {
+		windows.SetEvent(w.stop)
+		<-w.done
+		w.stopErr = CloseHandle(w.stop)
+	})
+	return w.stopErr
+}
+
+// expandString 展开 REG_EXPAND_SZ 中的环境变量
+func expandString(u []uint16) (string, error) {
+	src := append(u[:len(u):len(u)], 0)
+	n := uint32(len(src) + 64)
+	for range 16 {
+		buf := make([]uint16, n)
+		r, err := windows.ExpandEnvironmentStrings(&src[0], &buf[0], n)
+		if err != nil {
+			return "", err
+		}
+		if r <= n {
+			return windows.UTF16ToString(buf[:r]), nil
+		}
+		n = r
+	}
+	return "", ErrInsufficientBuffer
+}
+
+// bytesToUTF16 将注册表值的数据解释为 UTF-16 字符串，忽略末尾的奇数字节
+func bytesToUTF16(b []byte) []uint16 {
+	u := make([]uint16, len(b)/2)
+	for i := range u {
+		u[i] = binary.LittleEndian.Uint16(b[2*i:])
+	}
+	return u
+}
+
+func utf16ToBytes(u []uint16) []byte {
+	b := make([]byte, 0, 2*len(u))
+	for _, c := range u {
+		b = binary.LittleEndian.AppendUint16(b, c)
+	}
+	return b
+}
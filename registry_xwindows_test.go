@@ -0,0 +1,235 @@
+package xwindows
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"testing"
+	"time"
+
+	"golang.org/x/sys/windows"
+)
+
+// testKey 在 HKCU\Software 下创建临时的注册表项，测试结束时删除
+func testKey(t *testing.T) (Key, string) {
+	t.Helper()
+	path := fmt.Sprintf(`Software\xwindows-test-%d-%s`, os.Getpid(), t.Name())
+	k, created, err := CreateKey(HKCU, path, windows.KEY_ALL_ACCESS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !created {
+		t.Fatalf("CreateKey(%s) opened an existing key", path)
+	}
+	t.Cleanup(func() {
+		k.Close()
+		if err := DeleteTree(HKCU, path); err != nil {
+			t.Error(err)
+		}
+	})
+	return k, path
+}
+
+func TestKeyValues(t *testing.T) {
+	k, _ := testKey(t)
+	bin := []byte{0, 1, 2, 0xff}
+	multi := []string{"a", "bc", "中文"}
+	must := func(err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	must(k.SetString("sz", "hello"))
+	must(k.SetString("", "default"))
+	must(k.SetExpandString("expand", `%SystemRoot%\System32`))
+	must(k.SetStrings("multi", multi))
+	must(k.SetStrings("empty", nil))
+	must(k.SetDWORD("dword", 0xdeadbeef))
+	must(k.SetQWORD("qword", 1<<40|7))
+	must(k.SetBinary("binary", bin))
+
+	if s, err := k.String("sz"); err != nil || s != "hello" {
+		t.Errorf("String(sz) = %q, %v", s, err)
+	}
+	if s, err := k.String(""); err != nil || s != "default" {
+		t.Errorf("String(default) = %q, %v", s, err)
+	}
+	want := os.Getenv("SystemRoot") + `\System32`
+	if s, err := k.String("expand"); err != nil || s != want {
+		t.Errorf("String(expand) = %q, %v; want %q", s, err, want)
+	}
+	if s, typ, err := k.RawString("expand"); err != nil || s != `%SystemRoot%\System32` || typ != windows.REG_EXPAND_SZ {
+		t.Errorf("RawString(expand) = %q, %d, %v", s, typ, err)
+	}
+	if got, err := k.Strings("multi"); err != nil || !slices.Equal(got, multi) {
+		t.Errorf("Strings(multi) = %q, %v", got, err)
+	}
+	if got, err := k.Strings("empty"); err != nil || len(got) != 0 {
+		t.Errorf("Strings(empty) = %q, %v", got, err)
+	}
+	if v, err := k.DWORD("dword"); err != nil || v != 0xdeadbeef {
+		t.Errorf("DWORD = %#x, %v", v, err)
+	}
+	if v, err := k.QWORD("qword"); err != nil || v != 1<<40|7 {
+		t.Errorf("QWORD = %#x, %v", v, err)
+	}
+	if v, err := k.Binary("binary"); err != nil || !bytes.Equal(v, bin) {
+		t.Errorf("Binary = %x, %v", v, err)
+	}
+	if _, err := k.DWORD("sz"); !errors.Is(err, ErrUnexpectedType) {
+		t.Errorf("DWORD(sz) error = %v", err)
+	}
+	if err := k.SetStrings("bad", []string{"a", ""}); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("SetStrings with an empty string: %v", err)
+	}
+
+	// 超过初始缓冲区的值
+	long := bytes.Repeat([]byte{0x5a}, 4096)
+	must(k.SetBinary("long", long))
+	if v, err := k.Binary("long"); err != nil || !bytes.Equal(v, long) {
+		t.Errorf("Binary(long) = %d bytes, %v", len(v), err)
+	}
+
+	var names []string
+	for name, err := range k.Values() {
+		must(err)
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	wantNames := []string{"", "binary", "dword", "empty", "expand", "long", "multi", "qword", "sz"}
+	if !slices.Equal(names, wantNames) {
+		t.Errorf("Values() = %q, want %q", names, wantNames)
+	}
+
+	must(k.DeleteValue("sz"))
+	if _, err := k.String("sz"); err != windows.ERROR_FILE_NOT_FOUND {
+		t.Errorf("String after DeleteValue: %v", err)
+	}
+}
+
+func TestKeyTree(t *testing.T) {
+	k, path := testKey(t)
+	for _, sub := range []string{`a`, `b\c`, `b\d`} {
+		s, created, err := CreateKey(k, sub, windows.KEY_ALL_ACCESS)
+		if err != nil || !created {
+			t.Fatalf("CreateKey(%s) = %v, %v", sub, created, err)
+		}
+		if err := s.SetString("name", sub); err != nil {
+			t.Fatal(err)
+		}
+		s.Close()
+	}
+	if _, created, err := CreateKey(k, "a", windows.KEY_READ); err != nil || created {
+		t.Errorf("CreateKey(existing) = %v, %v", created, err)
+	}
+
+	var subs []string
+	for name, err := range k.SubKeys() {
+		if err != nil {
+			t.Fatal(err)
+		}
+		subs = append(subs, name)
+	}
+	slices.Sort(subs)
+	if !slices.Equal(subs, []string{"a", "b"}) {
+		t.Errorf("SubKeys() = %q", subs)
+	}
+
+	// 复制 b 到 copy，再比较其中的值
+	dst, _, err := CreateKey(k, "copy", windows.KEY_ALL_ACCESS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dst.Close()
+	if err := CopyTree(k, "b", dst); err != nil {
+		t.Fatal(err)
+	}
+	c, err := OpenKey(HKCU, path+`\copy\c`, windows.KEY_READ)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s, err := c.String("name"); err != nil || s != `b\c` {
+		t.Errorf("copied value = %q, %v", s, err)
+	}
+	c.Close()
+
+	if err := DeleteKey(k, "b", 0); err == nil {
+		t.Error("DeleteKey deleted a key with subkeys")
+	}
+	if err := DeleteKey(k, "a", 0); err != nil {
+		t.Error(err)
+	}
+	if err := DeleteTree(k, "b"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := OpenKey(k, "b", windows.KEY_READ); err != windows.ERROR_FILE_NOT_FOUND {
+		t.Errorf("OpenKey after DeleteTree: %v", err)
+	}
+}
+
+func TestKeySecurity(t *testing.T) {
+	k, _ := testKey(t)
+	sd, err := windows.SecurityDescriptorFromString("D:P(A;OICI;KA;;;SY)(A;OICI;KA;;;BA)(A;OICI;KA;;;OW)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := k.SetSecurity(windows.DACL_SECURITY_INFORMATION, sd); err != nil {
+		t.Fatal(err)
+	}
+	got, err := k.Security(windows.DACL_SECURITY_INFORMATION)
+	if err != nil {
+		t.Fatal(err)
+	}
+	control, _, err := got.Control()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if control&windows.SE_DACL_PROTECTED == 0 {
+		t.Errorf("DACL is not protected: %s", got)
+	}
+	dacl, _, err := got.DACL()
+	if err != nil || dacl.AceCount != 3 {
+		t.Errorf("DACL() = %v, %v; want 3 ACEs (%s)", dacl, err, got)
+	}
+}
+
+func TestKeyWatch(t *testing.T) {
+	k, _ := testKey(t)
+	w, err := k.Watch(true, windows.REG_NOTIFY_CHANGE_LAST_SET|windows.REG_NOTIFY_CHANGE_NAME)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Stop()
+
+	if err := k.SetDWORD("v", 1); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-w.C:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no notification for SetDWORD")
+	}
+	s, _, err := CreateKey(k, "sub", windows.KEY_ALL_ACCESS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Close()
+	select {
+	case <-w.C:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no notification for CreateKey")
+	}
+
+	if err := w.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := <-w.C; ok {
+		t.Error("C is not closed after Stop")
+	}
+	if err := w.Err(); err != nil {
+		t.Errorf("Err() after Stop = %v", err)
+	}
+}
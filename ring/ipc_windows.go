@@ -0,0 +1,163 @@
+package ring
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/C1ph3rX13/xwindows"
+	"golang.org/x/sys/windows"
+)
+
+// eventName 返回槽位 slot 的唤醒事件名称，与共享内存位于同一命名空间
+func eventName(name string, slot int) string {
+	return fmt.Sprintf("%s.event.%d", name, slot)
+}
+
+// processAlive 报告进程是否仍在运行，无权打开的进程视为存活
+func processAlive(pid uint32) bool {
+	h, err := xwindows.OpenProcess(windows.SYNCHRONIZE, false, pid)
+	if err != nil {
+		return !errors.Is(err, windows.ERROR_INVALID_PARAMETER)
+	}
+	defer xwindows.CloseHandle(h)
+	event, err := xwindows.WaitForSingleObject(h, 0)
+	return err == nil && event == uint32(windows.WAIT_TIMEOUT)
+}
+
+// SharedProducer 是位于命名共享内存中的环形缓冲区的生产者，每个消费者槽位对应一个自动重置事件
+type SharedProducer struct {
+	*Producer
+	mem    *xwindows.Mapping
+	events []windows.Handle
+}
+
+// CreateProducer 创建（或在生产者重启后恢复）名为 name 的环形缓冲区。
+// name 可以带 Local\ 或 Global\ 前缀，唤醒事件使用 name 加 .event.N 后缀。
+// 已退出进程占用的消费者槽位会被释放。
+func CreateProducer(name string, capacity, slots int) (*SharedProducer, error) {
+	mem, err := xwindows.CreateSharedMemory(name, int64(Size(capacity, slots)))
+	if err != nil {
+		return nil, err
+	}
+	p := &SharedProducer{mem: mem}
+	if p.Producer, err = Init(mem.Bytes(), capacity, slots, uint32(os.Getpid())); err != nil {
+		p.Close()
+		return nil, err
+	}
+	for i := range slots {
+		event, err := xwindows.CreateEventW(nil, false, false, eventName(name, i))
+		if err != nil && !errors.Is(err, windows.ERROR_ALREADY_EXISTS) {
+			p.Close()
+			return nil, err
+		}
+		p.events = append(p.events, event)
+	}
+	p.Reap()
+	return p, nil
+}
+
+// Write 写入一条记录并唤醒所有活动的消费者
+func (p *SharedProducer) Write(rec []byte) error {
+	if err := p.Producer.Write(rec); err != nil {
+		return err
+	}
+	for i, event := range p.events {
+		if p.Active(i) {
+			xwindows.SetEvent(event)
+		}
+	}
+	return nil
+}
+
+// Reap 释放已退出进程占用的消费者槽位
+func (p *SharedProducer) Reap() int {
+	return p.Producer.Reap(processAlive)
+}
+
+// Close 关闭事件和共享内存。已打开的消费者仍可读取已发布的数据。
+func (p *SharedProducer) Close() error {
+	var errs []error
+	for _, event := range p.events {
+		errs = append(errs, xwindows.CloseHandle(event))
+	}
+	p.events = nil
+	errs = append(errs, p.mem.Close())
+	return errors.Join(errs...)
+}
+
+// SharedConsumer 是命名环形缓冲区的消费者
+type SharedConsumer struct {
+	*Consumer
+	mem   *xwindows.Mapping
+	event windows.Handle
+}
+
+// OpenConsumer 打开 CreateProducer 创建的环形缓冲区并占用一个消费者槽位。
+// 槽位已满时先释放已退出进程占用的槽位再重试。
+func OpenConsumer(name string) (*SharedConsumer, error) {
+	mem, err := xwindows.OpenSharedMemory(name)
+	if err != nil {
+		return nil, err
+	}
+	c := &SharedConsumer{mem: mem}
+	pid := uint32(os.Getpid())
+	c.Consumer, err = Attach(mem.Bytes(), pid)
+	if errors.Is(err, ErrNoSlots) {
+		if l, verr := view(mem.Bytes()); verr == nil && l.readGeometry() == nil && reap(l, processAlive) > 0 {
+			c.Consumer, err = Attach(mem.Bytes(), pid)
+		}
+	}
+	if err != nil {
+		mem.Close()
+		return nil, err
+	}
+	if c.event, err = xwindows.OpenEventW(windows.SYNCHRONIZE, false, eventName(name, c.Slot())); err != nil {
+		c.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// Read 读取下一条记录并追加到 dst[:0]，没有新记录时等待生产者唤醒。
+// timeout 为负数时无限等待，超时返回 xwindows.ErrTimeout。
+func (c *SharedConsumer) Read(dst []byte, timeout time.Duration) ([]byte, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		rec, ok, err := c.Next(dst)
+		if err != nil || ok {
+			return rec, err
+		}
+		wait := uint32(windows.INFINITE)
+		if timeout >= 0 {
+			remaining := time.Until(deadline)
+			if remaining <= 0 {
+				return nil, xwindows.ErrTimeout
+			}
+			wait = uint32((remaining + time.Millisecond - 1) / time.Millisecond)
+		}
+		if _, err := xwindows.WaitForSingleObject(c.event, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// ProducerAlive 报告最近一次初始化缓冲区的生产者进程是否仍在运行
+func (c *SharedConsumer) ProducerAlive() bool {
+	return processAlive(c.ProducerPID())
+}
+
+// Close 释放槽位并关闭事件和共享内存
+func (c *SharedConsumer) Close() error {
+	var errs []error
+	if c.Consumer != nil {
+		errs = append(errs, c.Consumer.Close())
+	}
+	if c.event != 0 {
+		errs = append(errs, xwindows.CloseHandle(c.event))
+		c.event = 0
+	}
+	errs = append(errs, c.mem.Close())
+	return errors.Join(errs...)
+}
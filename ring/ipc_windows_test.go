@@ -0,0 +1,42 @@
+package ring
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/C1ph3rX13/xwindows"
+)
+
+func TestSharedRing(t *testing.T) {
+	name := fmt.Sprintf(`Local\xwindows-ring-%d`, os.Getpid())
+	p, err := CreateProducer(name, 4096, 2)
+	if err != nil {
+		t.Fatalf("CreateProducer() error = %v", err)
+	}
+	defer p.Close()
+
+	c, err := OpenConsumer(name)
+	if err != nil {
+		t.Fatalf("OpenConsumer() error = %v", err)
+	}
+	defer c.Close()
+	if !c.ProducerAlive() {
+		t.Errorf("ProducerAlive() = false for the current process")
+	}
+
+	if _, err := c.Read(nil, 10*time.Millisecond); !errors.Is(err, xwindows.ErrTimeout) {
+		t.Fatalf("Read() on empty ring error = %v, want ErrTimeout", err)
+	}
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		p.Write([]byte("telemetry"))
+	}()
+	rec, err := c.Read(nil, 5*time.Second)
+	if err != nil || string(rec) != "telemetry" {
+		t.Errorf("Read() = %q, %v, want telemetry", rec, err)
+	}
+}
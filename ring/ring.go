@@ -0,0 +1,414 @@
+// Package ring 实现位于共享内存区域中的单生产者多消费者（SPMC）环形缓冲区。
+//
+// 区域布局与平台无关，可以在普通的 []byte 上测试；Windows 上由 CreateProducer/OpenConsumer
+// 将其放在命名共享内存中，并使用命名事件唤醒消费者。
+//
+// 缓冲区是有损的：生产者从不等待消费者，落后超过一圈的消费者会跳到最新位置并记录一次溢出。
+// 生产者在写入记录之前先推进保留位置，消费者复制记录后检查保留位置，
+// 以发现在复制期间被覆盖（撕裂）的记录，这与 seqlock 的读端校验相同。
+//
+// 区域布局（所有字段均为本机字节序的 8 字节字）：
+//
+//	0    magic、version、capacity、slots、epoch、producer pid
+//	64   reserve（生产者已保留的位置）、write（已发布的位置）
+//	128  slots 个消费者槽位，每个 64 字节：owner pid、读取位置、溢出次数
+//	...  capacity 字节的数据区，记录为 8 字节长度头加数据，按 8 字节对齐
+package ring
+
+import (
+	"errors"
+	"runtime"
+	"sync/atomic"
+	"unsafe"
+)
+
+const (
+	Magic   = 0x42525758 // "XWRB"
+	Version = 1
+
+	headerSize  = 128
+	slotSize    = 64
+	wordSize    = 8
+	recordHead  = wordSize
+	padding     = 0xFFFFFFFF // 数据区末尾不足以容纳记录时写入的填充标记
+	minCapacity = 64
+)
+
+// 头部字段的字偏移
+const (
+	offIdent    = 0 // magic | version<<32
+	offGeometry = 1 // capacity | slots<<32
+	offEpoch    = 2
+	offProducer = 3
+	offReserve  = 8
+	offWrite    = 9
+)
+
+// 槽位字段的字偏移
+const (
+	slotOwner    = 0
+	slotPosition = 1
+	slotOverruns = 2
+)
+
+var (
+	ErrRegionSize  = errors.New("ring: region too small or misaligned")
+	ErrGeometry    = errors.New("ring: capacity must be a power of two of at least 64 bytes")
+	ErrBadMagic    = errors.New("ring: region is not initialized")
+	ErrVersion     = errors.New("ring: layout version or geometry changed")
+	ErrTooLarge    = errors.New("ring: record larger than half the capacity")
+	ErrNoSlots     = errors.New("ring: all consumer slots are in use")
+	ErrCorrupt     = errors.New("ring: corrupt record header")
+	ErrInvalidSlot = errors.New("ring: invalid consumer slot")
+)
+
+// Size 返回容纳 capacity 字节数据和 slots 个消费者所需的区域大小
+func Size(capacity, slots int) int {
+	return headerSize + slots*slotSize + capacity
+}
+
+// layout 是区域的视图，所有共享字段都通过原子操作访问
+type layout struct {
+	words    []atomic.Uint64 // 整个区域按 8 字节划分
+	capacity uint64
+	slots    int
+	data     int // 数据区起始字偏移
+}
+
+func view(region []byte) (*layout, error) {
+	if len(region) < headerSize {
+		return nil, ErrRegionSize
+	}
+	// 转换结果传给普通函数，固定底层数组以满足 unsafe 规则
+	var p runtime.Pinner
+	defer p.Unpin()
+	p.Pin(unsafe.SliceData(region))
+	if !aligned(uintptr(unsafe.Pointer(unsafe.SliceData(region)))) {
+		return nil, ErrRegionSize
+	}
+	words := unsafe.Slice((*atomic.Uint64)(unsafe.Pointer(unsafe.SliceData(region))), len(region)/wordSize)
+	return &layout{words: words}, nil
+}
+
+// aligned 报告地址是否按字对齐，原子操作要求 8 字节对齐
+func aligned(addr uintptr) bool {
+	return addr%wordSize == 0
+}
+
+// setGeometry 根据 capacity 和 slots 计算数据区位置并检查区域大小
+func (l *layout) setGeometry(capacity, slots int) error {
+	if capacity < minCapacity || capacity&(capacity-1) != 0 || slots <= 0 {
+		return ErrGeometry
+	}
+	if len(l.words)*wordSize < Size(capacity, slots) {
+		return ErrRegionSize
+	}
+	l.capacity = uint64(capacity)
+	l.slots = slots
+	l.data = (headerSize + slots*slotSize) / wordSize
+	return nil
+}
+
+func (l *layout) header(off int) *atomic.Uint64 {
+	return &l.words[off]
+}
+
+func (l *layout) slot(i, field int) *atomic.Uint64 {
+	return &l.words[headerSize/wordSize+i*slotSize/wordSize+field]
+}
+
+// dataWord 返回数据区中位置 pos 处的字
+func (l *layout) dataWord(pos uint64) *atomic.Uint64 {
+	return &l.words[l.data+int(pos%l.capacity/wordSize)]
+}
+
+// readGeometry 从头部读取并校验布局
+func (l *layout) readGeometry() error {
+	ident := l.header(offIdent).Load()
+	if uint32(ident) != Magic {
+		return ErrBadMagic
+	}
+	if uint32(ident>>32) != Version {
+		return ErrVersion
+	}
+	g := l.header(offGeometry).Load()
+	return l.setGeometry(int(uint32(g)), int(g>>32))
+}
+
+func align(n uint64) uint64 {
+	return (n + wordSize - 1) &^ (wordSize - 1)
+}
+
+// Producer 是环形缓冲区的唯一写端
+type Producer struct {
+	l *layout
+}
+
+// Init 在区域中初始化环形缓冲区并成为生产者，pid 记录在头部供消费者检查生产者是否存活。
+//
+// 如果区域中已有相同版本和几何参数的缓冲区（例如生产者崩溃后重启），则保留已发布的数据、
+// 写入位置和消费者槽位，只递增 epoch；否则清空区域并重新初始化。
+// 生产者崩溃时只可能丢失尚未发布的记录：保留位置会回退到写入位置。
+func Init(region []byte, capacity, slots int, pid uint32) (*Producer, error) {
+	l, err := view(region)
+	if err != nil {
+		return nil, err
+	}
+	if err := l.setGeometry(capacity, slots); err != nil {
+		return nil, err
+	}
+
+	existing := &layout{words: l.words}
+	if existing.readGeometry() == nil && existing.capacity == l.capacity && existing.slots == l.slots {
+		// 恢复：丢弃崩溃时未发布的保留区间
+		l.header(offReserve).Store(l.header(offWrite).Load())
+	} else {
+		// 先清除 magic，使并发的消费者在初始化完成前拒绝附加。
+		// epoch 不清零：重新初始化后必须与旧消费者记录的值不同，否则旧消费者无法发现几何参数的变化
+		l.header(offIdent).Store(0)
+		for i := 1; i < l.data; i++ {
+			if i != offEpoch {
+				l.words[i].Store(0)
+			}
+		}
+		l.header(offGeometry).Store(uint64(capacity) | uint64(slots)<<32)
+	}
+	l.header(offProducer).Store(uint64(pid))
+	l.header(offEpoch).Add(1)
+	l.header(offIdent).Store(Magic | Version<<32)
+	return &Producer{l: l}, nil
+}
+
+// Capacity 返回数据区大小
+func (p *Producer) Capacity() int {
+	return int(p.l.capacity)
+}
+
+// MaxRecord 返回单条记录的最大长度
+func (p *Producer) MaxRecord() int {
+	return int(p.l.capacity/2) - recordHead
+}
+
+// Epoch 返回生产者初始化的次数
+func (p *Producer) Epoch() uint64 {
+	return p.l.header(offEpoch).Load()
+}
+
+// Write 追加一条记录并发布。缓冲区满时覆盖最旧的记录，从不阻塞。
+func (p *Producer) Write(rec []byte) error {
+	if len(rec) > p.MaxRecord() {
+		return ErrTooLarge
+	}
+	l := p.l
+	pos := l.header(offWrite).Load()
+	total := align(recordHead + uint64(len(rec)))
+
+	// 记录不跨越数据区末尾，剩余空间写入填充标记
+	var pad uint64
+	if rest := l.capacity - pos%l.capacity; rest < total {
+		pad = rest
+	}
+	l.header(offReserve).Store(pos + pad + total)
+
+	if pad != 0 {
+		l.dataWord(pos).Store(padding)
+		pos += pad
+	}
+	l.dataWord(pos).Store(uint64(len(rec)))
+	for i := 0; i < len(rec); i += wordSize {
+		var w [wordSize]byte
+		copy(w[:], rec[i:])
+		l.dataWord(pos + recordHead + uint64(i)).Store(*(*uint64)(unsafe.Pointer(&w)))
+	}
+	l.header(offWrite).Store(pos + total)
+	return nil
+}
+
+// Active 报告消费者槽位 i 是否被占用
+func (p *Producer) Active(i int) bool {
+	return p.l.slot(i, slotOwner).Load() != 0
+}
+
+// Slots 返回消费者槽位数
+func (p *Producer) Slots() int {
+	return p.l.slots
+}
+
+// Lag 返回槽位 i 的消费者尚未读取的字节数，槽位空闲时返回 0
+func (p *Producer) Lag(i int) uint64 {
+	if !p.Active(i) {
+		return 0
+	}
+	return p.l.header(offWrite).Load() - p.l.slot(i, slotPosition).Load()
+}
+
+// Reap 释放所有者进程已不存在的消费者槽位，返回释放的槽位数。
+// 消费者崩溃时不会释放槽位，生产者或新的消费者应定期调用 Reap。
+func (p *Producer) Reap(isAlive func(pid uint32) bool) int {
+	return reap(p.l, isAlive)
+}
+
+func reap(l *layout, isAlive func(pid uint32) bool) int {
+	n := 0
+	for i := range l.slots {
+		owner := l.slot(i, slotOwner)
+		pid := owner.Load()
+		if pid != 0 && !isAlive(uint32(pid)) && owner.CompareAndSwap(pid, 0) {
+			n++
+		}
+	}
+	return n
+}
+
+// Consumer 是环形缓冲区的一个读端，每个 Consumer 独占一个槽位，不能被多个 goroutine 同时使用
+type Consumer struct {
+	l        *layout
+	slot     int
+	pid      uint32
+	pos      uint64
+	epoch    uint64
+	overruns uint64
+}
+
+// Attach 在已初始化的区域中占用一个空闲槽位，从当前写入位置开始读取
+func Attach(region []byte, pid uint32) (*Consumer, error) {
+	if pid == 0 {
+		return nil, ErrInvalidSlot
+	}
+	l, err := view(region)
+	if err != nil {
+		return nil, err
+	}
+	if err := l.readGeometry(); err != nil {
+		return nil, err
+	}
+	for i := range l.slots {
+		if l.slot(i, slotOwner).CompareAndSwap(0, uint64(pid)) {
+			c := &Consumer{l: l, slot: i, pid: pid, epoch: l.header(offEpoch).Load()}
+			c.pos = l.header(offWrite).Load()
+			l.slot(i, slotPosition).Store(c.pos)
+			l.slot(i, slotOverruns).Store(0)
+			return c, nil
+		}
+	}
+	return nil, ErrNoSlots
+}
+
+// Reap 释放所有者进程已不存在的消费者槽位，返回释放的槽位数
+func (c *Consumer) Reap(isAlive func(pid uint32) bool) int {
+	return reap(c.l, isAlive)
+}
+
+// Slot 返回消费者占用的槽位
+func (c *Consumer) Slot() int {
+	return c.slot
+}
+
+// ProducerPID 返回最近一次初始化区域的生产者进程 ID
+func (c *Consumer) ProducerPID() uint32 {
+	return uint32(c.l.header(offProducer).Load())
+}
+
+// Overruns 返回因落后超过一圈而跳过数据的次数
+func (c *Consumer) Overruns() uint64 {
+	return c.overruns
+}
+
+// Close 释放槽位。生产者以不同的几何参数重新初始化后，槽位已被清空并可能属于新的消费者，
+// 旧布局中的槽位偏移甚至可能落在数据区中，此时不写入区域。
+func (c *Consumer) Close() error {
+	if c.l == nil {
+		return nil
+	}
+	if c.checkEpoch() == nil {
+		c.l.slot(c.slot, slotOwner).CompareAndSwap(uint64(c.pid), 0)
+	}
+	c.l = nil
+	return nil
+}
+
+// checkEpoch 在生产者重新初始化后检查布局是否仍与附加时相同，相同时记录新的 epoch
+func (c *Consumer) checkEpoch() error {
+	l := c.l
+	epoch := l.header(offEpoch).Load()
+	if epoch == c.epoch {
+		return nil
+	}
+	check := &layout{words: l.words}
+	if err := check.readGeometry(); err != nil {
+		return err
+	}
+	if check.capacity != l.capacity || check.slots != l.slots {
+		return ErrVersion
+	}
+	c.epoch = epoch
+	return nil
+}
+
+// overrun 跳到最新的写入位置
+func (c *Consumer) overrun() {
+	c.pos = c.l.header(offWrite).Load()
+	c.overruns++
+	c.l.slot(c.slot, slotOverruns).Store(c.overruns)
+}
+
+// overwritten 报告位置 pos 处的数据是否可能已被生产者覆盖
+func (c *Consumer) overwritten(pos uint64) bool {
+	return c.l.header(offReserve).Load()-pos > c.l.capacity
+}
+
+// Next 读取下一条记录并追加到 dst[:0]，没有新记录时 ok 为 false。
+// 生产者重新初始化为不同的版本或几何参数时返回 ErrVersion，调用方应重新附加。
+func (c *Consumer) Next(dst []byte) (rec []byte, ok bool, err error) {
+	l := c.l
+	if l == nil {
+		return nil, false, ErrInvalidSlot
+	}
+	if err := c.checkEpoch(); err != nil {
+		return nil, false, err
+	}
+	for {
+		w := l.header(offWrite).Load()
+		if c.pos == w {
+			return nil, false, nil
+		}
+		// 生产者以不同几何参数重新初始化后写入位置可能回退，无符号差值同样超过容量
+		if w-c.pos > l.capacity {
+			c.overrun()
+			continue
+		}
+		head := l.dataWord(c.pos).Load()
+		if head == padding {
+			next := c.pos + l.capacity - c.pos%l.capacity
+			if c.overwritten(c.pos) {
+				c.overrun()
+				continue
+			}
+			c.pos = next
+			continue
+		}
+		n := head
+		total := align(recordHead + n)
+		if n > l.capacity/2 || c.pos%l.capacity+total > l.capacity {
+			if c.overwritten(c.pos) {
+				c.overrun()
+				continue
+			}
+			return nil, false, ErrCorrupt
+		}
+
+		rec = dst[:0]
+		for i := uint64(0); i < n; i += wordSize {
+			v := l.dataWord(c.pos + recordHead + i).Load()
+			w := (*[wordSize]byte)(unsafe.Pointer(&v))
+			rec = append(rec, w[:min(wordSize, n-i)]...)
+		}
+		if c.overwritten(c.pos) {
+			c.overrun()
+			continue
+		}
+		c.pos += total
+		l.slot(c.slot, slotPosition).Store(c.pos)
+		return rec, true, nil
+	}
+}
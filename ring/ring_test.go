@@ -0,0 +1,320 @@
+package ring
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"unsafe"
+)
+
+// region 分配按 8 字节对齐的内存区域，模拟共享内存
+func region(capacity, slots int) []byte {
+	words := make([]uint64, (Size(capacity, slots)+7)/8)
+	return unsafe.Slice((*byte)(unsafe.Pointer(&words[0])), len(words)*8)
+}
+
+func mustInit(t *testing.T, r []byte, capacity, slots int) *Producer {
+	t.Helper()
+	p, err := Init(r, capacity, slots, 100)
+	if err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	return p
+}
+
+func mustAttach(t *testing.T, r []byte, pid uint32) *Consumer {
+	t.Helper()
+	c, err := Attach(r, pid)
+	if err != nil {
+		t.Fatalf("Attach() error = %v", err)
+	}
+	return c
+}
+
+func readAll(t *testing.T, c *Consumer) []string {
+	t.Helper()
+	var got []string
+	for {
+		rec, ok, err := c.Next(nil)
+		if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+		if !ok {
+			return got
+		}
+		got = append(got, string(rec))
+	}
+}
+
+func TestInitErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		region   []byte
+		capacity int
+		slots    int
+		want     error
+	}{
+		{"not power of two", region(100, 1), 100, 1, ErrGeometry},
+		{"too small capacity", region(32, 1), 32, 1, ErrGeometry},
+		{"no slots", region(64, 1), 64, 0, ErrGeometry},
+		{"region too small", region(64, 1), 128, 1, ErrRegionSize},
+		{"misaligned", region(128, 1)[1:], 64, 1, ErrRegionSize},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Init(tt.region, tt.capacity, tt.slots, 1); !errors.Is(err, tt.want) {
+				t.Errorf("Init() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestAttachUninitialized(t *testing.T) {
+	if _, err := Attach(region(64, 1), 1); !errors.Is(err, ErrBadMagic) {
+		t.Errorf("Attach() error = %v, want ErrBadMagic", err)
+	}
+}
+
+func TestWriteRead(t *testing.T) {
+	r := region(256, 2)
+	p := mustInit(t, r, 256, 2)
+	a := mustAttach(t, r, 1)
+	b := mustAttach(t, r, 2)
+
+	want := []string{"", "a", "hello", "exactly8", "more than eight bytes"}
+	for _, s := range want {
+		if err := p.Write([]byte(s)); err != nil {
+			t.Fatalf("Write(%q) error = %v", s, err)
+		}
+	}
+	for _, c := range []*Consumer{a, b} {
+		if got := readAll(t, c); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("consumer %d read %q, want %q", c.Slot(), got, want)
+		}
+		if p.Lag(c.Slot()) != 0 {
+			t.Errorf("Lag(%d) = %d, want 0", c.Slot(), p.Lag(c.Slot()))
+		}
+	}
+}
+
+func TestWrapAround(t *testing.T) {
+	r := region(64, 1)
+	p := mustInit(t, r, 64, 1)
+	c := mustAttach(t, r, 1)
+
+	// 每条记录占 24 字节，第三条需要填充到数据区末尾
+	for i := range 20 {
+		rec := fmt.Sprintf("record-%02d", i)
+		if err := p.Write([]byte(rec)); err != nil {
+			t.Fatal(err)
+		}
+		got, ok, err := c.Next(nil)
+		if err != nil || !ok || string(got) != rec {
+			t.Fatalf("Next() = %q, %v, %v, want %q", got, ok, err, rec)
+		}
+	}
+	if c.Overruns() != 0 {
+		t.Errorf("Overruns() = %d, want 0", c.Overruns())
+	}
+}
+
+func TestOverrun(t *testing.T) {
+	r := region(128, 1)
+	p := mustInit(t, r, 128, 1)
+	c := mustAttach(t, r, 1)
+
+	for i := range 50 {
+		p.Write([]byte(fmt.Sprint(i)))
+	}
+	if got := readAll(t, c); len(got) != 0 {
+		t.Errorf("after overrun read %q, want nothing", got)
+	}
+	if c.Overruns() == 0 {
+		t.Errorf("Overruns() = 0, want > 0")
+	}
+	p.Write([]byte("fresh"))
+	if got := readAll(t, c); fmt.Sprint(got) != "[fresh]" {
+		t.Errorf("after resync read %q, want [fresh]", got)
+	}
+}
+
+func TestTooLarge(t *testing.T) {
+	r := region(64, 1)
+	p := mustInit(t, r, 64, 1)
+	if err := p.Write(make([]byte, p.MaxRecord()+1)); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("Write() error = %v, want ErrTooLarge", err)
+	}
+	if err := p.Write(make([]byte, p.MaxRecord())); err != nil {
+		t.Errorf("Write(MaxRecord) error = %v", err)
+	}
+}
+
+func TestSlotsAndReap(t *testing.T) {
+	r := region(64, 2)
+	p := mustInit(t, r, 64, 2)
+	a := mustAttach(t, r, 10)
+	mustAttach(t, r, 20)
+	if _, err := Attach(r, 30); !errors.Is(err, ErrNoSlots) {
+		t.Fatalf("Attach() with full table error = %v, want ErrNoSlots", err)
+	}
+
+	alive := func(pid uint32) bool { return pid != 20 }
+	if n := p.Reap(alive); n != 1 {
+		t.Errorf("Reap() = %d, want 1", n)
+	}
+	c := mustAttach(t, r, 30)
+	if !p.Active(a.Slot()) || !p.Active(c.Slot()) {
+		t.Errorf("slots %d and %d should be active", a.Slot(), c.Slot())
+	}
+	a.Close()
+	if p.Active(a.Slot()) {
+		t.Errorf("slot %d still active after Close", a.Slot())
+	}
+}
+
+func TestProducerRecovery(t *testing.T) {
+	r := region(128, 1)
+	p := mustInit(t, r, 128, 1)
+	c := mustAttach(t, r, 1)
+	p.Write([]byte("before"))
+
+	// 生产者崩溃后以相同参数重新初始化，已发布的数据和消费者槽位保留
+	p2, err := Init(r, 128, 1, 200)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p2.Epoch() != 2 || !p2.Active(c.Slot()) || c.ProducerPID() != 200 {
+		t.Errorf("after recovery epoch = %d, active = %v, pid = %d", p2.Epoch(), p2.Active(c.Slot()), c.ProducerPID())
+	}
+	p2.Write([]byte("after"))
+	if got := readAll(t, c); fmt.Sprint(got) != "[before after]" {
+		t.Errorf("read %q, want [before after]", got)
+	}
+
+	// 以不同的几何参数重新初始化时，旧消费者必须重新附加
+	if _, err := Init(r, 64, 1, 300); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := c.Next(nil); !errors.Is(err, ErrVersion) {
+		t.Errorf("Next() after geometry change error = %v, want ErrVersion", err)
+	}
+}
+
+// TestReinitGeometry 检查在首次初始化后附加的消费者能发现几何参数的变化
+func TestReinitGeometry(t *testing.T) {
+	r := region(128, 2)
+	p := mustInit(t, r, 128, 2)
+	c := mustAttach(t, r, 1)
+	p.Write([]byte("old"))
+
+	p2, err := Init(r, 64, 2, 200)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p2.Epoch() != 2 {
+		t.Errorf("epoch after re-init = %d, want 2", p2.Epoch())
+	}
+	p2.Write([]byte("new"))
+	if rec, ok, err := c.Next(nil); !errors.Is(err, ErrVersion) {
+		t.Errorf("Next() = %q, %v, %v; want ErrVersion", rec, ok, err)
+	}
+}
+
+// TestStaleConsumerClose 检查几何参数变化后，旧消费者的 Close 不会释放新消费者的槽位或破坏数据区
+func TestStaleConsumerClose(t *testing.T) {
+	r := region(128, 2)
+	p := mustInit(t, r, 128, 2)
+	c0 := mustAttach(t, r, 1)
+	c1 := mustAttach(t, r, 2) // 槽位 1 在单槽位布局中是数据区的第一个字
+	p.Write([]byte("old"))
+
+	p2, err := Init(r, 128, 1, 200)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c2 := mustAttach(t, r, 3)
+	if c2.Slot() != c0.Slot() {
+		t.Fatalf("new consumer slot = %d, want %d", c2.Slot(), c0.Slot())
+	}
+	if err := p2.Write([]byte("record")); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := c0.Next(nil); !errors.Is(err, ErrVersion) {
+		t.Errorf("stale Next() error = %v, want ErrVersion", err)
+	}
+	c0.Close()
+	c1.Close()
+
+	if !p2.Active(c2.Slot()) {
+		t.Error("stale Close released the new consumer's slot")
+	}
+	if got := readAll(t, c2); fmt.Sprint(got) != "[record]" {
+		t.Errorf("new consumer read %q, want [record]", got)
+	}
+}
+
+// TestConcurrent 检查并发写入时消费者不会读到撕裂的记录
+func TestConcurrent(t *testing.T) {
+	const records = 20000
+	r := region(1024, 4)
+	p := mustInit(t, r, 1024, 4)
+
+	var (
+		wg   sync.WaitGroup
+		done atomic.Bool
+	)
+	for i := range 4 {
+		c := mustAttach(t, r, uint32(i+1))
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var buf []byte
+			last := -1
+			for last < records-1 {
+				// 先读取 done，再确认没有新记录，避免漏掉最后几条记录
+				finished := done.Load()
+				rec, ok, err := c.Next(buf)
+				if err != nil {
+					t.Errorf("Next() error = %v", err)
+					return
+				}
+				if !ok {
+					if finished {
+						return
+					}
+					runtime.Gosched()
+					continue
+				}
+				buf = rec
+				seq := int(binary.LittleEndian.Uint32(rec))
+				if want := bytes.Repeat([]byte{byte(seq)}, len(rec)-4); !bytes.Equal(rec[4:], want) {
+					t.Errorf("torn record %d", seq)
+					return
+				}
+				if seq <= last {
+					t.Errorf("record %d after %d", seq, last)
+					return
+				}
+				last = seq
+			}
+		}()
+	}
+
+	for i := range records {
+		rec := make([]byte, 4+i%61)
+		binary.LittleEndian.PutUint32(rec, uint32(i))
+		for j := 4; j < len(rec); j++ {
+			rec[j] = byte(i)
+		}
+		if err := p.Write(rec); err != nil {
+			t.Fatal(err)
+		}
+	}
+	done.Store(true)
+	wg.Wait()
+}
@@ -0,0 +1,88 @@
+package xwindows
+
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/C1ph3rX13/xwindows/guid"
+)
+
+// TestUuidFromStringCorpus 检查 guid.Parse 与 UuidFromString 对黄金语料的结果一致
+func TestUuidFromStringCorpus(t *testing.T) {
+	f, err := os.Open("guid/testdata/corpus.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		line := s.Text()
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		i := strings.LastIndexByte(line, ' ')
+		input, err := strconv.Unquote(line[:max(i, 0)])
+		if err != nil {
+			t.Fatalf("malformed corpus line %q", line)
+		}
+		// UuidFromString 不接受花括号；空字符串只有 NULL 指针的行为有文档说明
+		if input == "" || strings.HasPrefix(input, "{") {
+			continue
+		}
+		want, wantErr := guid.Parse(input)
+		got, err := UuidFromString(input)
+		if (err != nil) != (wantErr != nil) || got != want {
+			t.Errorf("UuidFromString(%q) = %v, %v; guid.Parse = %v, %v", input, got, err, want, wantErr)
+		}
+	}
+}
+
+func TestUuidCreate(t *testing.T) {
+	var a, b GUID
+	if _, err := UuidCreate(&a); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := UuidCreate(&b); err != nil {
+		t.Fatal(err)
+	}
+	if a == b || a.Version() != 4 {
+		t.Errorf("UuidCreate = %v, %v", a, b)
+	}
+	for _, g := range []GUID{a, b, guid.MustParse("{00000000-0000-0000-C000-000000000046}")} {
+		s, err := UuidToString(g)
+		if err != nil || s != g.String() {
+			t.Errorf("UuidToString(%v) = %q, %v", g, s, err)
+		}
+	}
+
+	var seq GUID
+	if _, err := UuidCreateSequential(&seq); err != nil {
+		t.Skip(err)
+	}
+	if seq.Version() != 1 {
+		t.Errorf("UuidCreateSequential version = %d", seq.Version())
+	}
+}
+
+func TestUuidCompare(t *testing.T) {
+	values := []GUID{
+		guid.Nil,
+		guid.MustParse("00000000-0000-0000-0000-000000000001"),
+		guid.MustParse("000000ff-0000-0000-0000-000000000000"),
+		guid.MustParse("00000100-0000-0000-0000-000000000000"),
+		guid.MustParse("00000000-0100-0000-0000-000000000000"),
+		guid.MustParse("00000000-0000-0000-8000-000000000000"),
+		guid.MustParse("ffffffff-0000-0000-0000-000000000000"),
+	}
+	for _, a := range values {
+		for _, b := range values {
+			got, err := UuidCompare(&a, &b)
+			if err != nil || got != a.Compare(b) {
+				t.Errorf("UuidCompare(%v, %v) = %d, %v; Compare = %d", a, b, got, err, a.Compare(b))
+			}
+		}
+	}
+}
@@ -0,0 +1,384 @@
+package xwindows
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// SyncOption 是命名同步对象（Mutex、Event、Semaphore、WaitableTimer）的可选设置
+type SyncOption func(*syncConfig)
+
+type syncConfig struct {
+	namespace string
+	sddl      string
+	createNew bool
+	inherit   bool
+}
+
+// SyncGlobal 在名称前加 Global\ 前缀，使对象对所有会话可见。服务以外的进程创建全局对象需要 SeCreateGlobalPrivilege。
+func SyncGlobal() SyncOption {
+	return func(c *syncConfig) { c.namespace = `Global\` }
+}
+
+// SyncLocal 在名称前加 Local\ 前缀，对象只在当前会话中可见，这也是不带前缀时的默认行为
+func SyncLocal() SyncOption {
+	return func(c *syncConfig) { c.namespace = `Local\` }
+}
+
+// SyncSecurityDescriptor 以 SDDL 指定新对象的安全描述符，如 "D:P(A;;GA;;;SY)(A;;GA;;;AU)"，打开现有对象时忽略
+func SyncSecurityDescriptor(sddl string) SyncOption {
+	return func(c *syncConfig) { c.sddl = sddl }
+}
+
+// SyncCreateNew 要求创建新对象，同名对象已存在时返回 ErrResourceExists，用于单实例检测
+func SyncCreateNew() SyncOption {
+	return func(c *syncConfig) { c.createNew = true }
+}
+
+// SyncInheritable 使句柄可被子进程继承
+func SyncInheritable() SyncOption {
+	return func(c *syncConfig) { c.inherit = true }
+}
+
+func newSyncConfig(opts []SyncOption) syncConfig {
+	var c syncConfig
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// fullName 返回加上命名空间前缀的名称，未命名对象返回空字符串
+func (c syncConfig) fullName(name string) string {
+	if name == "" {
+		return ""
+	}
+	return c.namespace + name
+}
+
+// namePtr 返回名称的 UTF-16 指针，未命名对象返回 nil
+func namePtr(name string) (*uint16, error) {
+	if name == "" {
+		return nil, nil
+	}
+	return windows.UTF16PtrFromString(name)
+}
+
+func (c syncConfig) securityAttributes() (*windows.SecurityAttributes, error) {
+	if c.sddl == "" {
+		return securityAttributes(c.inherit), nil
+	}
+	sd, err := windows.SecurityDescriptorFromString(c.sddl)
+	if err != nil {
+		return nil, err
+	}
+	sa := &windows.SecurityAttributes{SecurityDescriptor: sd}
+	sa.Length = uint32(unsafe.Sizeof(*sa))
+	if c.inherit {
+		sa.InheritHandle = 1
+	}
+	return sa, nil
+}
+
+// create 以 fn 创建对象并处理 ERROR_ALREADY_EXISTS
+func (c syncConfig) create(name string, fn func(sa *windows.SecurityAttributes, name string) (windows.Handle, error)) (syncObject, error) {
+	full := c.fullName(name)
+	sa, err := c.securityAttributes()
+	if err != nil {
+		return syncObject{}, err
+	}
+	h, err := fn(sa, full)
+	if errors.Is(err, windows.ERROR_ALREADY_EXISTS) {
+		if c.createNew {
+			CloseHandle(h)
+			return syncObject{}, ErrResourceExists
+		}
+		err = nil
+	}
+	if err != nil {
+		return syncObject{}, err
+	}
+	return syncObject{handle: h, name: full}, nil
+}
+
+// open 以 fn 打开现有对象，对象不存在时返回 ErrResourceNotFound
+func (c syncConfig) open(name string, fn func(name string, inherit bool) (windows.Handle, error)) (syncObject, error) {
+	full := c.fullName(name)
+	h, err := fn(full, c.inherit)
+	if errors.Is(err, windows.ERROR_FILE_NOT_FOUND) {
+		return syncObject{}, ErrResourceNotFound
+	} else if err != nil {
+		return syncObject{}, err
+	}
+	return syncObject{handle: h, name: full}, nil
+}
+
+// syncObject 是同步对象的句柄和名称
+type syncObject struct {
+	handle windows.Handle
+	name   string
+}
+
+// Handle 返回对象的句柄，可用于 Wait 和 WaitAll
+func (o *syncObject) Handle() windows.Handle {
+	return o.handle
+}
+
+// Name 返回包括命名空间前缀的对象名称，未命名对象返回空字符串
+func (o *syncObject) Name() string {
+	return o.name
+}
+
+// Close 关闭句柄，重复调用返回 nil
+func (o *syncObject) Close() error {
+	if o.handle == 0 {
+		return nil
+	}
+	err := CloseHandle(o.handle)
+	o.handle = 0
+	return err
+}
+
+// tryWait 不等待地检查对象是否有信号，有信号时获取对象
+func (o *syncObject) tryWait() (bool, error) {
+	event, err := windows.WaitForSingleObject(o.handle, 0)
+	switch {
+	case err != nil:
+		return false, err
+	case event == windows.WAIT_ABANDONED:
+		return true, ErrAbandoned
+	}
+	return event == windows.WAIT_OBJECT_0, nil
+}
+
+// Mutex 是命名互斥体，可用于跨进程互斥和单实例检测。
+// 互斥体由线程拥有：Lock 成功后当前 goroutine 被锁定到系统线程，直到同一个 goroutine 调用 Unlock。
+//
+//	m, err := xwindows.NewMutex("MyApp.SingleInstance", xwindows.SyncCreateNew())
+//	if errors.Is(err, xwindows.ErrResourceExists) {
+//		return errors.New("another instance is running")
+//	}
+type Mutex struct {
+	syncObject
+}
+
+// NewMutex 创建或打开命名互斥体（初始不被拥有），name 为空时创建未命名互斥体
+func NewMutex(name string, opts ...SyncOption) (*Mutex, error) {
+	o, err := newSyncConfig(opts).create(name, func(sa *windows.SecurityAttributes, name string) (windows.Handle, error) {
+		namep, err := namePtr(name)
+		if err != nil {
+			return 0, err
+		}
+		return windows.CreateMutex(sa, false, namep)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Mutex{o}, nil
+}
+
+// OpenMutex 打开现有的命名互斥体，不存在时返回 ErrResourceNotFound
+func OpenMutex(name string, opts ...SyncOption) (*Mutex, error) {
+	o, err := newSyncConfig(opts).open(name, func(name string, inherit bool) (windows.Handle, error) {
+		namep, err := windows.UTF16PtrFromString(name)
+		if err != nil {
+			return 0, err
+		}
+		return windows.OpenMutex(windows.SYNCHRONIZE|windows.MUTEX_MODIFY_STATE, inherit, namep)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Mutex{o}, nil
+}
+
+// Lock 等待获取互斥体，ctx 结束时返回 ctx.Err()。
+// 前一个拥有者未释放就退出时返回 ErrAbandoned，此时互斥体已被获取，但其保护的状态可能不一致。
+func (m *Mutex) Lock(ctx context.Context) error {
+	runtime.LockOSThread()
+	_, err := Wait(ctx, m.handle)
+	if err != nil && !errors.Is(err, ErrAbandoned) {
+		runtime.UnlockOSThread()
+	}
+	return err
+}
+
+// TryLock 尝试不等待地获取互斥体，错误含义与 Lock 相同
+func (m *Mutex) TryLock() (bool, error) {
+	runtime.LockOSThread()
+	ok, err := m.tryWait()
+	if !ok {
+		runtime.UnlockOSThread()
+	}
+	return ok, err
+}
+
+// Unlock 释放互斥体，必须在调用 Lock 的 goroutine 中调用
+func (m *Mutex) Unlock() error {
+	if err := windows.ReleaseMutex(m.handle); err != nil {
+		return err
+	}
+	runtime.UnlockOSThread()
+	return nil
+}
+
+// Event 是命名事件
+type Event struct {
+	syncObject
+}
+
+// NewEvent 创建或打开命名事件（初始无信号），name 为空时创建未命名事件。
+// manualReset 为 false 时创建自动重置事件，每次 Set 只释放一个等待者。
+func NewEvent(name string, manualReset bool, opts ...SyncOption) (*Event, error) {
+	o, err := newSyncConfig(opts).create(name, func(sa *windows.SecurityAttributes, name string) (windows.Handle, error) {
+		return CreateEventW(sa, manualReset, false, name)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Event{o}, nil
+}
+
+// OpenEvent 打开现有的命名事件，不存在时返回 ErrResourceNotFound
+func OpenEvent(name string, opts ...SyncOption) (*Event, error) {
+	o, err := newSyncConfig(opts).open(name, func(name string, inherit bool) (windows.Handle, error) {
+		return OpenEventW(windows.SYNCHRONIZE|windows.EVENT_MODIFY_STATE, inherit, name)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Event{o}, nil
+}
+
+// Set 将事件设置为有信号状态
+func (e *Event) Set() error {
+	return SetEvent(e.handle)
+}
+
+// Reset 将事件设置为无信号状态
+func (e *Event) Reset() error {
+	return windows.ResetEvent(e.handle)
+}
+
+// Wait 等待事件有信号，ctx 结束时返回 ctx.Err()
+func (e *Event) Wait(ctx context.Context) error {
+	_, err := Wait(ctx, e.handle)
+	return err
+}
+
+// Semaphore 是命名信号量
+type Semaphore struct {
+	syncObject
+}
+
+// NewSemaphore 创建或打开命名信号量，name 为空时创建未命名信号量。打开现有对象时 initial 和 maximum 被忽略。
+func NewSemaphore(name string, initial, maximum int32, opts ...SyncOption) (*Semaphore, error) {
+	if maximum <= 0 || initial < 0 || initial > maximum {
+		return nil, ErrInvalidParameter
+	}
+	o, err := newSyncConfig(opts).create(name, func(sa *windows.SecurityAttributes, name string) (windows.Handle, error) {
+		return CreateSemaphoreW(sa, initial, maximum, name)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Semaphore{o}, nil
+}
+
+// OpenSemaphore 打开现有的命名信号量，不存在时返回 ErrResourceNotFound
+func OpenSemaphore(name string, opts ...SyncOption) (*Semaphore, error) {
+	o, err := newSyncConfig(opts).open(name, func(name string, inherit bool) (windows.Handle, error) {
+		return OpenSemaphoreW(windows.SYNCHRONIZE|windows.SEMAPHORE_MODIFY_STATE, inherit, name)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Semaphore{o}, nil
+}
+
+// Acquire 等待计数大于 0 并将其减 1，ctx 结束时返回 ctx.Err()
+func (s *Semaphore) Acquire(ctx context.Context) error {
+	_, err := Wait(ctx, s.handle)
+	return err
+}
+
+// TryAcquire 计数大于 0 时将其减 1 并返回 true，否则立即返回 false
+func (s *Semaphore) TryAcquire() (bool, error) {
+	return s.tryWait()
+}
+
+// Release 将计数增加 n 并返回之前的计数，超过最大计数时返回 ERROR_TOO_MANY_POSTS 且计数不变
+func (s *Semaphore) Release(n int32) (int32, error) {
+	var previous int32
+	err := ReleaseSemaphore(s.handle, n, &previous)
+	return previous, err
+}
+
+// WaitableTimer 是命名可等待计时器
+type WaitableTimer struct {
+	syncObject
+}
+
+// NewWaitableTimer 创建或打开命名可等待计时器（初始未激活），name 为空时创建未命名计时器。
+// manualReset 为 false 时创建同步计时器，每次到期只释放一个等待者。
+func NewWaitableTimer(name string, manualReset bool, opts ...SyncOption) (*WaitableTimer, error) {
+	var flags uint32
+	if manualReset {
+		flags |= CREATE_WAITABLE_TIMER_MANUAL_RESET
+	}
+	o, err := newSyncConfig(opts).create(name, func(sa *windows.SecurityAttributes, name string) (windows.Handle, error) {
+		return CreateWaitableTimerExW(sa, name, flags, windows.TIMER_ALL_ACCESS)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &WaitableTimer{o}, nil
+}
+
+// OpenWaitableTimer 打开现有的命名可等待计时器，不存在时返回 ErrResourceNotFound
+func OpenWaitableTimer(name string, opts ...SyncOption) (*WaitableTimer, error) {
+	o, err := newSyncConfig(opts).open(name, func(name string, inherit bool) (windows.Handle, error) {
+		return OpenWaitableTimerW(windows.SYNCHRONIZE|windows.TIMER_MODIFY_STATE, inherit, name)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &WaitableTimer{o}, nil
+}
+
+// Set 使计时器在 d 之后到期，period 大于 0 时此后每隔 period 到期一次（精度为毫秒）
+func (t *WaitableTimer) Set(d, period time.Duration) error {
+	// 负值表示相对时间，以 100 纳秒为单位
+	due := -max(1, int64(d/100))
+	return t.set(due, period)
+}
+
+// SetAt 使计时器在 at 到期，系统时间调整后仍按绝对时间到期
+func (t *WaitableTimer) SetAt(at time.Time, period time.Duration) error {
+	ft := windows.NsecToFiletime(at.UnixNano())
+	return t.set(int64(ft.HighDateTime)<<32|int64(ft.LowDateTime), period)
+}
+
+func (t *WaitableTimer) set(due int64, period time.Duration) error {
+	ms := period / time.Millisecond
+	if ms < 0 || ms > 1<<31-1 {
+		return ErrInvalidParameter
+	}
+	return SetWaitableTimer(t.handle, &due, int32(ms), 0, 0, false)
+}
+
+// Cancel 停止计时器，不改变其信号状态
+func (t *WaitableTimer) Cancel() error {
+	return CancelWaitableTimer(t.handle)
+}
+
+// Wait 等待计时器到期，ctx 结束时返回 ctx.Err()
+func (t *WaitableTimer) Wait(ctx context.Context) error {
+	_, err := Wait(ctx, t.handle)
+	return err
+}
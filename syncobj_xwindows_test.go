@@ -0,0 +1,231 @@
+package xwindows
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"golang.org/x/sys/windows"
+)
+
+func testSyncName(t *testing.T) string {
+	return fmt.Sprintf("xwindows-test-%d-%s", os.Getpid(), t.Name())
+}
+
+func TestSyncCreateNew(t *testing.T) {
+	type closer interface{ Close() error }
+	tests := []struct {
+		name   string
+		create func(name string, opts ...SyncOption) (closer, error)
+		open   func(name string) (closer, error)
+	}{
+		{
+			"mutex",
+			func(name string, opts ...SyncOption) (closer, error) { return NewMutex(name, opts...) },
+			func(name string) (closer, error) { return OpenMutex(name) },
+		},
+		{
+			"event",
+			func(name string, opts ...SyncOption) (closer, error) { return NewEvent(name, true, opts...) },
+			func(name string) (closer, error) { return OpenEvent(name) },
+		},
+		{
+			"semaphore",
+			func(name string, opts ...SyncOption) (closer, error) { return NewSemaphore(name, 0, 1, opts...) },
+			func(name string) (closer, error) { return OpenSemaphore(name) },
+		},
+		{
+			"waitable timer",
+			func(name string, opts ...SyncOption) (closer, error) { return NewWaitableTimer(name, true, opts...) },
+			func(name string) (closer, error) { return OpenWaitableTimer(name) },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name := testSyncName(t)
+			if _, err := tt.open(name); !errors.Is(err, ErrResourceNotFound) {
+				t.Errorf("open missing error = %v, want %v", err, ErrResourceNotFound)
+			}
+			first, err := tt.create(name, SyncCreateNew(), SyncLocal(), SyncSecurityDescriptor("D:P(A;;GA;;;OW)(A;;GA;;;SY)"))
+			if err != nil {
+				t.Fatalf("create error = %v", err)
+			}
+			defer first.Close()
+			if _, err := tt.create(name, SyncCreateNew()); !errors.Is(err, ErrResourceExists) {
+				t.Errorf("create new existing error = %v, want %v", err, ErrResourceExists)
+			}
+			second, err := tt.create(name)
+			if err != nil {
+				t.Fatalf("create existing error = %v", err)
+			}
+			second.Close()
+			opened, err := tt.open(`Local\` + name)
+			if err != nil {
+				t.Fatalf("open error = %v", err)
+			}
+			opened.Close()
+		})
+	}
+}
+
+func TestMutex(t *testing.T) {
+	m, err := NewMutex(testSyncName(t))
+	if err != nil {
+		t.Fatalf("NewMutex() error = %v", err)
+	}
+	defer m.Close()
+	other, err := OpenMutex(m.Name())
+	if err != nil {
+		t.Fatalf("OpenMutex() error = %v", err)
+	}
+	defer other.Close()
+
+	if err := m.Lock(context.Background()); err != nil {
+		t.Fatalf("Lock() error = %v", err)
+	}
+	// 另一个 goroutine 在另一个线程上，无法获取
+	result := make(chan error)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		result <- other.Lock(ctx)
+	}()
+	if err := <-result; !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Lock() while held error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if err := m.Unlock(); err != nil {
+		t.Fatalf("Unlock() error = %v", err)
+	}
+	go func() {
+		ok, err := other.TryLock()
+		if ok {
+			err = errors.Join(err, other.Unlock())
+		} else if err == nil {
+			err = errors.New("TryLock() = false after Unlock")
+		}
+		result <- err
+	}()
+	if err := <-result; err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMutexAbandoned(t *testing.T) {
+	m, err := NewMutex(testSyncName(t))
+	if err != nil {
+		t.Fatalf("NewMutex() error = %v", err)
+	}
+	defer m.Close()
+	done := make(chan error)
+	go func() {
+		// 获取后不释放就退出，线程随 goroutine 退出
+		done <- m.Lock(context.Background())
+	}()
+	if err := <-done; err != nil {
+		t.Fatalf("Lock() error = %v", err)
+	}
+	go func() {
+		err := m.Lock(context.Background())
+		if errors.Is(err, ErrAbandoned) {
+			m.Unlock()
+		}
+		done <- err
+	}()
+	if err := <-done; !errors.Is(err, ErrAbandoned) {
+		t.Errorf("Lock() error = %v, want %v", err, ErrAbandoned)
+	}
+}
+
+func TestEvent(t *testing.T) {
+	tests := []struct {
+		name        string
+		manualReset bool
+	}{
+		{"manual reset", true},
+		{"auto reset", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := NewEvent("", tt.manualReset)
+			if err != nil {
+				t.Fatalf("NewEvent() error = %v", err)
+			}
+			defer e.Close()
+			time.AfterFunc(10*time.Millisecond, func() { e.Set() })
+			if err := e.Wait(context.Background()); err != nil {
+				t.Fatalf("Wait() error = %v", err)
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			defer cancel()
+			err = e.Wait(ctx)
+			if tt.manualReset && err != nil {
+				t.Errorf("second Wait() error = %v, want nil", err)
+			}
+			if !tt.manualReset && !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("second Wait() error = %v, want %v", err, context.DeadlineExceeded)
+			}
+		})
+	}
+}
+
+func TestSemaphore(t *testing.T) {
+	if _, err := NewSemaphore("", 2, 1); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("NewSemaphore(2, 1) error = %v, want %v", err, ErrInvalidParameter)
+	}
+	s, err := NewSemaphore("", 2, 2)
+	if err != nil {
+		t.Fatalf("NewSemaphore() error = %v", err)
+	}
+	defer s.Close()
+	for range 2 {
+		if err := s.Acquire(context.Background()); err != nil {
+			t.Fatalf("Acquire() error = %v", err)
+		}
+	}
+	if ok, err := s.TryAcquire(); ok || err != nil {
+		t.Errorf("TryAcquire() = %v, %v, want false", ok, err)
+	}
+	if prev, err := s.Release(2); prev != 0 || err != nil {
+		t.Errorf("Release(2) = %d, %v, want 0", prev, err)
+	}
+	if _, err := s.Release(1); !errors.Is(err, windows.ERROR_TOO_MANY_POSTS) {
+		t.Errorf("Release() over maximum error = %v, want %v", err, windows.ERROR_TOO_MANY_POSTS)
+	}
+}
+
+func TestWaitableTimer(t *testing.T) {
+	timer, err := NewWaitableTimer("", false)
+	if err != nil {
+		t.Fatalf("NewWaitableTimer() error = %v", err)
+	}
+	defer timer.Close()
+
+	start := time.Now()
+	if err := timer.Set(30*time.Millisecond, 0); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if err := timer.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("timer fired after %v, want about 30ms", elapsed)
+	}
+
+	if err := timer.SetAt(time.Now().Add(time.Hour), 0); err != nil {
+		t.Fatalf("SetAt() error = %v", err)
+	}
+	if err := timer.Cancel(); err != nil {
+		t.Fatalf("Cancel() error = %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := timer.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Wait() after Cancel error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if err := timer.Set(0, -time.Second); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("Set(negative period) error = %v, want %v", err, ErrInvalidParameter)
+	}
+}
@@ -35,16 +35,30 @@ func errnoErr(e syscall.Errno) error {
 	return e
 }
 
+// hresultErr 将函数返回的 HRESULT 转换为错误。成功代码（包括 S_FALSE）返回 nil；
+// FACILITY_WIN32 的错误还原为 Win32 错误码，以便与 windows.ERROR_* 比较。
+func hresultErr(r1 uintptr) error {
+	hr := uint32(r1) // 只有低 32 位有效
+	if int32(hr) >= 0 {
+		return nil
+	}
+	if hr>>16&0x1FFF == windows.FACILITY_WIN32 {
+		return syscall.Errno(hr & 0xFFFF)
+	}
+	return syscall.Errno(hr)
+}
+
 var (
-	modkernel32 = windows.NewLazySystemDLL("kernel32.dll")
-	modntdll    = windows.NewLazySystemDLL("ntdll.dll")
-	modrpcrt4   = windows.NewLazySystemDLL("Rpcrt4.dll")
-	modactiveds = windows.NewLazySystemDLL("Activeds.dll")
-	modpsapi    = windows.NewLazySystemDLL("psapi.dll")
-	moddbghelp  = windows.NewLazySystemDLL("dbghelp.dll")
-	modadvapi32 = windows.NewLazySystemDLL("Advapi32.dll")
-	moduser32   = windows.NewLazySystemDLL("user32.dll")
-	modwinmm    = windows.NewLazySystemDLL("Winmm.dll")
+	modkernel32   = windows.NewLazySystemDLL("kernel32.dll")
+	modntdll      = windows.NewLazySystemDLL("ntdll.dll")
+	modrpcrt4     = windows.NewLazySystemDLL("Rpcrt4.dll")
+	modactiveds   = windows.NewLazySystemDLL("Activeds.dll")
+	modpsapi      = windows.NewLazySystemDLL("psapi.dll")
+	moddbghelp    = windows.NewLazySystemDLL("dbghelp.dll")
+	modadvapi32   = windows.NewLazySystemDLL("Advapi32.dll")
+	moduser32     = windows.NewLazySystemDLL("user32.dll")
+	modwinmm      = windows.NewLazySystemDLL("Winmm.dll")
+	modkernelbase = windows.NewLazySystemDLL("kernelbase.dll")
 )
 
 // kernel32.dll
@@ -53,6 +67,16 @@ var (
 	procVirtualProtect             = modkernel32.NewProc("VirtualProtect")
 	procVirtualProtectEx           = modkernel32.NewProc("VirtualProtectEx")
 	procVirtualAllocEx             = modkernel32.NewProc("VirtualAllocEx")
+	procVirtualQueryEx             = modkernel32.NewProc("VirtualQueryEx")
+	procCreateFileMappingW         = modkernel32.NewProc("CreateFileMappingW")
+	procOpenFileMappingW           = modkernel32.NewProc("OpenFileMappingW")
+	procMapViewOfFile              = modkernel32.NewProc("MapViewOfFile")
+	procUnmapViewOfFile            = modkernel32.NewProc("UnmapViewOfFile")
+	procFlushViewOfFile            = modkernel32.NewProc("FlushViewOfFile")
+	procGetLargePageMinimum        = modkernel32.NewProc("GetLargePageMinimum")
+	procCreateEventW               = modkernel32.NewProc("CreateEventW")
+	procOpenEventW                 = modkernel32.NewProc("OpenEventW")
+	procSetEvent                   = modkernel32.NewProc("SetEvent")
 	procCreateRemoteThreadEx       = modkernel32.NewProc("CreateRemoteThreadEx")
 	procConvertThreadToFiber       = modkernel32.NewProc("ConvertThreadToFiber")
 	procCreateFiber                = modkernel32.NewProc("CreateFiber")
@@ -72,6 +96,13 @@ var (
 	procReadProcessMemory          = modkernel32.NewProc("ReadProcessMemory")
 	procCreateToolhelp32Snapshot   = modkernel32.NewProc("CreateToolhelp32Snapshot")
 	procThread32First              = modkernel32.NewProc("Thread32First")
+	procThread32Next               = modkernel32.NewProc("Thread32Next")
+	procProcess32FirstW            = modkernel32.NewProc("Process32FirstW")
+	procProcess32NextW             = modkernel32.NewProc("Process32NextW")
+	procModule32FirstW             = modkernel32.NewProc("Module32FirstW")
+	procModule32NextW              = modkernel32.NewProc("Module32NextW")
+	procHeap32ListFirst            = modkernel32.NewProc("Heap32ListFirst")
+	procHeap32ListNext             = modkernel32.NewProc("Heap32ListNext")
 	procOpenThread                 = modkernel32.NewProc("OpenThread")
 	procQueueUserAPC               = modkernel32.NewProc("QueueUserAPC")
 	procCreateRemoteThread         = modkernel32.NewProc("CreateRemoteThread")
@@ -90,6 +121,50 @@ var (
 	procEnumTimeFormatsA           = modkernel32.NewProc("EnumTimeFormatsA")
 	procEnumSystemLocalesA         = modkernel32.NewProc("EnumSystemLocalesA")
 	procCreatePipe                 = modkernel32.NewProc("CreatePipe")
+	procCreateNamedPipeW           = modkernel32.NewProc("CreateNamedPipeW")
+	procConnectNamedPipe           = modkernel32.NewProc("ConnectNamedPipe")
+	procGetHandleInformation       = modkernel32.NewProc("GetHandleInformation")
+	procTerminateProcess           = modkernel32.NewProc("TerminateProcess")
+	procGetExitCodeProcess         = modkernel32.NewProc("GetExitCodeProcess")
+	procSetInformationJobObject    = modkernel32.NewProc("SetInformationJobObject")
+	procQueryInformationJobObject  = modkernel32.NewProc("QueryInformationJobObject")
+	procCreateSemaphoreW           = modkernel32.NewProc("CreateSemaphoreW")
+	procOpenSemaphoreW             = modkernel32.NewProc("OpenSemaphoreW")
+	procReleaseSemaphore           = modkernel32.NewProc("ReleaseSemaphore")
+	procCreateWaitableTimerExW     = modkernel32.NewProc("CreateWaitableTimerExW")
+	procOpenWaitableTimerW         = modkernel32.NewProc("OpenWaitableTimerW")
+	procSetWaitableTimer           = modkernel32.NewProc("SetWaitableTimer")
+	procCancelWaitableTimer        = modkernel32.NewProc("CancelWaitableTimer")
+	procCreatePseudoConsole        = modkernel32.NewProc("CreatePseudoConsole")
+	procResizePseudoConsole        = modkernel32.NewProc("ResizePseudoConsole")
+	procClosePseudoConsole         = modkernel32.NewProc("ClosePseudoConsole")
+	procGetThreadDescription       = modkernel32.NewProc("GetThreadDescription")
+	procSetThreadDescription       = modkernel32.NewProc("SetThreadDescription")
+	// Console
+	procGetConsoleScreenBufferInfoEx = modkernel32.NewProc("GetConsoleScreenBufferInfoEx")
+	procSetConsoleScreenBufferSize   = modkernel32.NewProc("SetConsoleScreenBufferSize")
+	procReadConsoleInputW            = modkernel32.NewProc("ReadConsoleInputW")
+	procGetConsoleTitleW             = modkernel32.NewProc("GetConsoleTitleW")
+	procSetConsoleTitleW             = modkernel32.NewProc("SetConsoleTitleW")
+	procSetConsoleCtrlHandler        = modkernel32.NewProc("SetConsoleCtrlHandler")
+	// Time
+	procGetTickCount64             = modkernel32.NewProc("GetTickCount64")
+	procQueryPerformanceCounter    = modkernel32.NewProc("QueryPerformanceCounter")
+	procQueryPerformanceFrequency  = modkernel32.NewProc("QueryPerformanceFrequency")
+	procQueryUnbiasedInterruptTime = modkernel32.NewProc("QueryUnbiasedInterruptTime")
+	// Locale
+	procGetLocaleInfoEx          = modkernel32.NewProc("GetLocaleInfoEx")
+	procLocaleNameToLCID         = modkernel32.NewProc("LocaleNameToLCID")
+	procLCIDToLocaleName         = modkernel32.NewProc("LCIDToLocaleName")
+	procGetUserDefaultLocaleName = modkernel32.NewProc("GetUserDefaultLocaleName")
+	procGetCalendarInfoEx        = modkernel32.NewProc("GetCalendarInfoEx")
+	procEnumTimeFormatsEx        = modkernel32.NewProc("EnumTimeFormatsEx")
+	procEnumDateFormatsExEx      = modkernel32.NewProc("EnumDateFormatsExEx")
+	procGetTimeFormatEx          = modkernel32.NewProc("GetTimeFormatEx")
+	procGetDateFormatEx          = modkernel32.NewProc("GetDateFormatEx")
+	// TimeZone
+	procGetDynamicTimeZoneInformation = modkernel32.NewProc("GetDynamicTimeZoneInformation")
+	procGetTimeZoneInformationForYear = modkernel32.NewProc("GetTimeZoneInformationForYear")
 	// SandBox
 	procGetTickCount                       = modkernel32.NewProc("GetTickCount")
 	procGetPhysicallyInstalledSystemMemory = modkernel32.NewProc("GetPhysicallyInstalledSystemMemory")
@@ -120,11 +195,22 @@ var (
 	procNtQueryInformationProcess   = modntdll.NewProc("NtQueryInformationProcess")
 	procNtDelayExecution            = modntdll.NewProc("NtDelayExecution")
 	procRtlIpv4StringToAddressExA   = modntdll.NewProc("RtlIpv4StringToAddressExA")
+	// Ip2string
+	procRtlIpv4AddressToStringExA = modntdll.NewProc("RtlIpv4AddressToStringExA")
+	procRtlIpv6StringToAddressA   = modntdll.NewProc("RtlIpv6StringToAddressA")
+	procRtlIpv6StringToAddressExA = modntdll.NewProc("RtlIpv6StringToAddressExA")
+	procRtlIpv6AddressToStringA   = modntdll.NewProc("RtlIpv6AddressToStringA")
+	procRtlIpv6AddressToStringExA = modntdll.NewProc("RtlIpv6AddressToStringExA")
 )
 
 // Rpcrt4
 var (
-	procUuidFromStringA = modrpcrt4.NewProc("UuidFromStringA")
+	procUuidFromStringA      = modrpcrt4.NewProc("UuidFromStringA")
+	procUuidCreate           = modrpcrt4.NewProc("UuidCreate")
+	procUuidCreateSequential = modrpcrt4.NewProc("UuidCreateSequential")
+	procUuidToStringW        = modrpcrt4.NewProc("UuidToStringW")
+	procRpcStringFreeW       = modrpcrt4.NewProc("RpcStringFreeW")
+	procUuidCompare          = modrpcrt4.NewProc("UuidCompare")
 )
 
 // Activeds.dll
@@ -136,18 +222,32 @@ var (
 
 // psapi.dll
 var (
-	procEnumPageFilesW = modpsapi.NewProc("EnumPageFilesW")
+	procEnumPageFilesW     = modpsapi.NewProc("EnumPageFilesW")
+	procGetMappedFileNameW = modpsapi.NewProc("GetMappedFileNameW")
 )
 
 // dbghelp.dll
 var (
-	procEnumerateLoadedModules = moddbghelp.NewProc("EnumerateLoadedModules")
+	procEnumerateLoadedModules    = moddbghelp.NewProc("EnumerateLoadedModules")
+	procEnumerateLoadedModulesW64 = moddbghelp.NewProc("EnumerateLoadedModulesW64")
 )
 
 // Advapi32.dll
 var (
 	procIQueryTagInformation = modadvapi32.NewProc("I_QueryTagInformation")
 	procRegDeleteTreeA       = modadvapi32.NewProc("RegDeleteTreeA")
+	// TimeZone
+	procEnumDynamicTimeZoneInformation = modadvapi32.NewProc("EnumDynamicTimeZoneInformation")
+	// Registry
+	procRegCreateKeyExW   = modadvapi32.NewProc("RegCreateKeyExW")
+	procRegSetValueExW    = modadvapi32.NewProc("RegSetValueExW")
+	procRegDeleteValueW   = modadvapi32.NewProc("RegDeleteValueW")
+	procRegDeleteKeyExW   = modadvapi32.NewProc("RegDeleteKeyExW")
+	procRegDeleteTreeW    = modadvapi32.NewProc("RegDeleteTreeW")
+	procRegEnumValueW     = modadvapi32.NewProc("RegEnumValueW")
+	procRegCopyTreeW      = modadvapi32.NewProc("RegCopyTreeW")
+	procRegGetKeySecurity = modadvapi32.NewProc("RegGetKeySecurity")
+	procRegSetKeySecurity = modadvapi32.NewProc("RegSetKeySecurity")
 )
 
 // user32.dll
@@ -161,5 +261,28 @@ var (
 
 // Winmm.dll
 var (
-	procTimeGetTime = modwinmm.NewProc("timeGetTime")
+	procTimeGetTime            = modwinmm.NewProc("timeGetTime")
+	procTimeBeginPeriod        = modwinmm.NewProc("timeBeginPeriod")
+	procTimeEndPeriod          = modwinmm.NewProc("timeEndPeriod")
+	procTimeGetDevCaps         = modwinmm.NewProc("timeGetDevCaps")
+	procTimeSetEvent           = modwinmm.NewProc("timeSetEvent")
+	procTimeKillEvent          = modwinmm.NewProc("timeKillEvent")
+	procWaveOutGetNumDevs      = modwinmm.NewProc("waveOutGetNumDevs")
+	procWaveOutGetDevCapsW     = modwinmm.NewProc("waveOutGetDevCapsW")
+	procWaveOutOpen            = modwinmm.NewProc("waveOutOpen")
+	procWaveOutClose           = modwinmm.NewProc("waveOutClose")
+	procWaveOutPrepareHeader   = modwinmm.NewProc("waveOutPrepareHeader")
+	procWaveOutUnprepareHeader = modwinmm.NewProc("waveOutUnprepareHeader")
+	procWaveOutWrite           = modwinmm.NewProc("waveOutWrite")
+	procWaveOutPause           = modwinmm.NewProc("waveOutPause")
+	procWaveOutRestart         = modwinmm.NewProc("waveOutRestart")
+	procWaveOutReset           = modwinmm.NewProc("waveOutReset")
+	procWaveOutGetVolume       = modwinmm.NewProc("waveOutGetVolume")
+	procWaveOutSetVolume       = modwinmm.NewProc("waveOutSetVolume")
+	procPlaySoundW             = modwinmm.NewProc("PlaySoundW")
+)
+
+// kernelbase.dll
+var (
+	procQueryInterruptTimePrecise = modkernelbase.NewProc("QueryInterruptTimePrecise")
 )
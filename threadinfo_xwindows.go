@@ -0,0 +1,57 @@
+package xwindows
+
+import "golang.org/x/sys/windows"
+
+// ThreadBasicInfo 是 THREAD_BASIC_INFORMATION 的 Go 表示
+type ThreadBasicInfo struct {
+	ExitStatus     windows.NTStatus // 线程仍在运行时为 STATUS_PENDING
+	TebBaseAddress uintptr
+	ProcessID      uint32
+	ThreadID       uint32
+	AffinityMask   uintptr
+	Priority       int32
+	BasePriority   int32
+}
+
+// NtQueryInformationThread 信息类 (THREADINFOCLASS)
+var (
+	// ThreadBasicInformation 查询退出状态、TEB 地址、客户端 ID、亲和性和优先级
+	ThreadBasicInformation = fixedClass(ThreadBasicInformationClass, func(i *THREAD_BASIC_INFORMATION) ThreadBasicInfo {
+		return ThreadBasicInfo{
+			ExitStatus:     i.ExitStatus,
+			TebBaseAddress: i.TebBaseAddress,
+			ProcessID:      uint32(i.ClientId.UniqueProcess),
+			ThreadID:       uint32(i.ClientId.UniqueThread),
+			AffinityMask:   i.AffinityMask,
+			Priority:       i.Priority,
+			BasePriority:   i.BasePriority,
+		}
+	})
+
+	// ThreadTimes 查询线程的创建、退出时间及内核态、用户态时间
+	ThreadTimes = fixedClass(ThreadTimesClass, newKernelUserTimes)
+
+	// ThreadQuerySetWin32StartAddress 查询线程的 Win32 起始地址，需要 THREAD_QUERY_INFORMATION 访问权限
+	ThreadQuerySetWin32StartAddress = fixedClass(ThreadQuerySetWin32StartAddressClass, func(addr *uintptr) uintptr { return *addr })
+
+	// ThreadIsIoPending 查询线程是否有挂起的 I/O 操作
+	ThreadIsIoPending = fixedClass(ThreadIsIoPendingClass, func(pending *uint32) bool { return *pending != 0 })
+
+	// ThreadNameInformation 查询线程说明（与 GetThreadDescription 相同），需要 Windows 10 1607 及以上版本
+	ThreadNameInformation = unicodeStringClass(ThreadNameInformationClass)
+)
+
+// QueryThreadInformation 查询指定线程的一个信息类并返回类型化的结果。
+// 变长结果的缓冲区在返回 STATUS_INFO_LENGTH_MISMATCH 时自动扩大。
+//
+//	info, err := xwindows.QueryThreadInformation(thread, xwindows.ThreadBasicInformation)
+func QueryThreadInformation[T any](thread windows.Handle, class InformationClass[T]) (T, error) {
+	buf, err := queryInformation(class.size, func(buf []byte) (uint32, error) {
+		return QueryInformationThreadBuffer(thread, class.Class, buf)
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return class.decode(buf)
+}
@@ -0,0 +1,75 @@
+package xwindows
+
+import (
+	"errors"
+	"os"
+	"runtime"
+	"syscall"
+	"testing"
+
+	"golang.org/x/sys/windows"
+)
+
+func TestQueryThreadInformation(t *testing.T) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	thread := windows.CurrentThread()
+
+	basic, err := QueryThreadInformation(thread, ThreadBasicInformation)
+	if err != nil {
+		t.Fatalf("QueryThreadInformation(ThreadBasicInformation) error = %v", err)
+	}
+	if basic.ProcessID != uint32(os.Getpid()) || basic.ThreadID != windows.GetCurrentThreadId() {
+		t.Errorf("ThreadBasicInformation = %+v, want pid %d tid %d", basic, os.Getpid(), windows.GetCurrentThreadId())
+	}
+
+	times, err := QueryThreadInformation(thread, ThreadTimes)
+	if err != nil || times.CreateTime.IsZero() {
+		t.Errorf("QueryThreadInformation(ThreadTimes) = %+v, %v", times, err)
+	}
+
+	if _, err := QueryThreadInformation(thread, ThreadIsIoPending); err != nil {
+		t.Errorf("QueryThreadInformation(ThreadIsIoPending) error = %v", err)
+	}
+}
+
+func TestThreadDescription(t *testing.T) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	thread := windows.CurrentThread()
+
+	const want = "xwindows test thread"
+	if err := SetThreadDescription(thread, want); errors.Is(err, ErrNotImplemented) {
+		t.Skip("SetThreadDescription requires Windows 10 1607")
+	} else if err != nil {
+		t.Fatalf("SetThreadDescription() error = %v", err)
+	}
+	if got, err := ThreadDescription(thread); err != nil || got != want {
+		t.Errorf("ThreadDescription() = %q, %v, want %q", got, err, want)
+	}
+	if got, err := QueryThreadInformation(thread, ThreadNameInformation); err != nil || got != want {
+		t.Errorf("QueryThreadInformation(ThreadNameInformation) = %q, %v, want %q", got, err, want)
+	}
+}
+
+func TestHresultErr(t *testing.T) {
+	tests := []struct {
+		hr   uintptr
+		want error
+	}{
+		{0, nil},                         // S_OK
+		{1, nil},                         // S_FALSE
+		{^uintptr(0) &^ 0xFFFFFFFF, nil}, // 高 32 位无效（386 上为 0）
+		{0x80070057, windows.ERROR_INVALID_PARAMETER},
+		{0x80070005, windows.ERROR_ACCESS_DENIED},
+		{0x80004005, syscall.Errno(0x80004005)}, // E_FAIL 不属于 FACILITY_WIN32
+	}
+	for _, tt := range tests {
+		if err := hresultErr(tt.hr); err != tt.want {
+			t.Errorf("hresultErr(%#x) = %v, want %v", tt.hr, err, tt.want)
+		}
+	}
+	if !errors.Is(hresultErr(0x80070006), windows.ERROR_INVALID_HANDLE) {
+		t.Error("HRESULT_FROM_WIN32(ERROR_INVALID_HANDLE) does not match windows.ERROR_INVALID_HANDLE")
+	}
+}
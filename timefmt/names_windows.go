@@ -0,0 +1,63 @@
+package timefmt
+
+import (
+	"time"
+
+	"github.com/C1ph3rX13/xwindows"
+	"golang.org/x/sys/windows"
+)
+
+// LocaleNames 查询区域设置 locale（如 "de-DE"）的星期、月份、上午/下午标识符和默认日历的纪元名称
+func LocaleNames(locale string) (*Names, error) {
+	l, err := xwindows.LocaleByName(locale)
+	if err != nil {
+		return nil, err
+	}
+	n := &Names{}
+	get := func(dst *string, lctype uint32) {
+		if err == nil {
+			*dst, err = l.Info(lctype)
+		}
+	}
+	for i := range 7 {
+		// LOCALE_SDAYNAME1 是星期一，time.Weekday 以星期日为 0
+		wd := time.Weekday((i + 1) % 7)
+		get(&n.Days[wd], xwindows.LOCALE_SDAYNAME1+uint32(i))
+		get(&n.ShortDays[wd], xwindows.LOCALE_SABBREVDAYNAME1+uint32(i))
+	}
+	for i := range 12 {
+		get(&n.Months[i], xwindows.LOCALE_SMONTHNAME1+uint32(i))
+		get(&n.ShortMonths[i], xwindows.LOCALE_SABBREVMONTHNAME1+uint32(i))
+		get(&n.GenitiveMonths[i], xwindows.LOCALE_SMONTHNAME1+uint32(i)|xwindows.LOCALE_RETURN_GENITIVE_NAMES)
+		get(&n.GenitiveShortMonths[i], xwindows.LOCALE_SABBREVMONTHNAME1+uint32(i)|xwindows.LOCALE_RETURN_GENITIVE_NAMES)
+	}
+	get(&n.AM, xwindows.LOCALE_S1159)
+	get(&n.PM, xwindows.LOCALE_S2359)
+	if err != nil {
+		return nil, err
+	}
+	calendar, err := l.Calendar()
+	if err != nil {
+		return nil, err
+	}
+	if n.Era, err = eraName(l.Name(), calendar); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+func eraName(locale string, calendar uint32) (string, error) {
+	name, err := windows.UTF16PtrFromString(locale)
+	if err != nil {
+		return "", err
+	}
+	size, err := xwindows.GetCalendarInfoEx(name, calendar, xwindows.CAL_SERASTRING, nil, 0, nil)
+	if err != nil {
+		return "", err
+	}
+	buf := make([]uint16, size)
+	if size, err = xwindows.GetCalendarInfoEx(name, calendar, xwindows.CAL_SERASTRING, &buf[0], size, nil); err != nil {
+		return "", err
+	}
+	return windows.UTF16ToString(buf[:size]), nil
+}
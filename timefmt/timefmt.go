@@ -0,0 +1,295 @@
+// Package timefmt 解释 Windows 日期和时间格式图片字符串（如 "HH:mm:ss"、"tt h:mm"、"dddd, MMMM d, yyyy"），
+// 按 GetTimeFormatEx 和 GetDateFormatEx 的规则格式化 time.Time，并在可能时转换为 Go 的布局字符串。
+//
+// 本包不依赖 Windows API，可以在任何平台上测试；区域设置相关的名称由 Names 提供。
+package timefmt
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Kind 区分时间图片和日期图片：时间图片只解释 h、H、m、s、t，日期图片只解释 d、M、y、g，
+// 其他字符都按原样输出。
+type Kind int
+
+const (
+	Time Kind = iota // GetTimeFormatEx 的图片
+	Date             // GetDateFormatEx 的图片
+)
+
+// Names 是格式化时使用的区域设置名称
+type Names struct {
+	Days        [7]string  // 星期的全称，以 time.Weekday 为索引
+	ShortDays   [7]string  // 星期的缩写
+	Months      [12]string // 月份的全称，索引 0 为一月
+	ShortMonths [12]string // 月份的缩写
+	// 所有格月份名称（如俄语 "марта"），图片中含有日期数字 (d、dd) 时代替 Months 和 ShortMonths，
+	// 为空时使用主格名称
+	GenitiveMonths      [12]string
+	GenitiveShortMonths [12]string
+	AM, PM              string // 上午和下午标识符，可以为空
+	Era                 string // 纪元名称，如 "A.D."
+}
+
+// English 是 en-US 区域设置的名称
+var English = &Names{
+	Days:        [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+	ShortDays:   [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+	Months:      [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+	ShortMonths: [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+	AM:          "AM",
+	PM:          "PM",
+	Era:         "A.D.",
+}
+
+// token 是图片中的一个元素：格式说明符 verb 重复 count 次，或 verb 为 0 时的字面文本 lit
+type token struct {
+	verb  byte
+	count int
+	lit   string
+}
+
+// Format 是解析后的图片字符串
+type Format struct {
+	kind    Kind
+	picture string
+	tokens  []token
+	hasDay  bool // 含有 d 或 dd，月份名称使用所有格
+}
+
+func verbs(kind Kind) string {
+	if kind == Date {
+		return "dMyg"
+	}
+	return "hHmst"
+}
+
+// Parse 解析图片字符串。单引号之间的文本按原样输出，两个连续的单引号输出一个单引号；
+// 未闭合的引号延续到字符串末尾。解析不会失败。
+func Parse(kind Kind, picture string) *Format {
+	f := &Format{kind: kind, picture: picture}
+	specs := verbs(kind)
+	var lit strings.Builder
+	flush := func() {
+		if lit.Len() > 0 {
+			f.tokens = append(f.tokens, token{lit: lit.String()})
+			lit.Reset()
+		}
+	}
+	for i := 0; i < len(picture); {
+		c := picture[i]
+		switch {
+		case c == '\'':
+			i++
+			if i < len(picture) && picture[i] == '\'' {
+				lit.WriteByte('\'')
+				i++
+				continue
+			}
+			for i < len(picture) {
+				if picture[i] == '\'' {
+					if i+1 < len(picture) && picture[i+1] == '\'' {
+						lit.WriteByte('\'')
+						i += 2
+						continue
+					}
+					i++
+					break
+				}
+				lit.WriteByte(picture[i])
+				i++
+			}
+		case strings.IndexByte(specs, c) >= 0:
+			n := 1
+			for i+n < len(picture) && picture[i+n] == c {
+				n++
+			}
+			flush()
+			f.tokens = append(f.tokens, token{verb: c, count: n})
+			if c == 'd' && n <= 2 {
+				f.hasDay = true
+			}
+			i += n
+		default:
+			lit.WriteByte(c)
+			i++
+		}
+	}
+	flush()
+	return f
+}
+
+// ParseTime 解析时间图片字符串
+func ParseTime(picture string) *Format {
+	return Parse(Time, picture)
+}
+
+// ParseDate 解析日期图片字符串
+func ParseDate(picture string) *Format {
+	return Parse(Date, picture)
+}
+
+// String 返回原始图片字符串
+func (f *Format) String() string {
+	return f.picture
+}
+
+func pad2(b []byte, v int) []byte {
+	if v < 10 {
+		b = append(b, '0')
+	}
+	return strconv.AppendInt(b, int64(v), 10)
+}
+
+func name(names []string, genitive []string, i int, useGenitive bool) string {
+	if useGenitive && genitive[i] != "" {
+		return genitive[i]
+	}
+	return names[i]
+}
+
+// Format 按图片格式化 t，names 为 nil 时使用 English。t 不做时区转换。
+func (f *Format) Format(t time.Time, names *Names) string {
+	if names == nil {
+		names = English
+	}
+	b := make([]byte, 0, len(f.picture)+16)
+	for _, tok := range f.tokens {
+		if tok.verb == 0 {
+			b = append(b, tok.lit...)
+			continue
+		}
+		n := tok.count
+		switch tok.verb {
+		case 'h':
+			h := t.Hour() % 12
+			if h == 0 {
+				h = 12
+			}
+			if n == 1 {
+				b = strconv.AppendInt(b, int64(h), 10)
+			} else {
+				b = pad2(b, h)
+			}
+		case 'H':
+			if n == 1 {
+				b = strconv.AppendInt(b, int64(t.Hour()), 10)
+			} else {
+				b = pad2(b, t.Hour())
+			}
+		case 'm':
+			if n == 1 {
+				b = strconv.AppendInt(b, int64(t.Minute()), 10)
+			} else {
+				b = pad2(b, t.Minute())
+			}
+		case 's':
+			if n == 1 {
+				b = strconv.AppendInt(b, int64(t.Second()), 10)
+			} else {
+				b = pad2(b, t.Second())
+			}
+		case 't':
+			d := names.AM
+			if t.Hour() >= 12 {
+				d = names.PM
+			}
+			if n == 1 && d != "" {
+				_, size := utf8.DecodeRuneInString(d)
+				d = d[:size]
+			}
+			b = append(b, d...)
+		case 'd':
+			switch n {
+			case 1:
+				b = strconv.AppendInt(b, int64(t.Day()), 10)
+			case 2:
+				b = pad2(b, t.Day())
+			case 3:
+				b = append(b, names.ShortDays[t.Weekday()]...)
+			default:
+				b = append(b, names.Days[t.Weekday()]...)
+			}
+		case 'M':
+			m := int(t.Month()) - 1
+			switch n {
+			case 1:
+				b = strconv.AppendInt(b, int64(m+1), 10)
+			case 2:
+				b = pad2(b, m+1)
+			case 3:
+				b = append(b, name(names.ShortMonths[:], names.GenitiveShortMonths[:], m, f.hasDay)...)
+			default:
+				b = append(b, name(names.Months[:], names.GenitiveMonths[:], m, f.hasDay)...)
+			}
+		case 'y':
+			y := t.Year()
+			switch n {
+			case 1:
+				b = strconv.AppendInt(b, int64(y%100), 10)
+			case 2:
+				b = pad2(b, y%100)
+			default:
+				b = strconv.AppendInt(b, int64(y), 10)
+			}
+		case 'g':
+			b = append(b, names.Era...)
+		}
+	}
+	return string(b)
+}
+
+// goVerbs 是可以直接转换为 Go 布局的说明符，键为说明符和重复次数（超过 4 次按 4 次处理）
+var goVerbs = map[[2]int]string{
+	{'h', 1}: "3", {'h', 2}: "03",
+	{'H', 2}: "15",
+	{'m', 1}: "4", {'m', 2}: "04",
+	{'s', 1}: "5", {'s', 2}: "05",
+	{'t', 2}: "PM",
+	{'d', 1}: "2", {'d', 2}: "02", {'d', 3}: "Mon", {'d', 4}: "Monday",
+	{'M', 1}: "1", {'M', 2}: "01", {'M', 3}: "Jan", {'M', 4}: "January",
+	{'y', 2}: "06", {'y', 4}: "2006",
+}
+
+// GoLayout 返回与图片在 English 名称下输出相同的 Go 布局字符串。
+// 图片包含 Go 布局无法表示的元素（如不补零的 24 小时制 H、单字符 t、一位年份 y、纪元 g、
+// 所有格月份名称）或字面文本与 Go 布局元素冲突时返回 false。
+func (f *Format) GoLayout() (string, bool) {
+	var b strings.Builder
+	for _, tok := range f.tokens {
+		if tok.verb == 0 {
+			b.WriteString(tok.lit)
+			continue
+		}
+		n := tok.count
+		switch {
+		case tok.verb == 'y' && n >= 3:
+			n = 4
+		case tok.verb == 'd' || tok.verb == 'M':
+			n = min(n, 4)
+		default:
+			n = min(n, 2)
+		}
+		s, ok := goVerbs[[2]int{int(tok.verb), n}]
+		if !ok {
+			return "", false
+		}
+		b.WriteString(s)
+	}
+	layout := b.String()
+	// Go 布局没有转义语法，用两个各字段都不同的时间验证字面文本没有被解释为布局元素
+	for _, probe := range layoutProbes {
+		if probe.Format(layout) != f.Format(probe, English) {
+			return "", false
+		}
+	}
+	return layout, true
+}
+
+var layoutProbes = []time.Time{
+	time.Date(2023, time.March, 5, 14, 7, 9, 0, time.UTC),
+	time.Date(1999, time.November, 28, 9, 45, 30, 0, time.FixedZone("", -7*3600)),
+}
@@ -0,0 +1,118 @@
+package timefmt
+
+import (
+	"testing"
+	"time"
+)
+
+var (
+	afternoon = time.Date(2023, time.March, 5, 13, 5, 9, 0, time.UTC)
+	morning   = time.Date(2005, time.December, 24, 0, 30, 0, 0, time.UTC)
+)
+
+// 期望值为 en-US 区域设置下 GetTimeFormatEx/GetDateFormatEx 的输出
+func TestFormatGolden(t *testing.T) {
+	tests := []struct {
+		kind    Kind
+		picture string
+		t       time.Time
+		want    string
+	}{
+		{Time, "HH:mm:ss", afternoon, "13:05:09"},
+		{Time, "h:mm:ss tt", afternoon, "1:05:09 PM"},
+		{Time, "h:mm:ss tt", morning, "12:30:00 AM"},
+		{Time, "tt h:mm", afternoon, "PM 1:05"},
+		{Time, "H:m:s", morning, "0:30:0"},
+		{Time, "hhh:mmm", afternoon, "01:05"},
+		{Time, "t", morning, "A"},
+		{Time, "HH 'h' mm 'min'", afternoon, "13 h 05 min"},
+		{Time, "HH'h'mm", afternoon, "13h05"},
+		{Time, "HH''mm", afternoon, "13'05"},
+		{Time, "'o''clock' H", afternoon, "o'clock 13"},
+		{Time, "'unterminated H", afternoon, "unterminated H"},
+		{Time, "dd/MM HH", afternoon, "dd/MM 13"},
+		{Date, "M/d/yyyy", afternoon, "3/5/2023"},
+		{Date, "MM/dd/yy", morning, "12/24/05"},
+		{Date, "dddd, MMMM d, yyyy", afternoon, "Sunday, March 5, 2023"},
+		{Date, "ddd, MMM dd yyyyy", morning, "Sat, Dec 24 2005"},
+		{Date, "ddddd MMMMM", afternoon, "Sunday March"},
+		{Date, "y yy yyy", morning, "5 05 2005"},
+		{Date, "MMMM yyyy", morning, "December 2005"},
+		{Date, "gg yyyy", afternoon, "A.D. 2023"},
+		{Date, "yyyy'年'M'月'd'日'", afternoon, "2023年3月5日"},
+		{Date, "d 'de' MMMM", afternoon, "5 de March"},
+		{Date, "HH:mm d", afternoon, "HH:mm 5"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.picture, func(t *testing.T) {
+			if got := Parse(tt.kind, tt.picture).Format(tt.t, nil); got != tt.want {
+				t.Errorf("Format(%q) = %q, want %q", tt.picture, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatGenitive(t *testing.T) {
+	ru := &Names{}
+	ru.Months[2] = "Март"
+	ru.GenitiveMonths[2] = "марта"
+	ru.ShortMonths[2] = "мар"
+	tests := []struct {
+		picture string
+		want    string
+	}{
+		{"d MMMM yyyy", "5 марта 2023"},
+		{"dd MMMM", "05 марта"},
+		{"MMMM yyyy", "Март 2023"},
+		// 星期名称不是日期数字，不触发所有格
+		{"dddd MMMM", " Март"},
+		// 没有所有格缩写时使用主格缩写
+		{"d MMM", "5 мар"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.picture, func(t *testing.T) {
+			if got := ParseDate(tt.picture).Format(afternoon, ru); got != tt.want {
+				t.Errorf("Format(%q) = %q, want %q", tt.picture, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatEmptyDesignator(t *testing.T) {
+	names := *English
+	names.AM, names.PM = "", ""
+	if got := ParseTime("h:mm t|tt").Format(afternoon, &names); got != "1:05 |" {
+		t.Errorf("Format() = %q, want %q", got, "1:05 |")
+	}
+}
+
+func TestGoLayout(t *testing.T) {
+	tests := []struct {
+		kind    Kind
+		picture string
+		want    string
+		ok      bool
+	}{
+		{Time, "HH:mm:ss", "15:04:05", true},
+		{Time, "h:mm:ss tt", "3:04:05 PM", true},
+		{Time, "hh 'o''clock'", "03 o'clock", true},
+		{Date, "dddd, MMMM d, yyyy", "Monday, January 2, 2006", true},
+		{Date, "M/d/yy", "1/2/06", true},
+		{Date, "yyy-MM-dd", "2006-01-02", true},
+		{Time, "H:mm", "", false},   // 不补零的 24 小时制
+		{Time, "h:mm t", "", false}, // 单字符标识符
+		{Date, "d/M/y", "", false},  // 一位年份
+		{Date, "gg yyyy", "", false},
+		{Date, "'Day' d", "Day 2", true},
+		{Date, "'Mon' d", "", false}, // 字面文本 "Mon" 会被 Go 解释为星期缩写
+		{Time, "HH'15'", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.picture, func(t *testing.T) {
+			got, ok := Parse(tt.kind, tt.picture).GoLayout()
+			if ok != tt.ok || got != tt.want {
+				t.Errorf("GoLayout(%q) = %q, %v; want %q, %v", tt.picture, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
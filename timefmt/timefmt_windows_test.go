@@ -0,0 +1,82 @@
+package timefmt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/C1ph3rX13/xwindows"
+	"golang.org/x/sys/windows"
+)
+
+// systemFormat 使用 GetTimeFormatEx 或 GetDateFormatEx 按图片格式化 t
+func systemFormat(t *testing.T, kind Kind, locale, picture string, tm time.Time) string {
+	t.Helper()
+	name, _ := windows.UTF16PtrFromString(locale)
+	format, _ := windows.UTF16PtrFromString(picture)
+	st := windows.Systemtime{
+		Year: uint16(tm.Year()), Month: uint16(tm.Month()), Day: uint16(tm.Day()), DayOfWeek: uint16(tm.Weekday()),
+		Hour: uint16(tm.Hour()), Minute: uint16(tm.Minute()), Second: uint16(tm.Second()),
+	}
+	buf := make([]uint16, 256)
+	var n int32
+	var err error
+	if kind == Time {
+		n, err = xwindows.GetTimeFormatEx(name, 0, &st, format, &buf[0], int32(len(buf)))
+	} else {
+		n, err = xwindows.GetDateFormatEx(name, 0, &st, format, &buf[0], int32(len(buf)))
+	}
+	if err != nil {
+		t.Fatalf("format %q for %s: %v", picture, locale, err)
+	}
+	return windows.UTF16ToString(buf[:n])
+}
+
+// TestAgainstSystem 将各区域设置的全部格式与系统的格式化结果对比
+func TestAgainstSystem(t *testing.T) {
+	times := []time.Time{afternoon, morning, time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC)}
+	for _, locale := range []string{"en-US", "de-DE", "fr-FR", "ru-RU", "ja-JP", "zh-CN"} {
+		t.Run(locale, func(t *testing.T) {
+			names, err := LocaleNames(locale)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pictures := map[string]Kind{}
+			for p, err := range xwindows.TimeFormats(locale, 0) {
+				if err != nil {
+					t.Fatal(err)
+				}
+				pictures[p] = Time
+			}
+			for _, flags := range []uint32{xwindows.DATE_SHORTDATE, xwindows.DATE_LONGDATE, xwindows.DATE_YEARMONTH} {
+				err := xwindows.EnumDateFormatsExExFunc(locale, flags, func(p string, calendar uint32) bool {
+					if calendar == xwindows.CAL_GREGORIAN {
+						pictures[p] = Date
+					}
+					return true
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			for p, kind := range pictures {
+				f := Parse(kind, p)
+				for _, tm := range times {
+					want := systemFormat(t, kind, locale, p, tm)
+					if got := f.Format(tm, names); got != want {
+						t.Errorf("%q at %v: got %q, system %q", p, tm, got, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestLocaleNamesEnglish(t *testing.T) {
+	names, err := LocaleNames("en-US")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names.Days != English.Days || names.Months != English.Months || names.AM != "AM" || names.Era != "A.D." {
+		t.Errorf("LocaleNames(en-US) = %+v", names)
+	}
+}
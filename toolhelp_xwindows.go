@@ -0,0 +1,235 @@
+package xwindows
+
+import (
+	"cmp"
+	"errors"
+	"iter"
+	"slices"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// ProcessEntry 是 PROCESSENTRY32W 的 Go 表示
+type ProcessEntry struct {
+	ProcessID       uint32
+	ParentProcessID uint32
+	Threads         uint32 // 进程启动的执行线程数
+	BasePriority    int32  // 进程创建的任何线程的基本优先级
+	ExeFile         string // 可执行文件的名称（不含路径）
+}
+
+// ThreadEntry 是 THREADENTRY32 的 Go 表示
+type ThreadEntry struct {
+	ThreadID       uint32
+	OwnerProcessID uint32
+	BasePriority   int32
+}
+
+// ModuleEntry 是 MODULEENTRY32W 的 Go 表示
+type ModuleEntry struct {
+	ProcessID uint32
+	Base      uintptr
+	Size      uint32
+	Handle    windows.Handle
+	Name      string // 模块名称
+	Path      string // 模块的完整路径
+}
+
+// HeapListEntry 是 HEAPLIST32 的 Go 表示
+type HeapListEntry struct {
+	ProcessID uint32
+	HeapID    uintptr
+	Default   bool // 是否为进程的默认堆
+}
+
+// createSnapshot 创建快照，模块快照遇到 ERROR_BAD_LENGTH 时按文档要求重试
+func createSnapshot(flags uint32, processId uint32) (snapshot windows.Handle, err error) {
+	for range 8 {
+		snapshot, err = CreateToolhelp32Snapshot(flags, processId)
+		if !errors.Is(err, windows.ERROR_BAD_LENGTH) {
+			break
+		}
+	}
+	return
+}
+
+// snapshotSeq 返回遍历快照条目的迭代器，迭代结束或循环体 break 时自动关闭快照
+func snapshotSeq[E, T any](flags uint32, processId uint32, first, next func(windows.Handle, *E) error, init func(*E), convert func(*E) (T, bool)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		snapshot, err := createSnapshot(flags, processId)
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		defer CloseHandle(snapshot)
+
+		var entry E
+		init(&entry)
+		for err = first(snapshot, &entry); err == nil; err = next(snapshot, &entry) {
+			if v, ok := convert(&entry); ok && !yield(v, nil) {
+				return
+			}
+			init(&entry)
+		}
+		if !errors.Is(err, windows.ERROR_NO_MORE_FILES) {
+			yield(zero, err)
+		}
+	}
+}
+
+// Processes 返回系统中所有进程的迭代器
+func Processes() iter.Seq2[ProcessEntry, error] {
+	return snapshotSeq(windows.TH32CS_SNAPPROCESS, 0, Process32FirstW, Process32NextW,
+		func(e *windows.ProcessEntry32) {
+			*e = windows.ProcessEntry32{Size: uint32(unsafe.Sizeof(*e))}
+		},
+		func(e *windows.ProcessEntry32) (ProcessEntry, bool) {
+			return ProcessEntry{
+				ProcessID:       e.ProcessID,
+				ParentProcessID: e.ParentProcessID,
+				Threads:         e.Threads,
+				BasePriority:    e.PriClassBase,
+				ExeFile:         windows.UTF16ToString(e.ExeFile[:]),
+			}, true
+		})
+}
+
+// Threads 返回指定进程所有线程的迭代器，processId 为 0 时返回系统中的所有线程
+func Threads(processId uint32) iter.Seq2[ThreadEntry, error] {
+	return snapshotSeq(windows.TH32CS_SNAPTHREAD, 0, Thread32First, Thread32Next,
+		func(e *ThreadEntry32) {
+			*e = ThreadEntry32{Size: uint32(unsafe.Sizeof(*e))}
+		},
+		func(e *ThreadEntry32) (ThreadEntry, bool) {
+			// 线程快照总是包含系统中的所有线程，需要按所有者过滤
+			if processId != 0 && e.OwnerProcessID != processId {
+				return ThreadEntry{}, false
+			}
+			return ThreadEntry{
+				ThreadID:       e.ThreadID,
+				OwnerProcessID: e.OwnerProcessID,
+				BasePriority:   e.BasePri,
+			}, true
+		})
+}
+
+// Modules 返回指定进程所有模块（包括 32 位模块）的迭代器，processId 为 0 时表示当前进程
+func Modules(processId uint32) iter.Seq2[ModuleEntry, error] {
+	return snapshotSeq(windows.TH32CS_SNAPMODULE|windows.TH32CS_SNAPMODULE32, processId, Module32FirstW, Module32NextW,
+		func(e *windows.ModuleEntry32) {
+			*e = windows.ModuleEntry32{Size: uint32(unsafe.Sizeof(*e))}
+		},
+		func(e *windows.ModuleEntry32) (ModuleEntry, bool) {
+			return ModuleEntry{
+				ProcessID: e.ProcessID,
+				Base:      e.ModBaseAddr,
+				Size:      e.ModBaseSize,
+				Handle:    e.ModuleHandle,
+				Name:      windows.UTF16ToString(e.Module[:]),
+				Path:      windows.UTF16ToString(e.ExePath[:]),
+			}, true
+		})
+}
+
+// HeapLists 返回指定进程所有堆的迭代器，processId 为 0 时表示当前进程
+func HeapLists(processId uint32) iter.Seq2[HeapListEntry, error] {
+	return snapshotSeq(windows.TH32CS_SNAPHEAPLIST, processId, Heap32ListFirst, Heap32ListNext,
+		func(e *HeapList32) {
+			*e = HeapList32{Size: unsafe.Sizeof(*e)}
+		},
+		func(e *HeapList32) (HeapListEntry, bool) {
+			return HeapListEntry{
+				ProcessID: e.ProcessID,
+				HeapID:    e.HeapID,
+				Default:   e.Flags&HF32_DEFAULT != 0,
+			}, true
+		})
+}
+
+// ProcessNode 是进程树中的一个节点
+type ProcessNode struct {
+	ProcessEntry
+	Parent   *ProcessNode
+	Children []*ProcessNode // 按进程 ID 排序
+}
+
+// ProcessTree 是按父子关系组织的进程快照
+type ProcessTree struct {
+	Roots []*ProcessNode // 父进程不存在（已退出）的进程，按进程 ID 排序
+	nodes map[uint32]*ProcessNode
+}
+
+// BuildProcessTree 对系统中的进程做快照，并将父进程与子进程关联起来
+func BuildProcessTree() (*ProcessTree, error) {
+	var entries []ProcessEntry
+	for e, err := range Processes() {
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	return newProcessTree(entries), nil
+}
+
+func newProcessTree(entries []ProcessEntry) *ProcessTree {
+	t := &ProcessTree{nodes: make(map[uint32]*ProcessNode, len(entries))}
+	for _, e := range entries {
+		t.nodes[e.ProcessID] = &ProcessNode{ProcessEntry: e}
+	}
+	for _, n := range t.nodes {
+		parent, ok := t.nodes[n.ParentProcessID]
+		// System Idle Process 的父进程是它自己；父进程 ID 被重用时可能形成环
+		if !ok || parent == n || parent.isDescendantOf(n) {
+			continue
+		}
+		n.Parent = parent
+		parent.Children = append(parent.Children, n)
+	}
+	for _, n := range t.nodes {
+		slices.SortFunc(n.Children, compareNodes)
+		if n.Parent == nil {
+			t.Roots = append(t.Roots, n)
+		}
+	}
+	slices.SortFunc(t.Roots, compareNodes)
+	return t
+}
+
+func compareNodes(a, b *ProcessNode) int {
+	return cmp.Compare(a.ProcessID, b.ProcessID)
+}
+
+func (n *ProcessNode) isDescendantOf(ancestor *ProcessNode) bool {
+	for p := n.Parent; p != nil; p = p.Parent {
+		if p == ancestor {
+			return true
+		}
+	}
+	return false
+}
+
+// Find 按进程 ID 查找节点，不存在时返回 nil
+func (t *ProcessTree) Find(processId uint32) *ProcessNode {
+	return t.nodes[processId]
+}
+
+// Len 返回树中的进程数
+func (t *ProcessTree) Len() int {
+	return len(t.nodes)
+}
+
+// Walk 以深度优先顺序遍历进程树，depth 为节点深度（根节点为 0），fn 返回 false 时停止遍历
+func (t *ProcessTree) Walk(fn func(node *ProcessNode, depth int) bool) {
+	var walk func(nodes []*ProcessNode, depth int) bool
+	walk = func(nodes []*ProcessNode, depth int) bool {
+		for _, n := range nodes {
+			if !fn(n, depth) || !walk(n.Children, depth+1) {
+				return false
+			}
+		}
+		return true
+	}
+	walk(t.Roots, 0)
+}
@@ -0,0 +1,98 @@
+package xwindows
+
+import (
+	"os"
+	"slices"
+	"testing"
+)
+
+func TestNewProcessTree(t *testing.T) {
+	tests := []struct {
+		name      string
+		entries   []ProcessEntry
+		wantRoots []uint32
+		wantWalk  []uint32
+	}{
+		{
+			name: "parent and children",
+			entries: []ProcessEntry{
+				{ProcessID: 4, ParentProcessID: 0},
+				{ProcessID: 100, ParentProcessID: 4},
+				{ProcessID: 300, ParentProcessID: 100},
+				{ProcessID: 200, ParentProcessID: 100},
+			},
+			wantRoots: []uint32{4},
+			wantWalk:  []uint32{4, 100, 200, 300},
+		},
+		{
+			name: "idle process is its own parent",
+			entries: []ProcessEntry{
+				{ProcessID: 0, ParentProcessID: 0},
+				{ProcessID: 4, ParentProcessID: 0},
+			},
+			wantRoots: []uint32{0},
+			wantWalk:  []uint32{0, 4},
+		},
+		{
+			name: "exited parent",
+			entries: []ProcessEntry{
+				{ProcessID: 500, ParentProcessID: 999},
+				{ProcessID: 400, ParentProcessID: 998},
+			},
+			wantRoots: []uint32{400, 500},
+			wantWalk:  []uint32{400, 500},
+		},
+		{
+			name: "reused parent id forms a cycle",
+			entries: []ProcessEntry{
+				{ProcessID: 10, ParentProcessID: 20},
+				{ProcessID: 20, ParentProcessID: 10},
+			},
+			wantWalk: []uint32{10, 20},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree := newProcessTree(tt.entries)
+			var walk []uint32
+			tree.Walk(func(node *ProcessNode, depth int) bool {
+				walk = append(walk, node.ProcessID)
+				return true
+			})
+			if tt.wantRoots != nil {
+				var roots []uint32
+				for _, n := range tree.Roots {
+					roots = append(roots, n.ProcessID)
+				}
+				if !slices.Equal(roots, tt.wantRoots) {
+					t.Errorf("Roots = %v, want %v", roots, tt.wantRoots)
+				}
+			}
+			slices.Sort(walk)
+			if !slices.Equal(walk, tt.wantWalk) {
+				t.Errorf("Walk() = %v, want %v", walk, tt.wantWalk)
+			}
+		})
+	}
+}
+
+func TestProcessesContainsSelf(t *testing.T) {
+	pid := uint32(os.Getpid())
+	tree, err := BuildProcessTree()
+	if err != nil {
+		t.Fatalf("BuildProcessTree() error = %v", err)
+	}
+	if tree.Find(pid) == nil {
+		t.Fatalf("BuildProcessTree() missing current process %d", pid)
+	}
+	threads := 0
+	for _, err := range Threads(pid) {
+		if err != nil {
+			t.Fatalf("Threads() error = %v", err)
+		}
+		threads++
+	}
+	if threads == 0 {
+		t.Errorf("Threads(%d) yielded no threads", pid)
+	}
+}
@@ -1,6 +1,7 @@
 package xwindows
 
 import (
+	"github.com/C1ph3rX13/xwindows/guid"
 	"golang.org/x/sys/windows"
 )
 
@@ -10,6 +11,272 @@ const (
 	PROCESS_ALL_ACCESS = windows.STANDARD_RIGHTS_REQUIRED | windows.SYNCHRONIZE | 0xFFF
 )
 
+// WaitForMultipleObjects 一次最多等待的句柄数
+const MAXIMUM_WAIT_OBJECTS = 64
+
+// CreateWaitableTimerEx 标志
+const (
+	CREATE_WAITABLE_TIMER_MANUAL_RESET    = 0x00000001 // 手动重置计时器
+	CREATE_WAITABLE_TIMER_HIGH_RESOLUTION = 0x00000002 // 高精度计时器，需要 Windows 10 1803 及以上版本
+)
+
+// TIMECAPS，定时器设备支持的分辨率（毫秒）
+// https://learn.microsoft.com/zh-cn/windows/win32/api/timeapi/ns-timeapi-timecaps
+type TIMECAPS struct {
+	PeriodMin uint32
+	PeriodMax uint32
+}
+
+// timeSetEvent 的 fuEvent 标志
+const (
+	TIME_ONESHOT              = 0x0000 // 只触发一次
+	TIME_PERIODIC             = 0x0001 // 周期触发
+	TIME_CALLBACK_FUNCTION    = 0x0000 // lpTimeProc 是回调函数
+	TIME_CALLBACK_EVENT_SET   = 0x0010 // lpTimeProc 是事件句柄，触发时调用 SetEvent
+	TIME_CALLBACK_EVENT_PULSE = 0x0020 // lpTimeProc 是事件句柄，触发时调用 PulseEvent
+	TIME_KILL_SYNCHRONOUS     = 0x0100 // timeKillEvent 之后不再触发
+)
+
+// WAVEFORMATEX，C 中的大小为 18 字节（pack(1)），PCM 和 IEEE 浮点格式的 CbSize 为 0
+// https://learn.microsoft.com/zh-cn/windows/win32/api/mmeapi/ns-mmeapi-waveformatex
+type WAVEFORMATEX struct {
+	FormatTag      uint16 // WAVE_FORMAT_PCM 或 WAVE_FORMAT_IEEE_FLOAT
+	Channels       uint16
+	SamplesPerSec  uint32
+	AvgBytesPerSec uint32 // SamplesPerSec * BlockAlign
+	BlockAlign     uint16 // Channels * BitsPerSample / 8
+	BitsPerSample  uint16
+	CbSize         uint16 // 附加格式信息的字节数
+}
+
+// WAVEHDR，排队期间缓冲区和结构本身都不能移动或释放
+// https://learn.microsoft.com/zh-cn/windows/win32/api/mmeapi/ns-mmeapi-wavehdr
+type WAVEHDR struct {
+	Data          uintptr // 波形数据缓冲区
+	BufferLength  uint32
+	BytesRecorded uint32
+	User          uintptr
+	Flags         uint32 // WHDR_*，由驱动程序更新
+	Loops         uint32
+	Next          uintptr // 保留
+	Reserved      uintptr
+}
+
+// WAVEOUTCAPSW
+// https://learn.microsoft.com/zh-cn/windows/win32/api/mmeapi/ns-mmeapi-waveoutcapsw
+type WAVEOUTCAPSW struct {
+	Mid           uint16
+	Pid           uint16
+	DriverVersion uint32
+	Pname         [32]uint16 // 设备名称
+	Formats       uint32     // 支持的标准格式 WAVE_FORMAT_1M08 等
+	Channels      uint16
+	Reserved1     uint16
+	Support       uint32 // WAVECAPS_*
+}
+
+// waveOut 常量
+const (
+	WAVE_MAPPER            = 0xFFFFFFFF // 选择能够播放指定格式的设备
+	WAVE_FORMAT_PCM        = 0x0001
+	WAVE_FORMAT_IEEE_FLOAT = 0x0003
+
+	CALLBACK_NULL     = 0x00000000
+	CALLBACK_EVENT    = 0x00050000 // dwCallback 是事件句柄
+	CALLBACK_FUNCTION = 0x00030000
+
+	WHDR_DONE      = 0x00000001 // 驱动程序已用完缓冲区
+	WHDR_PREPARED  = 0x00000002
+	WHDR_BEGINLOOP = 0x00000004
+	WHDR_ENDLOOP   = 0x00000008
+	WHDR_INQUEUE   = 0x00000010
+
+	WAVECAPS_PITCH    = 0x0001
+	WAVECAPS_RATE     = 0x0002
+	WAVECAPS_VOLUME   = 0x0004 // 支持音量控制
+	WAVECAPS_LRVOLUME = 0x0008 // 支持左右声道独立音量
+)
+
+// PlaySound 标志
+const (
+	SND_SYNC      = 0x00000000 // 播放完毕后返回
+	SND_ASYNC     = 0x00000001 // 立即返回
+	SND_NODEFAULT = 0x00000002 // 找不到声音时不播放默认声音
+	SND_MEMORY    = 0x00000004 // pszSound 指向内存中的声音
+	SND_LOOP      = 0x00000008 // 循环播放，需要 SND_ASYNC
+	SND_NOSTOP    = 0x00000010 // 正在播放其他声音时不打断并返回 FALSE
+	SND_PURGE     = 0x00000040
+	SND_ALIAS     = 0x00010000 // pszSound 是系统事件别名
+	SND_FILENAME  = 0x00020000 // pszSound 是文件名
+	SND_RESOURCE  = 0x00040004 // pszSound 是资源名，hmod 是模块句柄
+)
+
+// MMRESULT 错误码
+const (
+	MMSYSERR_NOERROR      = 0
+	MMSYSERR_ERROR        = 1
+	MMSYSERR_BADDEVICEID  = 2
+	MMSYSERR_NOTENABLED   = 3
+	MMSYSERR_ALLOCATED    = 4
+	MMSYSERR_INVALHANDLE  = 5
+	MMSYSERR_NODRIVER     = 6
+	MMSYSERR_NOMEM        = 7
+	MMSYSERR_NOTSUPPORTED = 8
+	MMSYSERR_INVALFLAG    = 10
+	MMSYSERR_INVALPARAM   = 11
+	WAVERR_BADFORMAT      = 32
+	WAVERR_STILLPLAYING   = 33
+	WAVERR_UNPREPARED     = 34
+	WAVERR_SYNC           = 35
+	TIMERR_NOCANDO        = 97
+	TIMERR_STRUCT         = 129
+)
+
+// EnumSystemLocalesEx 标志
+const (
+	LOCALE_ALL             = 0x00000000 // 枚举所有区域设置
+	LOCALE_WINDOWS         = 0x00000001 // Windows 附带的区域设置
+	LOCALE_SUPPLEMENTAL    = 0x00000002 // 补充区域设置
+	LOCALE_ALTERNATE_SORTS = 0x00000004 // 备用排序区域设置
+	LOCALE_REPLACEMENT     = 0x00000008 // 替换区域设置
+	LOCALE_NEUTRALDATA     = 0x00000010 // 非特定区域设置
+	LOCALE_SPECIFICDATA    = 0x00000020 // 特定区域设置
+)
+
+// GetLocaleInfoEx 的 LCTYPE
+const (
+	LOCALE_SLOCALIZEDDISPLAYNAME = 0x00000002 // 以用户界面语言表示的完整名称
+	LOCALE_SLIST                 = 0x0000000C // 列表分隔符
+	LOCALE_IDEFAULTCODEPAGE      = 0x0000000B // 默认 OEM 代码页
+	LOCALE_SDECIMAL              = 0x0000000E // 小数分隔符
+	LOCALE_STHOUSAND             = 0x0000000F // 千位分隔符
+	LOCALE_IDEFAULTANSICODEPAGE  = 0x00001004 // 默认 ANSI 代码页
+	LOCALE_ICALENDARTYPE         = 0x00001009 // 默认日历 CAL_*
+	LOCALE_IFIRSTDAYOFWEEK       = 0x0000100C // 一周的第一天，0 为星期一
+	LOCALE_SISO639LANGNAME       = 0x00000059 // ISO 639 语言代码
+	LOCALE_SISO3166CTRYNAME      = 0x0000005A // ISO 3166 国家/地区代码
+	LOCALE_SNAME                 = 0x0000005C // 区域设置名称，如 "en-US"
+	LOCALE_SENGLISHDISPLAYNAME   = 0x00000072 // 英语完整名称
+	LOCALE_SNATIVEDISPLAYNAME    = 0x00000073 // 以区域设置自身语言表示的完整名称
+	LOCALE_RETURN_NUMBER         = 0x20000000 // 以 DWORD 返回数值类型的信息
+
+	LOCALE_S1159                 = 0x00000028 // 上午标识符
+	LOCALE_S2359                 = 0x00000029 // 下午标识符
+	LOCALE_SDAYNAME1             = 0x0000002A // 星期一的全称，至 LOCALE_SDAYNAME7（星期日）
+	LOCALE_SABBREVDAYNAME1       = 0x00000031 // 星期一的缩写，至 LOCALE_SABBREVDAYNAME7
+	LOCALE_SMONTHNAME1           = 0x00000038 // 一月的全称，至 LOCALE_SMONTHNAME12
+	LOCALE_SABBREVMONTHNAME1     = 0x00000044 // 一月的缩写，至 LOCALE_SABBREVMONTHNAME12
+	LOCALE_SSHORTDATE            = 0x0000001F // 短日期格式
+	LOCALE_SLONGDATE             = 0x00000020 // 长日期格式
+	LOCALE_STIMEFORMAT           = 0x00001003 // 时间格式
+	LOCALE_RETURN_GENITIVE_NAMES = 0x10000000 // 与 LOCALE_SMONTHNAME 组合，返回所有格月份名称
+)
+
+// GetCalendarInfoEx 的 CALTYPE
+const (
+	CAL_SERASTRING = 0x00000004 // 纪元名称
+)
+
+// EnumTimeFormatsEx、GetTimeFormatEx 标志
+const (
+	TIME_NOMINUTESORSECONDS = 0x00000001
+	TIME_NOSECONDS          = 0x00000002
+	TIME_NOTIMEMARKER       = 0x00000004
+	TIME_FORCE24HOURFORMAT  = 0x00000008
+)
+
+// EnumDateFormatsExEx、GetDateFormatEx 标志
+const (
+	DATE_SHORTDATE = 0x00000001
+	DATE_LONGDATE  = 0x00000002
+	DATE_YEARMONTH = 0x00000008
+	DATE_MONTHDAY  = 0x00000080
+)
+
+// 区域设置名称与 LCID 转换标志，以及特殊的区域设置名称
+const (
+	LOCALE_ALLOW_NEUTRAL_NAMES = 0x08000000 // 允许非特定区域设置名称
+	LOCALE_NAME_MAX_LENGTH     = 85
+
+	LOCALE_NAME_INVARIANT      = ""
+	LOCALE_NAME_SYSTEM_DEFAULT = "!x-sys-default-locale"
+)
+
+// CALID 日历标识符
+const (
+	CAL_GREGORIAN              = 1
+	CAL_GREGORIAN_US           = 2
+	CAL_JAPAN                  = 3
+	CAL_TAIWAN                 = 4
+	CAL_KOREA                  = 5
+	CAL_HIJRI                  = 6
+	CAL_THAI                   = 7
+	CAL_HEBREW                 = 8
+	CAL_GREGORIAN_ME_FRENCH    = 9
+	CAL_GREGORIAN_ARABIC       = 10
+	CAL_GREGORIAN_XLIT_ENGLISH = 11
+	CAL_GREGORIAN_XLIT_FRENCH  = 12
+	CAL_UMALQURA               = 23
+)
+
+// DYNAMIC_TIME_ZONE_INFORMATION，前七个字段与 TIME_ZONE_INFORMATION 相同，
+// TimeZoneKeyName 是注册表 HKLM\SOFTWARE\Microsoft\Windows NT\CurrentVersion\Time Zones 下的子项名称
+// https://learn.microsoft.com/zh-cn/windows/win32/api/timezoneapi/ns-timezoneapi-dynamic_time_zone_information
+type DYNAMIC_TIME_ZONE_INFORMATION struct {
+	Bias                        int32 // UTC = 本地时间 + Bias（分钟）
+	StandardName                [32]uint16
+	StandardDate                windows.Systemtime // wYear 为 0 时是 "某月第 wDay 个星期 wDayOfWeek" 格式的规则
+	StandardBias                int32
+	DaylightName                [32]uint16
+	DaylightDate                windows.Systemtime
+	DaylightBias                int32
+	TimeZoneKeyName             [128]uint16
+	DynamicDaylightTimeDisabled BOOLEAN
+}
+
+// RPC_STATUS
+const (
+	RPC_S_OK                  = 0
+	RPC_S_INVALID_STRING_UUID = 1705
+	RPC_S_UUID_NO_ADDRESS     = 1739 // UuidCreateSequential 找不到网卡地址
+	RPC_S_UUID_LOCAL_ONLY     = 1824 // 生成的 UUID 只保证在本机唯一，调用仍然成功
+)
+
+// GetDynamicTimeZoneInformation 返回值
+const (
+	TIME_ZONE_ID_UNKNOWN  = 0 // 时区不使用夏令时
+	TIME_ZONE_ID_STANDARD = 1 // 当前处于标准时间
+	TIME_ZONE_ID_DAYLIGHT = 2 // 当前处于夏令时
+	TIME_ZONE_ID_INVALID  = 0xFFFFFFFF
+)
+
+// RegCreateKeyExW 的 dwOptions 取值和 lpdwDisposition 返回值，KEY_*、REG_SZ 等见 windows 包
+const (
+	REG_OPTION_NON_VOLATILE   = 0x00000000 // 保存到注册表文件，重启后仍然存在
+	REG_OPTION_VOLATILE       = 0x00000001 // 只保存在内存中，重启后丢失
+	REG_OPTION_CREATE_LINK    = 0x00000002 // 创建符号链接
+	REG_OPTION_BACKUP_RESTORE = 0x00000004 // 忽略访问检查，需要 SE_BACKUP_NAME 或 SE_RESTORE_NAME 特权
+
+	REG_CREATED_NEW_KEY     = 0x00000001 // 键不存在，已创建
+	REG_OPENED_EXISTING_KEY = 0x00000002 // 键已存在，已打开
+)
+
+// MEMORY_BASIC_INFORMATION 的 State 与 Type 取值，MEM_COMMIT、MEM_RESERVE 见 windows 包
+const (
+	MEM_FREE    = 0x00010000 // 空闲页面，不可访问
+	MEM_PRIVATE = 0x00020000 // 私有页面
+	MEM_MAPPED  = 0x00040000 // 映射到节视图的页面
+	MEM_IMAGE   = 0x01000000 // 映射到映像节视图的页面
+)
+
+// CreateFileMapping 节属性与 MapViewOfFile 访问标志，其余 FILE_MAP_* 见 windows 包
+const (
+	SEC_COMMIT           = 0x08000000 // 映射视图时提交所有页面，默认值
+	SEC_LARGE_PAGES      = 0x80000000 // 使用大页，必须与 SEC_COMMIT 一起指定，仅适用于页面文件支持的节
+	FILE_MAP_ALL_ACCESS  = 0x000F001F
+	FILE_MAP_LARGE_PAGES = 0x20000000 // 映射大页节的视图
+)
+
 type (
 	BOOLEAN          byte
 	BOOL             int32
@@ -42,6 +309,166 @@ type ThreadEntry32 struct {
 	Flags          uint32
 }
 
+// ENUM_PAGE_FILE_INFORMATION
+// https://learn.microsoft.com/zh-cn/windows/win32/api/psapi/ns-psapi-enum_page_file_information
+type ENUM_PAGE_FILE_INFORMATION struct {
+	Cb         uint32
+	Reserved   uint32
+	TotalSize  uintptr // 页面文件的总大小（以页为单位）
+	TotalInUse uintptr // 当前使用的页数
+	PeakUsage  uintptr // 使用的峰值页数
+}
+
+// CLIENT_ID
+// https://learn.microsoft.com/en-us/openspecs/windows_protocols/ms-tsts/a11e7129-685b-4535-8d37-21d4596ac057
+type CLIENT_ID struct {
+	UniqueProcess uintptr
+	UniqueThread  uintptr
+}
+
+// THREAD_BASIC_INFORMATION
+// https://ntdoc.m417z.com/thread_basic_information
+type THREAD_BASIC_INFORMATION struct {
+	ExitStatus     windows.NTStatus
+	TebBaseAddress uintptr
+	ClientId       CLIENT_ID
+	AffinityMask   uintptr
+	Priority       int32
+	BasePriority   int32
+}
+
+// THREADINFOCLASS 取值，名称加 Class 后缀以区别于 threadinfo_xwindows.go 中同名的类型化信息类
+// https://ntdoc.m417z.com/threadinfoclass
+const (
+	ThreadBasicInformationClass          = 0
+	ThreadTimesClass                     = 1
+	ThreadQuerySetWin32StartAddressClass = 9
+	ThreadIsIoPendingClass               = 16
+	ThreadNameInformationClass           = 38
+)
+
+// KERNEL_USER_TIMES 时间均以 100 纳秒为单位
+// https://ntdoc.m417z.com/kernel_user_times
+type KERNEL_USER_TIMES struct {
+	CreateTime int64
+	ExitTime   int64
+	KernelTime int64
+	UserTime   int64
+}
+
+// JOBOBJECT_BASIC_ACCOUNTING_INFORMATION 时间均以 100 纳秒为单位
+// https://learn.microsoft.com/zh-cn/windows/win32/api/winnt/ns-winnt-jobobject_basic_accounting_information
+type JOBOBJECT_BASIC_ACCOUNTING_INFORMATION struct {
+	TotalUserTime             int64
+	TotalKernelTime           int64
+	ThisPeriodTotalUserTime   int64
+	ThisPeriodTotalKernelTime int64
+	TotalPageFaultCount       uint32
+	TotalProcesses            uint32
+	ActiveProcesses           uint32
+	TotalTerminatedProcesses  uint32
+}
+
+// JOBOBJECT_BASIC_AND_IO_ACCOUNTING_INFORMATION
+// https://learn.microsoft.com/zh-cn/windows/win32/api/winnt/ns-winnt-jobobject_basic_and_io_accounting_information
+type JOBOBJECT_BASIC_AND_IO_ACCOUNTING_INFORMATION struct {
+	BasicInfo JOBOBJECT_BASIC_ACCOUNTING_INFORMATION
+	IoInfo    windows.IO_COUNTERS
+}
+
+// JOBOBJECT_BASIC_PROCESS_ID_LIST，ProcessIdList 实际长度为 NumberOfProcessIdsInList
+// https://learn.microsoft.com/zh-cn/windows/win32/api/winnt/ns-winnt-jobobject_basic_process_id_list
+type JOBOBJECT_BASIC_PROCESS_ID_LIST struct {
+	NumberOfAssignedProcesses uint32
+	NumberOfProcessIdsInList  uint32
+	ProcessIdList             [1]uintptr
+}
+
+// JOBOBJECT_CPU_RATE_CONTROL_INFORMATION，Value 按 ControlFlags 解释为 CpuRate、Weight 或 MinRate/MaxRate
+// https://learn.microsoft.com/zh-cn/windows/win32/api/winnt/ns-winnt-jobobject_cpu_rate_control_information
+type JOBOBJECT_CPU_RATE_CONTROL_INFORMATION struct {
+	ControlFlags uint32
+	Value        uint32
+}
+
+// JOBOBJECT_CPU_RATE_CONTROL_INFORMATION.ControlFlags
+const (
+	JOB_OBJECT_CPU_RATE_CONTROL_ENABLE       = 0x1  // 启用 CPU 速率控制
+	JOB_OBJECT_CPU_RATE_CONTROL_WEIGHT_BASED = 0x2  // Value 为 1 到 9 的权重
+	JOB_OBJECT_CPU_RATE_CONTROL_HARD_CAP     = 0x4  // 达到上限后不再调度作业中的线程
+	JOB_OBJECT_CPU_RATE_CONTROL_NOTIFY       = 0x8  // 超过速率时发送通知
+	JOB_OBJECT_CPU_RATE_CONTROL_MIN_MAX_RATE = 0x10 // Value 的低 16 位为 MinRate，高 16 位为 MaxRate
+)
+
+// JOBOBJECT_ASSOCIATE_COMPLETION_PORT
+// https://learn.microsoft.com/zh-cn/windows/win32/api/winnt/ns-winnt-jobobject_associate_completion_port
+type JOBOBJECT_ASSOCIATE_COMPLETION_PORT struct {
+	CompletionKey  uintptr
+	CompletionPort windows.Handle
+}
+
+// 作业对象通过完成端口发送的消息，对进程相关的消息 lpOverlapped 为进程 ID
+const (
+	JOB_OBJECT_MSG_END_OF_JOB_TIME       = 1
+	JOB_OBJECT_MSG_END_OF_PROCESS_TIME   = 2
+	JOB_OBJECT_MSG_ACTIVE_PROCESS_LIMIT  = 3
+	JOB_OBJECT_MSG_ACTIVE_PROCESS_ZERO   = 4
+	JOB_OBJECT_MSG_NEW_PROCESS           = 6
+	JOB_OBJECT_MSG_EXIT_PROCESS          = 7
+	JOB_OBJECT_MSG_ABNORMAL_EXIT_PROCESS = 8
+	JOB_OBJECT_MSG_PROCESS_MEMORY_LIMIT  = 9
+	JOB_OBJECT_MSG_JOB_MEMORY_LIMIT      = 10
+	JOB_OBJECT_MSG_NOTIFICATION_LIMIT    = 11
+)
+
+// CONSOLE_SCREEN_BUFFER_INFOEX，调用前 CbSize 必须设置为结构的大小
+// https://learn.microsoft.com/zh-cn/windows/console/console-screen-buffer-infoex
+type CONSOLE_SCREEN_BUFFER_INFOEX struct {
+	CbSize              uint32
+	Size                windows.Coord     // 缓冲区的列数和行数
+	CursorPosition      windows.Coord     // 光标的列和行
+	Attributes          uint16            // 写入字符的前景色和背景色属性
+	Window              windows.SmallRect // 窗口在缓冲区中的位置
+	MaximumWindowSize   windows.Coord
+	PopupAttributes     uint16
+	FullscreenSupported int32
+	ColorTable          [16]uint32 // 16 种控制台颜色，COLORREF 格式 0x00BBGGRR
+}
+
+// INPUT_RECORD，Event 按 EventType 解释为 KEY_EVENT_RECORD、MOUSE_EVENT_RECORD、
+// WINDOW_BUFFER_SIZE_RECORD、MENU_EVENT_RECORD 或 FOCUS_EVENT_RECORD
+// https://learn.microsoft.com/zh-cn/windows/console/input-record-str
+type INPUT_RECORD struct {
+	EventType uint16
+	_         uint16
+	Event     [16]byte
+}
+
+// KEY_EVENT_RECORD.dwControlKeyState 与 MOUSE_EVENT_RECORD.dwControlKeyState
+const (
+	RIGHT_ALT_PRESSED  = 0x0001
+	LEFT_ALT_PRESSED   = 0x0002
+	RIGHT_CTRL_PRESSED = 0x0004
+	LEFT_CTRL_PRESSED  = 0x0008
+	SHIFT_PRESSED      = 0x0010
+	NUMLOCK_ON         = 0x0020
+	SCROLLLOCK_ON      = 0x0040
+	CAPSLOCK_ON        = 0x0080
+	ENHANCED_KEY       = 0x0100
+)
+
+// HeapList32
+// https://learn.microsoft.com/zh-cn/windows/win32/api/tlhelp32/ns-tlhelp32-heaplist32
+type HeapList32 struct {
+	Size      uintptr
+	ProcessID uint32
+	HeapID    uintptr
+	Flags     uint32
+}
+
+// HeapList32.Flags
+const HF32_DEFAULT = 1 // 进程的默认堆
+
 /* EtwEventWrite Funcs */
 
 type EVENT_DESCRIPTOR struct {
@@ -54,12 +481,8 @@ type EVENT_DESCRIPTOR struct {
 	Keyword uint64
 }
 
-type GUID struct {
-	Data1 uint32
-	Data2 uint16
-	Data3 uint16
-	Data4 [8]byte
-}
+// GUID 的解析、格式化和比较见 guid 包
+type GUID = guid.GUID
 
 type EVENT_DATA_DESCRIPTOR struct {
 	ptr      uintptr
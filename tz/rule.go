@@ -0,0 +1,293 @@
+package tz
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ErrInvalidRule 表示 TZI 规则的字段超出范围
+var ErrInvalidRule = errors.New("tz: invalid time zone rule")
+
+// TZI 是一组时区规则，布局与注册表中 Time Zones\<键名>\TZI 值 (REG_TZI_FORMAT) 相同，
+// 也是 TIME_ZONE_INFORMATION 去掉名称后的部分。
+//
+// StandardDate 和 DaylightDate 是切换到标准时间和夏令时的时刻，Year 为 0 时表示
+// "Month 月第 Day 个星期 DayOfWeek 的 Hour:Minute"（Day 为 5 表示最后一个），
+// 以切换前的本地时间表示；Month 为 0 表示不使用夏令时。
+type TZI struct {
+	Bias         int32 // UTC = 本地时间 + Bias（分钟）
+	StandardBias int32 // 标准时间的附加偏移，通常为 0
+	DaylightBias int32 // 夏令时的附加偏移，通常为 -60
+	StandardDate SystemTime
+	DaylightDate SystemTime
+}
+
+// tziSize 是 REG_TZI_FORMAT 的字节数
+const tziSize = 44
+
+// ParseTZI 解析注册表 TZI 值或 "Dynamic DST" 子项中按年份记录的值
+func ParseTZI(b []byte) (TZI, error) {
+	if len(b) != tziSize {
+		return TZI{}, fmt.Errorf("tz: TZI value is %d bytes, want %d", len(b), tziSize)
+	}
+	var z TZI
+	_, err := binary.Decode(b, binary.LittleEndian, &z)
+	return z, err
+}
+
+// MarshalBinary 返回 REG_TZI_FORMAT 格式的 TZI 值
+func (z TZI) MarshalBinary() ([]byte, error) {
+	return binary.Append(make([]byte, 0, tziSize), binary.LittleEndian, z)
+}
+
+// StandardOffset 返回标准时间相对 UTC 的偏移（东正西负）
+func (z TZI) StandardOffset() time.Duration {
+	return -time.Duration(z.Bias+z.StandardBias) * time.Minute
+}
+
+// DaylightOffset 返回夏令时相对 UTC 的偏移（东正西负）
+func (z TZI) DaylightOffset() time.Duration {
+	return -time.Duration(z.Bias+z.DaylightBias) * time.Minute
+}
+
+// HasDaylight 报告规则是否包含夏令时
+func (z TZI) HasDaylight() bool {
+	return z.StandardDate.Month != 0 && z.DaylightDate.Month != 0 && z.StandardBias != z.DaylightBias
+}
+
+func (z TZI) validate() error {
+	if !z.HasDaylight() {
+		return nil
+	}
+	for _, d := range []SystemTime{z.StandardDate, z.DaylightDate} {
+		if d.Month > 12 || d.DayOfWeek > 6 || d.Hour > 23 || d.Minute > 59 || d.Second > 59 || d.Milliseconds > 999 {
+			return ErrInvalidRule
+		}
+		if d.Year == 0 && (d.Day < 1 || d.Day > 5) || d.Year != 0 && (d.Day < 1 || d.Day > 31) {
+			return ErrInvalidRule
+		}
+	}
+	return nil
+}
+
+// Location 返回按规则 z 计算本地时间的 time.Location，所有年份使用同一组规则。
+// 规则使用绝对日期（Year 不为 0）时只在该年份切换夏令时。
+func (z TZI) Location(name string) (*time.Location, error) {
+	if z.HasDaylight() && z.DaylightDate.Year != 0 {
+		return YearlyLocation(name, int(z.DaylightDate.Year), []TZI{z})
+	}
+	return YearlyLocation(name, 0, []TZI{z})
+}
+
+// YearlyLocation 返回按年份使用不同规则的 time.Location，rules[i] 适用于 firstYear+i 年，
+// 对应注册表 "Dynamic DST" 子项中 FirstEntry 到 LastEntry 的值。
+// 早于 firstYear 的时间使用 rules[0] 的标准时间，晚于最后一年的时间重复使用最后一组规则。
+//
+//	loc, err := tz.YearlyLocation("Russian Standard Time", 2010, rules)
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Println(time.Date(2012, 1, 1, 0, 0, 0, 0, time.UTC).In(loc))
+func YearlyLocation(name string, firstYear int, rules []TZI) (*time.Location, error) {
+	if len(rules) == 0 {
+		return nil, ErrInvalidRule
+	}
+	for _, z := range rules {
+		if err := z.validate(); err != nil {
+			return nil, err
+		}
+	}
+	b := newTZifBuilder(rules[0])
+	// 只有一组周期性规则时不需要显式的切换时刻，全部由 TZif 尾部的 POSIX TZ 字符串描述
+	if len(rules) > 1 || rules[0].HasDaylight() && rules[0].DaylightDate.Year != 0 {
+		for i, z := range rules {
+			b.year(firstYear+i, z)
+		}
+	}
+	footer, _ := posixTZ(rules[len(rules)-1])
+	return time.LoadLocationFromTZData(name, b.build(footer))
+}
+
+// zoneType 是 TZif 中的本地时间类型
+type zoneType struct {
+	offset int32 // 相对 UTC 的秒数，东正西负
+	isDST  bool
+}
+
+type transition struct {
+	when int64 // Unix 秒
+	typ  zoneType
+}
+
+type tzifBuilder struct {
+	types []zoneType
+	trans []transition
+	cur   zoneType
+}
+
+func newTZifBuilder(first TZI) *tzifBuilder {
+	std := zoneType{offset: int32(first.StandardOffset() / time.Second)}
+	return &tzifBuilder{types: []zoneType{std}, cur: std}
+}
+
+func (b *tzifBuilder) add(when int64, typ zoneType) {
+	if typ == b.cur {
+		return
+	}
+	if !slices.Contains(b.types, typ) {
+		b.types = append(b.types, typ)
+	}
+	b.trans = append(b.trans, transition{when, typ})
+	b.cur = typ
+}
+
+// year 添加 year 年按规则 z 发生的切换。年初的状态取决于夏令时是否跨年（南半球），
+// 与上一年年末的状态不同时（如 Bias 改变）在 1 月 1 日切换。
+func (b *tzifBuilder) year(year int, z TZI) {
+	std := zoneType{offset: int32(z.StandardOffset() / time.Second)}
+	if !z.HasDaylight() {
+		b.add(localToUnix(time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC), b.cur.offset), std)
+		return
+	}
+	dst := zoneType{offset: int32(z.DaylightOffset() / time.Second), isDST: true}
+	dstStart := ruleDate(year, z.DaylightDate)
+	stdStart := ruleDate(year, z.StandardDate)
+	initial := std
+	if dstStart.After(stdStart) {
+		initial = dst
+	}
+	b.add(localToUnix(time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC), b.cur.offset), initial)
+	// 切换时刻以切换前的本地时间表示
+	if initial == std {
+		b.add(localToUnix(dstStart, std.offset), dst)
+		b.add(localToUnix(stdStart, dst.offset), std)
+	} else {
+		b.add(localToUnix(stdStart, dst.offset), std)
+		b.add(localToUnix(dstStart, std.offset), dst)
+	}
+}
+
+// localToUnix 将以 UTC 表示的本地墙上时间转换为 Unix 秒
+func localToUnix(wall time.Time, offset int32) int64 {
+	return wall.Unix() - int64(offset)
+}
+
+// ruleDate 返回规则 d 在 year 年的切换时刻（本地墙上时间，以 UTC 表示）。
+// 毫秒向上取整到秒，使 23:59:59.999 成为次日 0 点。
+func ruleDate(year int, d SystemTime) time.Time {
+	month := time.Month(d.Month)
+	day := int(d.Day)
+	if d.Year == 0 {
+		first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+		day = 1 + (int(d.DayOfWeek)-int(first.Weekday())+7)%7 + (day-1)*7
+		// 第 5 个星期表示最后一个
+		if last := first.AddDate(0, 1, -1).Day(); day > last {
+			day -= 7
+		}
+	}
+	t := time.Date(year, month, day, int(d.Hour), int(d.Minute), int(d.Second), 0, time.UTC)
+	if d.Milliseconds > 0 {
+		t = t.Add(time.Second)
+	}
+	return t
+}
+
+// posixTZ 返回规则 z 的 POSIX TZ 字符串，如 "<-08>8<-07>,M3.2.0/2,M11.1.0/2"。
+// 使用绝对日期的规则无法表示为周期性规则，返回 false。
+func posixTZ(z TZI) (string, bool) {
+	std := int32(z.StandardOffset() / time.Second)
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "<%s>%s", abbrev(std), posixOffset(-std))
+	if !z.HasDaylight() {
+		return sb.String(), true
+	}
+	if z.StandardDate.Year != 0 || z.DaylightDate.Year != 0 {
+		return "", false
+	}
+	dst := int32(z.DaylightOffset() / time.Second)
+	fmt.Fprintf(&sb, "<%s>%s,%s,%s", abbrev(dst), posixOffset(-dst), posixRule(z.DaylightDate), posixRule(z.StandardDate))
+	return sb.String(), true
+}
+
+// posixRule 返回 "Mm.w.d/time" 格式的规则，星期的编号与 Windows 相同（5 为最后一个，0 为星期日）
+func posixRule(d SystemTime) string {
+	secs := int32(d.Hour)*3600 + int32(d.Minute)*60 + int32(d.Second)
+	if d.Milliseconds > 0 {
+		secs++
+	}
+	return fmt.Sprintf("M%d.%d.%d/%s", d.Month, d.Day, d.DayOfWeek, posixOffset(secs))
+}
+
+// posixOffset 将秒数格式化为 [-]h[:mm[:ss]]
+func posixOffset(secs int32) string {
+	sign := ""
+	if secs < 0 {
+		sign, secs = "-", -secs
+	}
+	h, m, s := secs/3600, secs/60%60, secs%60
+	switch {
+	case s != 0:
+		return fmt.Sprintf("%s%d:%02d:%02d", sign, h, m, s)
+	case m != 0:
+		return fmt.Sprintf("%s%d:%02d", sign, h, m)
+	}
+	return fmt.Sprintf("%s%d", sign, h)
+}
+
+// abbrev 返回 tzdata 风格的数字缩写，如 "+08"、"+0530"、"-03"
+func abbrev(offset int32) string {
+	sign := "+"
+	if offset < 0 {
+		sign, offset = "-", -offset
+	}
+	h, m := offset/3600, offset/60%60
+	if m != 0 {
+		return fmt.Sprintf("%s%02d%02d", sign, h, m)
+	}
+	return fmt.Sprintf("%s%02d", sign, h)
+}
+
+// build 生成只包含 64 位数据的 TZif 第 2 版数据，footer 为空时最后一次切换之后保持其时间类型
+func (b *tzifBuilder) build(footer string) []byte {
+	var chars []byte
+	index := make(map[string]byte)
+	for _, t := range b.types {
+		name := abbrev(t.offset)
+		if _, ok := index[name]; !ok {
+			index[name] = byte(len(chars))
+			chars = append(append(chars, name...), 0)
+		}
+	}
+	header := func(data []byte, timecnt, typecnt, charcnt int) []byte {
+		data = append(data, "TZif2"...)
+		data = append(data, make([]byte, 15)...)
+		// isutcnt、isstdcnt、leapcnt、timecnt、typecnt、charcnt
+		for _, n := range []int{0, 0, 0, timecnt, typecnt, charcnt} {
+			data = binary.BigEndian.AppendUint32(data, uint32(n))
+		}
+		return data
+	}
+	// 第 1 版的 32 位数据块为空，读取方直接使用第 2 版数据
+	data := header(nil, 0, 0, 0)
+	data = header(data, len(b.trans), len(b.types), len(chars))
+	for _, t := range b.trans {
+		data = binary.BigEndian.AppendUint64(data, uint64(t.when))
+	}
+	for _, t := range b.trans {
+		data = append(data, byte(slices.Index(b.types, t.typ)))
+	}
+	for _, t := range b.types {
+		isDST := byte(0)
+		if t.isDST {
+			isDST = 1
+		}
+		data = binary.BigEndian.AppendUint32(data, uint32(t.offset))
+		data = append(data, isDST, index[abbrev(t.offset)])
+	}
+	data = append(data, chars...)
+	return append(data, "\n"+footer+"\n"...)
+}
@@ -0,0 +1,59 @@
+package tz
+
+import "time"
+
+const (
+	filetimeEpoch  = 11644473600            // FILETIME 纪元 1601-01-01 UTC 与 Unix 纪元之间的秒数
+	filetimeMaxSec = (1<<64-1)/10000000 - 1 // FILETIME 能表示的最大整秒数（保守值）
+)
+
+// FiletimeToTime 将 FILETIME（自 1601-01-01 UTC 起以 100 纳秒为单位的计数）转换为 UTC 时间，
+// 覆盖 FILETIME 的完整范围，不受 time.Duration 约 292 年上限的影响
+func FiletimeToTime(ft uint64) time.Time {
+	return time.Unix(int64(ft/1e7)-filetimeEpoch, int64(ft%1e7)*100).UTC()
+}
+
+// TimeToFiletime 将时间转换为 FILETIME，不足 100 纳秒的部分被截断。
+// 早于 1601-01-01 UTC 或超出 FILETIME 范围的时间返回 false。
+func TimeToFiletime(t time.Time) (uint64, bool) {
+	sec := t.Unix() + filetimeEpoch
+	if sec < 0 || sec > filetimeMaxSec {
+		return 0, false
+	}
+	return uint64(sec)*1e7 + uint64(t.Nanosecond()/100), true
+}
+
+// SystemTime 与 SYSTEMTIME 的布局相同，可以与 windows.Systemtime 直接转换：
+//
+//	st := tz.SystemTime(winSystemtime)
+type SystemTime struct {
+	Year         uint16
+	Month        uint16 // 1 为一月
+	DayOfWeek    uint16 // 0 为星期日
+	Day          uint16
+	Hour         uint16
+	Minute       uint16
+	Second       uint16
+	Milliseconds uint16
+}
+
+// SystemTimeOf 返回 t 在其时区中的 SystemTime，毫秒以下的部分被截断
+func SystemTimeOf(t time.Time) SystemTime {
+	return SystemTime{
+		Year:         uint16(t.Year()),
+		Month:        uint16(t.Month()),
+		DayOfWeek:    uint16(t.Weekday()),
+		Day:          uint16(t.Day()),
+		Hour:         uint16(t.Hour()),
+		Minute:       uint16(t.Minute()),
+		Second:       uint16(t.Second()),
+		Milliseconds: uint16(t.Nanosecond() / 1e6),
+	}
+}
+
+// Time 将 SystemTime 解释为时区 loc 中的时间，忽略 DayOfWeek。
+// GetSystemTime 返回的 SYSTEMTIME 使用 time.UTC，GetLocalTime 返回的使用 time.Local。
+func (st SystemTime) Time(loc *time.Location) time.Time {
+	return time.Date(int(st.Year), time.Month(st.Month), int(st.Day),
+		int(st.Hour), int(st.Minute), int(st.Second), int(st.Milliseconds)*1e6, loc)
+}
@@ -0,0 +1,111 @@
+// Package tz 在 Windows 时区与 IANA 时区之间转换，并根据 Windows 时区规则 (TZI) 构造 time.Location。
+//
+// 映射表来自 CLDR windowsZones，与 TZI 规则的解析一样不依赖 Windows API，可以在任何平台上使用，
+// 例如解码从 Windows 主机收集的时区设置。查询本机时区的函数见 Current 和 Local（仅 Windows）。
+package tz
+
+import (
+	"bufio"
+	_ "embed"
+	"strings"
+	"sync"
+)
+
+// World 是 CLDR 中表示默认映射的地区代码
+const World = "001"
+
+//go:embed windowszones.txt
+var table string
+
+type zoneKey struct {
+	windows   string
+	territory string
+}
+
+type mapping struct {
+	iana    map[zoneKey][]string
+	windows map[string]string // IANA 名称到 Windows 时区键名
+}
+
+var load = sync.OnceValue(func() *mapping {
+	m := &mapping{iana: make(map[zoneKey][]string), windows: make(map[string]string)}
+	s := bufio.NewScanner(strings.NewReader(table))
+	for s.Scan() {
+		line := s.Text()
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		if len(fields) != 3 {
+			panic("tz: malformed table line: " + line)
+		}
+		names := strings.Fields(fields[2])
+		m.iana[zoneKey{fields[0], fields[1]}] = names
+		for _, name := range names {
+			// 同一 IANA 名称只属于一个 Windows 时区，001 行先于地区行出现
+			if _, ok := m.windows[name]; !ok {
+				m.windows[name] = fields[0]
+			}
+		}
+	}
+	return m
+})
+
+// aliases 将 IANA 的当前名称映射到 CLDR 使用的规范名称
+var aliases = map[string]string{
+	"UTC":                            "Etc/UTC",
+	"Etc/UCT":                        "Etc/UTC",
+	"Etc/Universal":                  "Etc/UTC",
+	"Etc/Zulu":                       "Etc/UTC",
+	"GMT":                            "Etc/GMT",
+	"Africa/Asmara":                  "Africa/Asmera",
+	"America/Argentina/Buenos_Aires": "America/Buenos_Aires",
+	"America/Argentina/Catamarca":    "America/Catamarca",
+	"America/Argentina/Cordoba":      "America/Cordoba",
+	"America/Argentina/Jujuy":        "America/Jujuy",
+	"America/Argentina/Mendoza":      "America/Mendoza",
+	"America/Atikokan":               "America/Coral_Harbour",
+	"America/Indiana/Indianapolis":   "America/Indianapolis",
+	"America/Kentucky/Louisville":    "America/Louisville",
+	"America/Nuuk":                   "America/Godthab",
+	"Asia/Ho_Chi_Minh":               "Asia/Saigon",
+	"Asia/Kathmandu":                 "Asia/Katmandu",
+	"Asia/Kolkata":                   "Asia/Calcutta",
+	"Asia/Yangon":                    "Asia/Rangoon",
+	"Atlantic/Faroe":                 "Atlantic/Faeroe",
+	"Europe/Kyiv":                    "Europe/Kiev",
+	"Pacific/Chuuk":                  "Pacific/Truk",
+	"Pacific/Kanton":                 "Pacific/Enderbury",
+	"Pacific/Pohnpei":                "Pacific/Ponape",
+}
+
+// IANA 返回 Windows 时区 windowsKey（如 "China Standard Time"）在地区 territory（ISO 3166 代码，如 "HK"）
+// 对应的 IANA 时区名称。territory 为空或表中没有该地区时返回默认映射 (World)。
+//
+//	name, _ := tz.IANA("W. Europe Standard Time", "CH") // "Europe/Zurich"
+func IANA(windowsKey, territory string) (string, bool) {
+	names := IANANames(windowsKey, territory)
+	if len(names) == 0 {
+		return "", false
+	}
+	return names[0], true
+}
+
+// IANANames 返回 Windows 时区在地区 territory 对应的所有 IANA 时区名称，第一个是该地区的首选名称
+func IANANames(windowsKey, territory string) []string {
+	m := load()
+	if names, ok := m.iana[zoneKey{windowsKey, strings.ToUpper(territory)}]; ok {
+		return names
+	}
+	return m.iana[zoneKey{windowsKey, World}]
+}
+
+// Windows 返回 IANA 时区对应的 Windows 时区键名，接受 CLDR 规范名称和 IANA 的当前名称（如 "Asia/Kolkata"）
+func Windows(iana string) (string, bool) {
+	m := load()
+	if key, ok := m.windows[iana]; ok {
+		return key, true
+	}
+	key, ok := m.windows[aliases[iana]]
+	return key, ok
+}
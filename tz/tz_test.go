@@ -0,0 +1,337 @@
+package tz
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func TestIANA(t *testing.T) {
+	tests := []struct {
+		key, territory string
+		want           string
+		ok             bool
+	}{
+		{"China Standard Time", "", "Asia/Shanghai", true},
+		{"China Standard Time", "HK", "Asia/Hong_Kong", true},
+		{"China Standard Time", "hk", "Asia/Hong_Kong", true},
+		{"China Standard Time", "DE", "Asia/Shanghai", true}, // 没有该地区的行回退到 001
+		{"W. Europe Standard Time", "CH", "Europe/Zurich", true},
+		{"Pacific Standard Time", "CA", "America/Vancouver", true},
+		{"UTC", World, "Etc/UTC", true},
+		{"Dateline Standard Time", "", "Etc/GMT+12", true},
+		{"china standard time", "", "", false},
+		{"No Such Standard Time", "", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key+"/"+tt.territory, func(t *testing.T) {
+			got, ok := IANA(tt.key, tt.territory)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("IANA(%q, %q) = %q, %v; want %q, %v", tt.key, tt.territory, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+	if got := IANANames("Eastern Standard Time", "CA"); len(got) != 2 || got[0] != "America/Toronto" {
+		t.Errorf("IANANames(Eastern Standard Time, CA) = %q", got)
+	}
+}
+
+func TestWindows(t *testing.T) {
+	tests := []struct {
+		iana string
+		want string
+		ok   bool
+	}{
+		{"Asia/Shanghai", "China Standard Time", true},
+		{"Asia/Hong_Kong", "China Standard Time", true},
+		{"Europe/Busingen", "W. Europe Standard Time", true},
+		{"America/Indiana/Knox", "Central Standard Time", true},
+		{"Asia/Calcutta", "India Standard Time", true},
+		{"Asia/Kolkata", "India Standard Time", true},
+		{"Europe/Kyiv", "FLE Standard Time", true},
+		{"UTC", "UTC", true},
+		{"Etc/GMT", "UTC", true},
+		{"Mars/Olympus_Mons", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.iana, func(t *testing.T) {
+			got, ok := Windows(tt.iana)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("Windows(%q) = %q, %v; want %q, %v", tt.iana, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+// TestTable 检查映射表中的每个 IANA 名称都能加载，且能映射回所在的 Windows 时区
+func TestTable(t *testing.T) {
+	s := bufio.NewScanner(strings.NewReader(table))
+	keys := make(map[string]bool)
+	for s.Scan() {
+		line := s.Text()
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		if len(fields) != 3 {
+			t.Fatalf("malformed line %q", line)
+		}
+		if fields[1] == World {
+			if keys[fields[0]] {
+				t.Errorf("duplicate %s row for %q", World, fields[0])
+			}
+			keys[fields[0]] = true
+		} else if !keys[fields[0]] {
+			t.Errorf("%q: territory row before %s row", fields[0], World)
+		}
+		for _, name := range strings.Fields(fields[2]) {
+			if _, err := time.LoadLocation(name); err != nil {
+				t.Errorf("%q: LoadLocation(%q): %v", fields[0], name, err)
+			}
+			if key, _ := Windows(name); key != fields[0] {
+				t.Errorf("Windows(%q) = %q, want %q", name, key, fields[0])
+			}
+		}
+	}
+	for alias, name := range aliases {
+		if _, ok := Windows(name); !ok {
+			t.Errorf("alias %q: %q is not in the table", alias, name)
+		}
+	}
+}
+
+// 注册表中的 TZI 规则
+var (
+	pacific = TZI{
+		Bias:         480,
+		DaylightBias: -60,
+		StandardDate: SystemTime{Month: 11, Day: 1, Hour: 2},
+		DaylightDate: SystemTime{Month: 3, Day: 2, Hour: 2},
+	}
+	ausEastern = TZI{
+		Bias:         -600,
+		DaylightBias: -60,
+		StandardDate: SystemTime{Month: 4, Day: 1, Hour: 3},
+		DaylightDate: SystemTime{Month: 10, Day: 1, Hour: 2},
+	}
+	gmt = TZI{
+		DaylightBias: -60,
+		StandardDate: SystemTime{Month: 10, Day: 5, Hour: 2},
+		DaylightDate: SystemTime{Month: 3, Day: 5, Hour: 1},
+	}
+	india  = TZI{Bias: -330}
+	china  = TZI{Bias: -480}
+	newfie = TZI{
+		Bias:         210,
+		DaylightBias: -60,
+		StandardDate: SystemTime{Month: 11, Day: 1, Hour: 2},
+		DaylightDate: SystemTime{Month: 3, Day: 2, Hour: 2},
+	}
+)
+
+// TestLocation 将按 TZI 规则构造的 time.Location 与 tzdata 逐半小时比较
+func TestLocation(t *testing.T) {
+	tests := []struct {
+		rule TZI
+		iana string
+	}{
+		{pacific, "America/Los_Angeles"},
+		{ausEastern, "Australia/Sydney"},
+		{gmt, "Europe/London"},
+		{india, "Asia/Kolkata"},
+		{china, "Asia/Shanghai"},
+		{newfie, "America/St_Johns"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.iana, func(t *testing.T) {
+			want, err := time.LoadLocation(tt.iana)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := tt.rule.Location("test")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.String() != "test" {
+				t.Errorf("name = %q", got)
+			}
+			for _, year := range []int{2024, 2031} {
+				end := time.Date(year+1, 1, 1, 0, 0, 0, 0, time.UTC)
+				for at := time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC); at.Before(end); at = at.Add(30 * time.Minute) {
+					_, gotOff := at.In(got).Zone()
+					_, wantOff := at.In(want).Zone()
+					if gotOff != wantOff {
+						t.Fatalf("offset at %v = %d, want %d", at, gotOff, wantOff)
+					}
+					if at.In(got).IsDST() != at.In(want).IsDST() {
+						t.Fatalf("IsDST at %v = %v", at, at.In(got).IsDST())
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestYearlyLocation(t *testing.T) {
+	moscowDST := TZI{
+		Bias:         -180,
+		DaylightBias: -60,
+		StandardDate: SystemTime{Month: 10, Day: 5, Hour: 3},
+		DaylightDate: SystemTime{Month: 3, Day: 5, Hour: 2},
+	}
+	rules := []TZI{moscowDST, {Bias: -240}, {Bias: -240}, {Bias: -240}, {Bias: -240}, {Bias: -180}}
+	loc, err := YearlyLocation("Russian Standard Time", 2010, rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		at   time.Time
+		want int
+		dst  bool
+	}{
+		{time.Date(2005, 7, 1, 0, 0, 0, 0, time.UTC), 3 * 3600, false}, // 早于第一年使用第一组规则的标准时间
+		{time.Date(2010, 7, 1, 0, 0, 0, 0, time.UTC), 4 * 3600, true},
+		{time.Date(2010, 12, 1, 0, 0, 0, 0, time.UTC), 3 * 3600, false},
+		{time.Date(2011, 6, 1, 0, 0, 0, 0, time.UTC), 4 * 3600, false},
+		{time.Date(2014, 12, 1, 0, 0, 0, 0, time.UTC), 4 * 3600, false},
+		{time.Date(2015, 1, 1, 12, 0, 0, 0, time.UTC), 3 * 3600, false},
+		{time.Date(2040, 7, 1, 0, 0, 0, 0, time.UTC), 3 * 3600, false}, // 晚于最后一年重复最后一组规则
+	}
+	for _, tt := range tests {
+		in := tt.at.In(loc)
+		if _, off := in.Zone(); off != tt.want || in.IsDST() != tt.dst {
+			t.Errorf("%v: offset %d, dst %v; want %d, %v", tt.at, off, in.IsDST(), tt.want, tt.dst)
+		}
+	}
+
+	// 南半球的规则在年初处于夏令时
+	loc, err = YearlyLocation("AUS Eastern Standard Time", 2020, []TZI{ausEastern, ausEastern})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, off := time.Date(2020, 1, 15, 0, 0, 0, 0, time.UTC).In(loc).Zone(); off != 11*3600 {
+		t.Errorf("2020-01-15 offset = %d, want %d", off, 11*3600)
+	}
+
+	if _, err := YearlyLocation("x", 2020, nil); !errors.Is(err, ErrInvalidRule) {
+		t.Errorf("no rules: err = %v", err)
+	}
+	bad := pacific
+	bad.DaylightDate.Day = 6
+	if _, err := bad.Location("x"); !errors.Is(err, ErrInvalidRule) {
+		t.Errorf("week 6: err = %v", err)
+	}
+}
+
+func TestAbsoluteRule(t *testing.T) {
+	z := TZI{
+		Bias:         480,
+		DaylightBias: -60,
+		StandardDate: SystemTime{Year: 2024, Month: 11, Day: 3, Hour: 2},
+		DaylightDate: SystemTime{Year: 2024, Month: 3, Day: 10, Hour: 2},
+	}
+	loc, err := z.Location("absolute")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		at  time.Time
+		dst bool
+	}{
+		{time.Date(2024, 3, 10, 9, 59, 59, 0, time.UTC), false},
+		{time.Date(2024, 3, 10, 10, 0, 0, 0, time.UTC), true},
+		{time.Date(2024, 11, 3, 8, 59, 59, 0, time.UTC), true},
+		{time.Date(2024, 11, 3, 9, 0, 0, 0, time.UTC), false},
+		{time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), false}, // 绝对日期只适用于该年份
+	} {
+		if got := tt.at.In(loc).IsDST(); got != tt.dst {
+			t.Errorf("IsDST at %v = %v, want %v", tt.at, got, tt.dst)
+		}
+	}
+}
+
+func TestPosixTZ(t *testing.T) {
+	endOfDay := gmt
+	endOfDay.StandardDate = SystemTime{Month: 10, Day: 5, DayOfWeek: 6, Hour: 23, Minute: 59, Second: 59, Milliseconds: 999}
+	tests := []struct {
+		rule TZI
+		want string
+	}{
+		{pacific, "<-08>8<-07>7,M3.2.0/2,M11.1.0/2"},
+		{ausEastern, "<+10>-10<+11>-11,M10.1.0/2,M4.1.0/3"},
+		{india, "<+0530>-5:30"},
+		{newfie, "<-0330>3:30<-0230>2:30,M3.2.0/2,M11.1.0/2"},
+		{endOfDay, "<+00>0<+01>-1,M3.5.0/1,M10.5.6/24"},
+	}
+	for _, tt := range tests {
+		if got, ok := posixTZ(tt.rule); got != tt.want || !ok {
+			t.Errorf("posixTZ(%+v) = %q, %v; want %q", tt.rule, got, ok, tt.want)
+		}
+	}
+}
+
+func TestParseTZI(t *testing.T) {
+	b, err := pacific.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 注册表中 Pacific Standard Time 的 TZI 值
+	want := []byte{
+		0xe0, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xc4, 0xff, 0xff, 0xff,
+		0x00, 0x00, 0x0b, 0x00, 0x00, 0x00, 0x01, 0x00, 0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x03, 0x00, 0x00, 0x00, 0x02, 0x00, 0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	}
+	if !bytes.Equal(b, want) {
+		t.Errorf("MarshalBinary = % x\nwant % x", b, want)
+	}
+	got, err := ParseTZI(want)
+	if err != nil || got != pacific {
+		t.Errorf("ParseTZI = %+v, %v", got, err)
+	}
+	if _, err := ParseTZI(want[:40]); err == nil {
+		t.Error("ParseTZI accepted 40 bytes")
+	}
+}
+
+func TestFiletime(t *testing.T) {
+	tests := []struct {
+		ft   uint64
+		want time.Time
+	}{
+		{0, time.Date(1601, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{116444736000000000, time.Unix(0, 0).UTC()},
+		{133500000001234567, time.Date(2024, 1, 17, 21, 20, 0, 123456700, time.UTC)},
+		{1<<64 - 1, time.Date(60056, 5, 28, 5, 36, 10, 955161500, time.UTC)},
+	}
+	for _, tt := range tests {
+		got := FiletimeToTime(tt.ft)
+		if !got.Equal(tt.want) {
+			t.Errorf("FiletimeToTime(%d) = %v, want %v", tt.ft, got, tt.want)
+		}
+		if ft, ok := TimeToFiletime(tt.want); tt.ft != 1<<64-1 && (ft != tt.ft || !ok) {
+			t.Errorf("TimeToFiletime(%v) = %d, %v; want %d", tt.want, ft, ok, tt.ft)
+		}
+	}
+	if _, ok := TimeToFiletime(time.Date(1600, 12, 31, 23, 59, 59, 0, time.UTC)); ok {
+		t.Error("TimeToFiletime accepted a time before 1601")
+	}
+	if _, ok := TimeToFiletime(time.Date(70000, 1, 1, 0, 0, 0, 0, time.UTC)); ok {
+		t.Error("TimeToFiletime accepted a time after the FILETIME range")
+	}
+}
+
+func TestSystemTime(t *testing.T) {
+	at := time.Date(2024, 2, 29, 13, 14, 15, 678901234, time.UTC)
+	st := SystemTimeOf(at)
+	want := SystemTime{2024, 2, 4, 29, 13, 14, 15, 678}
+	if st != want {
+		t.Errorf("SystemTimeOf = %+v, want %+v", st, want)
+	}
+	if got := st.Time(time.UTC); !got.Equal(at.Truncate(time.Millisecond)) {
+		t.Errorf("Time = %v", got)
+	}
+}
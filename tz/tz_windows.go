@@ -0,0 +1,140 @@
+package tz
+
+import (
+	"errors"
+	"iter"
+	"time"
+
+	"github.com/C1ph3rX13/xwindows"
+	"golang.org/x/sys/windows"
+)
+
+// Zone 是 Windows 时区
+type Zone struct {
+	Key          string // 注册表键名，如 "China Standard Time"，与语言无关
+	StandardName string // 以系统语言表示的标准时间名称
+	DaylightName string // 以系统语言表示的夏令时名称
+	Rule         TZI    // 当前年份的规则
+
+	// DynamicDaylightTimeDisabled 表示用户关闭了 "自动调整夏令时"，此时 Rule 不包含夏令时
+	DynamicDaylightTimeDisabled bool
+
+	raw xwindows.DYNAMIC_TIME_ZONE_INFORMATION
+}
+
+func newZone(d *xwindows.DYNAMIC_TIME_ZONE_INFORMATION) Zone {
+	return Zone{
+		Key:          windows.UTF16ToString(d.TimeZoneKeyName[:]),
+		StandardName: windows.UTF16ToString(d.StandardName[:]),
+		DaylightName: windows.UTF16ToString(d.DaylightName[:]),
+		Rule: TZI{
+			Bias:         d.Bias,
+			StandardBias: d.StandardBias,
+			DaylightBias: d.DaylightBias,
+			StandardDate: SystemTime(d.StandardDate),
+			DaylightDate: SystemTime(d.DaylightDate),
+		},
+		DynamicDaylightTimeDisabled: d.DynamicDaylightTimeDisabled != 0,
+		raw:                         *d,
+	}
+}
+
+// Current 返回本机当前的时区设置
+func Current() (Zone, error) {
+	var d xwindows.DYNAMIC_TIME_ZONE_INFORMATION
+	if _, err := xwindows.GetDynamicTimeZoneInformation(&d); err != nil {
+		return Zone{}, err
+	}
+	return newZone(&d), nil
+}
+
+// Zones 返回系统支持的所有时区的迭代器，需要 Windows 8 及以上版本
+//
+//	for z, err := range tz.Zones() {
+//		if err != nil {
+//			return err
+//		}
+//		name, _ := tz.IANA(z.Key, "")
+//		fmt.Println(z.Key, name)
+//	}
+func Zones() iter.Seq2[Zone, error] {
+	return func(yield func(Zone, error) bool) {
+		for i := uint32(0); ; i++ {
+			var d xwindows.DYNAMIC_TIME_ZONE_INFORMATION
+			err := xwindows.EnumDynamicTimeZoneInformation(i, &d)
+			if errors.Is(err, windows.ERROR_NO_MORE_ITEMS) {
+				return
+			}
+			if err != nil {
+				yield(Zone{}, err)
+				return
+			}
+			if !yield(newZone(&d), nil) {
+				return
+			}
+		}
+	}
+}
+
+// firstRuleYear 是 Location 查询按年份规则的起始年份，早于该年份的时间使用该年份的标准时间
+const firstRuleYear = 1970
+
+// Location 返回按 Windows 自身规则计算本地时间的 time.Location，名称为 Key。
+// 每一年的规则通过 GetTimeZoneInformationForYear 查询（注册表 "Dynamic DST" 子项），
+// 与 Windows 转换本地时间的结果一致；DynamicDaylightTimeDisabled 时只使用不含夏令时的 Rule。
+func (z Zone) Location() (*time.Location, error) {
+	if z.DynamicDaylightTimeDisabled {
+		return z.Rule.Location(z.Key)
+	}
+	last := time.Now().Year() + 1
+	rules := make([]TZI, 0, last-firstRuleYear+1)
+	for year := firstRuleYear; year <= last; year++ {
+		var tzi windows.Timezoneinformation
+		if err := xwindows.GetTimeZoneInformationForYear(uint16(year), &z.raw, &tzi); err != nil {
+			return nil, err
+		}
+		rules = append(rules, TZI{
+			Bias:         tzi.Bias,
+			StandardBias: tzi.StandardBias,
+			DaylightBias: tzi.DaylightBias,
+			StandardDate: SystemTime(tzi.StandardDate),
+			DaylightDate: SystemTime(tzi.DaylightDate),
+		})
+	}
+	return YearlyLocation(z.Key, firstRuleYear, rules)
+}
+
+// Territory 返回当前用户区域设置的 ISO 3166 国家/地区代码，用于选择 IANA 时区
+func Territory() (string, error) {
+	l, err := xwindows.UserLocale()
+	if err != nil {
+		return "", err
+	}
+	return l.Info(xwindows.LOCALE_SISO3166CTRYNAME)
+}
+
+// Local 返回本机时区对应的 time.Location。
+// 优先按时区键名和用户所在地区查找 IANA 时区并通过 time.LoadLocation 加载（需要时区数据库，
+// 可以导入 time/tzdata 嵌入），没有对应的 IANA 时区、无法加载或用户关闭了自动调整夏令时时，
+// 回退到 Zone.Location 按 Windows 规则构造。
+//
+//	loc, err := tz.Local()
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Println(loc, time.Now().In(loc))
+func Local() (*time.Location, error) {
+	z, err := Current()
+	if err != nil {
+		return nil, err
+	}
+	if !z.DynamicDaylightTimeDisabled {
+		territory, _ := Territory()
+		if name, ok := IANA(z.Key, territory); ok {
+			if loc, err := time.LoadLocation(name); err == nil {
+				return loc, nil
+			}
+		}
+	}
+	return z.Location()
+}
@@ -0,0 +1,92 @@
+package tz
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/C1ph3rX13/xwindows"
+)
+
+func TestLocal(t *testing.T) {
+	z, err := Current()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if z.Key == "" {
+		t.Fatal("Current() returned an empty key")
+	}
+	loc, err := Local()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// time.Local 在 Windows 上同样由 GetTimeZoneInformation 计算
+	now := time.Now()
+	_, got := now.In(loc).Zone()
+	_, want := now.Local().Zone()
+	if got != want {
+		t.Errorf("%s (%s): offset %d, time.Local %d", z.Key, loc, got, want)
+	}
+	zl, err := z.Location()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, off := now.In(zl).Zone(); off != want {
+		t.Errorf("Zone.Location offset %d, time.Local %d", off, want)
+	}
+}
+
+// TestZones 将按 Windows 规则构造的 time.Location 与 tzdata 在最近一年内比较
+func TestZones(t *testing.T) {
+	stable := map[string]bool{
+		"Pacific Standard Time":     true,
+		"Eastern Standard Time":     true,
+		"GMT Standard Time":         true,
+		"W. Europe Standard Time":   true,
+		"AUS Eastern Standard Time": true,
+		"China Standard Time":       true,
+		"India Standard Time":       true,
+		"Tokyo Standard Time":       true,
+	}
+	n := 0
+	for z, err := range Zones() {
+		if errors.Is(err, xwindows.ErrNotImplemented) {
+			t.Skip(err)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		n++
+		loc, err := z.Location()
+		if err != nil {
+			t.Errorf("%s: %v", z.Key, err)
+			continue
+		}
+		if !stable[z.Key] {
+			continue
+		}
+		name, _ := IANA(z.Key, "")
+		want, err := time.LoadLocation(name)
+		if err != nil {
+			t.Logf("%s: %v", name, err)
+			continue
+		}
+		year := time.Now().Year() - 1
+		end := time.Date(year+1, 1, 1, 0, 0, 0, 0, time.UTC)
+		for at := time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC); at.Before(end); at = at.Add(time.Hour) {
+			_, got := at.In(loc).Zone()
+			_, wantOff := at.In(want).Zone()
+			if got != wantOff {
+				t.Errorf("%s: offset at %v = %d, %s = %d", z.Key, at, got, name, wantOff)
+				break
+			}
+		}
+		delete(stable, z.Key)
+	}
+	if n < 100 {
+		t.Errorf("Zones() returned %d zones", n)
+	}
+	if len(stable) != 0 {
+		t.Errorf("zones not enumerated: %v", stable)
+	}
+}
@@ -0,0 +1,168 @@
+package xwindows
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"golang.org/x/sys/windows"
+)
+
+// waitShard 是每次 WaitForMultipleObjects 等待的句柄数，留出一个位置给取消事件
+const waitShard = MAXIMUM_WAIT_OBJECTS - 1
+
+// Wait 等待任意一个句柄有信号，返回其在 handles 中的索引。
+// ctx 结束时返回 -1 和 ctx.Err()。等待到被遗弃的互斥体时返回其索引和 ErrAbandoned，此时调用方已拥有该互斥体。
+//
+// 不超过 63 个句柄时在调用方的 goroutine 中等待；超过时分组在多个 goroutine 中等待，每组占用一个系统线程，
+// 多个组中的自动重置事件、信号量或互斥体可能同时被获取，但只报告先返回的一个。
+//
+//	i, err := xwindows.Wait(ctx, worker.Handle(), shutdown)
+func Wait(ctx context.Context, handles ...windows.Handle) (int, error) {
+	if len(handles) == 0 {
+		return -1, ErrInvalidParameter
+	}
+	if err := ctx.Err(); err != nil {
+		return -1, err
+	}
+	cancel, release, err := cancelEvent(ctx)
+	if err != nil {
+		return -1, err
+	}
+	defer release()
+	i, err := waitAny(handles, cancel)
+	if i < 0 && err == nil {
+		err = ctx.Err()
+	}
+	return i, err
+}
+
+// WaitAll 等待所有句柄都有信号，ctx 结束时返回 ctx.Err()。
+// 有被遗弃的互斥体时在所有句柄都有信号后返回 ErrAbandoned。
+//
+// 句柄逐个被获取而不是像 WaitForMultipleObjects(bWaitAll=TRUE) 那样原子地一次获取，
+// 适合等待进程、线程和手动重置事件；需要原子地获取多个互斥体或信号量时直接使用 windows.WaitForMultipleObjects。
+func WaitAll(ctx context.Context, handles ...windows.Handle) error {
+	if len(handles) == 0 {
+		return ErrInvalidParameter
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	cancel, release, err := cancelEvent(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	pending := slices.Clone(handles)
+	abandoned := false
+	for len(pending) > 0 {
+		i, err := waitAny(pending, cancel)
+		switch {
+		case i < 0 && err == nil:
+			return ctx.Err()
+		case errors.Is(err, ErrAbandoned):
+			abandoned = true
+		case err != nil:
+			return err
+		}
+		pending = slices.Delete(pending, i, i+1)
+	}
+	if abandoned {
+		return ErrAbandoned
+	}
+	return nil
+}
+
+// cancelEvent 创建在 ctx 结束时设置的手动重置事件。
+// release 停止监视 ctx 并关闭事件，等待已开始的 SetEvent 返回，避免设置已关闭（可能被复用）的句柄。
+func cancelEvent(ctx context.Context) (event windows.Handle, release func(), err error) {
+	event, err = CreateEventW(nil, true, false, "")
+	if err != nil {
+		return 0, nil, err
+	}
+	fired := make(chan struct{})
+	stop := context.AfterFunc(ctx, func() {
+		SetEvent(event)
+		close(fired)
+	})
+	return event, func() {
+		if !stop() {
+			<-fired
+		}
+		CloseHandle(event)
+	}, nil
+}
+
+// waitAny 等待 handles 中任意一个或 cancel 有信号。cancel 有信号时返回 -1 和 nil。
+func waitAny(handles []windows.Handle, cancel windows.Handle) (int, error) {
+	if len(handles) <= waitShard {
+		return waitShardAny(handles, cancel)
+	}
+
+	// 分组等待：第一个返回的组设置 stop，使其余的组返回
+	stop, err := CreateEventW(nil, true, false, "")
+	if err != nil {
+		return -1, err
+	}
+	defer CloseHandle(stop)
+
+	type result struct {
+		index int
+		err   error
+	}
+	shards := (len(handles) + waitShard - 1) / waitShard
+	results := make(chan result, shards+1)
+	for s := range shards {
+		base := s * waitShard
+		shard := handles[base:min(base+waitShard, len(handles))]
+		go func() {
+			i, err := waitShardAny(shard, stop)
+			if i >= 0 {
+				i += base
+			}
+			results <- result{i, err}
+		}()
+	}
+	// cancel 有信号时转发给所有组
+	go func() {
+		i, err := waitShardAny([]windows.Handle{cancel}, stop)
+		if i == 0 {
+			i = -1
+		}
+		results <- result{i, err}
+	}()
+
+	first := result{index: -1}
+	for n := range shards + 1 {
+		r := <-results
+		if n == 0 {
+			first = r
+			SetEvent(stop)
+		} else if first.index < 0 && first.err == nil && (r.index >= 0 || r.err != nil) {
+			// cancel 与句柄同时有信号时优先报告句柄
+			first = r
+		}
+	}
+	return first.index, first.err
+}
+
+// waitShardAny 以 WaitForMultipleObjects 等待不超过 63 个句柄和 cancel
+func waitShardAny(handles []windows.Handle, cancel windows.Handle) (int, error) {
+	hs := append(handles[:len(handles):len(handles)], cancel)
+	event, err := windows.WaitForMultipleObjects(hs, false, windows.INFINITE)
+	if err != nil {
+		return -1, err
+	}
+	n := uint32(len(handles))
+	switch {
+	case event < windows.WAIT_OBJECT_0+n:
+		return int(event - windows.WAIT_OBJECT_0), nil
+	case event == windows.WAIT_OBJECT_0+n:
+		return -1, nil
+	case event >= windows.WAIT_ABANDONED && event < windows.WAIT_ABANDONED+n:
+		return int(event - windows.WAIT_ABANDONED), ErrAbandoned
+	}
+	return -1, ErrAPICallFailed
+}
@@ -0,0 +1,121 @@
+package xwindows
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+
+	"golang.org/x/sys/windows"
+)
+
+func newEvents(t *testing.T, n int) []windows.Handle {
+	t.Helper()
+	events := make([]windows.Handle, n)
+	for i := range events {
+		h, err := CreateEventW(nil, true, false, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		events[i] = h
+		t.Cleanup(func() { CloseHandle(h) })
+	}
+	return events
+}
+
+func TestWait(t *testing.T) {
+	tests := []struct {
+		name   string
+		count  int
+		signal int
+	}{
+		{"single", 1, 0},
+		{"last of one shard", 63, 62},
+		{"first shard", 200, 5},
+		{"middle shard", 200, 100},
+		{"last shard", 200, 199},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := newEvents(t, tt.count)
+			time.AfterFunc(10*time.Millisecond, func() { SetEvent(events[tt.signal]) })
+			i, err := Wait(context.Background(), events...)
+			if i != tt.signal || err != nil {
+				t.Errorf("Wait() = %d, %v, want %d", i, err, tt.signal)
+			}
+		})
+	}
+}
+
+func TestWaitCancel(t *testing.T) {
+	tests := []struct {
+		name  string
+		count int
+		all   bool
+	}{
+		{"any", 3, false},
+		{"any sharded", 100, false},
+		{"all", 3, true},
+		{"all sharded", 100, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := newEvents(t, tt.count)
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			defer cancel()
+			var err error
+			if tt.all {
+				SetEvent(events[0])
+				err = WaitAll(ctx, events...)
+			} else {
+				_, err = Wait(ctx, events...)
+			}
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("error = %v, want %v", err, context.DeadlineExceeded)
+			}
+		})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if i, err := Wait(ctx, newEvents(t, 1)...); i != -1 || !errors.Is(err, context.Canceled) {
+		t.Errorf("Wait(canceled) = %d, %v, want -1, %v", i, err, context.Canceled)
+	}
+}
+
+func TestWaitAll(t *testing.T) {
+	events := newEvents(t, 150)
+	go func() {
+		for _, h := range events {
+			SetEvent(h)
+		}
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := WaitAll(ctx, events...); err != nil {
+		t.Errorf("WaitAll() error = %v", err)
+	}
+}
+
+func TestWaitAbandoned(t *testing.T) {
+	created := make(chan windows.Handle)
+	go func() {
+		// 不调用 UnlockOSThread，goroutine 退出时线程随之退出，互斥体被遗弃
+		runtime.LockOSThread()
+		h, err := windows.CreateMutex(nil, true, nil)
+		if err != nil {
+			t.Error(err)
+		}
+		created <- h
+	}()
+	mutex := <-created
+	defer CloseHandle(mutex)
+
+	events := newEvents(t, 1)
+	i, err := Wait(context.Background(), events[0], mutex)
+	if i != 1 || !errors.Is(err, ErrAbandoned) {
+		t.Errorf("Wait() = %d, %v, want 1, %v", i, err, ErrAbandoned)
+	}
+	windows.ReleaseMutex(mutex)
+}
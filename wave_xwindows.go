@@ -0,0 +1,288 @@
+package xwindows
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"runtime"
+	"sync/atomic"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// WaveFormat 是 PCM 或 IEEE 浮点波形格式，样本按声道交错排列，小端序
+type WaveFormat struct {
+	SampleRate    uint32
+	Channels      uint16
+	BitsPerSample uint16 // PCM 为 8、16、24 或 32，浮点为 32
+	Float         bool
+}
+
+// PCMFormat 返回整数 PCM 格式，8 位样本无符号，其他位宽有符号
+func PCMFormat(sampleRate uint32, channels, bitsPerSample uint16) WaveFormat {
+	return WaveFormat{SampleRate: sampleRate, Channels: channels, BitsPerSample: bitsPerSample}
+}
+
+// FloatFormat 返回 32 位 IEEE 浮点格式，样本范围为 [-1, 1]
+func FloatFormat(sampleRate uint32, channels uint16) WaveFormat {
+	return WaveFormat{SampleRate: sampleRate, Channels: channels, BitsPerSample: 32, Float: true}
+}
+
+// FrameSize 返回一帧（每个声道一个样本）的字节数
+func (f WaveFormat) FrameSize() int {
+	return int(f.Channels) * int(f.BitsPerSample) / 8
+}
+
+func (f WaveFormat) raw() WAVEFORMATEX {
+	tag := uint16(WAVE_FORMAT_PCM)
+	if f.Float {
+		tag = WAVE_FORMAT_IEEE_FLOAT
+	}
+	block := uint16(f.FrameSize())
+	return WAVEFORMATEX{
+		FormatTag:      tag,
+		Channels:       f.Channels,
+		SamplesPerSec:  f.SampleRate,
+		AvgBytesPerSec: f.SampleRate * uint32(block),
+		BlockAlign:     block,
+		BitsPerSample:  f.BitsPerSample,
+	}
+}
+
+// WaveDevice 是波形输出设备
+type WaveDevice struct {
+	ID       uint32
+	Name     string
+	Channels uint16
+	Formats  uint32 // 支持的标准格式 WAVE_FORMAT_1M08 等
+	Support  uint32 // WAVECAPS_*
+}
+
+// WaveOutDevices 返回系统中的波形输出设备，设备标识符可以传给 OpenWaveOut
+func WaveOutDevices() ([]WaveDevice, error) {
+	n := WaveOutGetNumDevs()
+	devices := make([]WaveDevice, 0, n)
+	for id := range n {
+		var caps WAVEOUTCAPSW
+		if err := WaveOutGetDevCapsW(uintptr(id), &caps, uint32(unsafe.Sizeof(caps))); err != nil {
+			return nil, err
+		}
+		devices = append(devices, WaveDevice{
+			ID:       id,
+			Name:     windows.UTF16ToString(caps.Pname[:]),
+			Channels: caps.Channels,
+			Formats:  caps.Formats,
+			Support:  caps.Support,
+		})
+	}
+	return devices, nil
+}
+
+// WavePlayer 是打开的波形输出设备，使用两个缓冲区交替向设备提交数据。
+// Play 同一时间只能由一个 goroutine 调用，Pause、Resume 和音量控制可以在播放期间从其他 goroutine 调用。
+//
+//	p, err := xwindows.OpenWaveOut(xwindows.WAVE_MAPPER, xwindows.PCMFormat(44100, 2, 16), 100*time.Millisecond)
+//	if err != nil {
+//		return err
+//	}
+//	defer p.Close()
+//	f, _ := os.Open("alert.pcm")
+//	defer f.Close()
+//	err = p.Play(ctx, f)
+type WavePlayer struct {
+	handle  windows.Handle // HWAVEOUT
+	event   windows.Handle // CALLBACK_EVENT，缓冲区播放完毕时触发
+	format  WaveFormat
+	headers []WAVEHDR
+	buffers [][]byte
+	queued  []bool
+	pinner  runtime.Pinner
+}
+
+// OpenWaveOut 打开设备 device（WAVE_MAPPER 表示默认设备），每个缓冲区容纳 bufferDuration 的音频。
+// 格式不受支持时返回 MMError(WAVERR_BADFORMAT)。
+func OpenWaveOut(device uint32, format WaveFormat, bufferDuration time.Duration) (*WavePlayer, error) {
+	frame := format.FrameSize()
+	if frame == 0 || format.SampleRate == 0 {
+		return nil, ErrInvalidParameter
+	}
+	frames := int(time.Duration(format.SampleRate) * bufferDuration / time.Second)
+	if frames <= 0 {
+		return nil, ErrInvalidSize
+	}
+	event, err := windows.CreateEvent(nil, 0, 0, nil)
+	if err != nil {
+		return nil, err
+	}
+	p := &WavePlayer{
+		event:   event,
+		format:  format,
+		headers: make([]WAVEHDR, 2),
+		buffers: make([][]byte, 2),
+		queued:  make([]bool, 2),
+	}
+	raw := format.raw()
+	if err := WaveOutOpen(&p.handle, device, &raw, uintptr(event), 0, CALLBACK_EVENT); err != nil {
+		CloseHandle(event)
+		return nil, err
+	}
+	// 驱动程序在排队期间访问缓冲区和 WAVEHDR，固定它们直到 Close
+	p.pinner.Pin(&p.headers[0])
+	for i := range p.headers {
+		p.buffers[i] = make([]byte, frames*frame)
+		h := &p.headers[i]
+		h.Data = pinSlice(&p.pinner, p.buffers[i])
+		h.BufferLength = uint32(len(p.buffers[i]))
+		if err := WaveOutPrepareHeader(p.handle, h, uint32(unsafe.Sizeof(*h))); err != nil {
+			p.Close()
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// Format 返回播放格式
+func (p *WavePlayer) Format() WaveFormat {
+	return p.format
+}
+
+// done 报告缓冲区 i 是否已经播放完毕，WHDR_DONE 由驱动程序在另一个线程中设置
+func (p *WavePlayer) done(i int) bool {
+	return atomic.LoadUint32(&p.headers[i].Flags)&WHDR_DONE != 0
+}
+
+// Play 从 r 读取样本数据并播放，直到 r 结束且所有数据播放完毕。
+// 末尾不足一帧的数据被丢弃。ctx 结束时停止播放并返回 ctx.Err()，此后可以再次调用 Play。
+func (p *WavePlayer) Play(ctx context.Context, r io.Reader) error {
+	frame := p.format.FrameSize()
+	eof := false
+	for {
+		pending := false
+		for i := range p.headers {
+			if p.queued[i] && p.done(i) {
+				p.queued[i] = false
+			}
+			if !p.queued[i] && !eof {
+				n, err := io.ReadFull(r, p.buffers[i])
+				if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+					eof = true
+				} else if err != nil {
+					p.stop()
+					return err
+				}
+				if n -= n % frame; n > 0 {
+					h := &p.headers[i]
+					h.BufferLength = uint32(n)
+					atomic.StoreUint32(&h.Flags, h.Flags&^WHDR_DONE)
+					if err := WaveOutWrite(p.handle, h, uint32(unsafe.Sizeof(*h))); err != nil {
+						p.stop()
+						return err
+					}
+					p.queued[i] = true
+				}
+			}
+			pending = pending || p.queued[i]
+		}
+		if eof && !pending {
+			return nil
+		}
+		if _, err := Wait(ctx, p.event); err != nil {
+			p.stop()
+			return err
+		}
+	}
+}
+
+// stop 停止播放并收回所有排队的缓冲区
+func (p *WavePlayer) stop() {
+	WaveOutReset(p.handle)
+	for i := range p.queued {
+		p.queued[i] = false
+	}
+}
+
+// Pause 暂停播放，Play 继续等待直到 Resume
+func (p *WavePlayer) Pause() error {
+	return WaveOutPause(p.handle)
+}
+
+// Resume 恢复暂停的播放
+func (p *WavePlayer) Resume() error {
+	return WaveOutRestart(p.handle)
+}
+
+// Volume 返回左右声道的音量，范围为 [0, 1]。设备不支持音量控制时返回 MMError(MMSYSERR_NOTSUPPORTED)。
+func (p *WavePlayer) Volume() (left, right float64, err error) {
+	var v uint32
+	if err := WaveOutGetVolume(p.handle, &v); err != nil {
+		return 0, 0, err
+	}
+	return float64(v&0xFFFF) / 0xFFFF, float64(v>>16) / 0xFFFF, nil
+}
+
+// SetVolume 设置左右声道的音量，范围为 [0, 1]，超出范围的值被截断。
+// 不支持独立声道音量 (WAVECAPS_LRVOLUME) 的设备使用 left。
+func (p *WavePlayer) SetVolume(left, right float64) error {
+	level := func(v float64) uint32 {
+		return uint32(math.Round(min(max(v, 0), 1) * 0xFFFF))
+	}
+	return WaveOutSetVolume(p.handle, level(left)|level(right)<<16)
+}
+
+// Close 停止播放并关闭设备，重复调用返回 nil
+func (p *WavePlayer) Close() error {
+	if p.handle == 0 {
+		return nil
+	}
+	var errs []error
+	errs = append(errs, WaveOutReset(p.handle))
+	for i := range p.headers {
+		if p.headers[i].Flags&WHDR_PREPARED != 0 {
+			errs = append(errs, WaveOutUnprepareHeader(p.handle, &p.headers[i], uint32(unsafe.Sizeof(p.headers[i]))))
+		}
+	}
+	errs = append(errs, WaveOutClose(p.handle), CloseHandle(p.event))
+	p.pinner.Unpin()
+	p.handle = 0
+	return errors.Join(errs...)
+}
+
+// PlaySoundFile 播放 WAV 文件，async 为 false 时播放完毕后返回。
+// 同一时间只能播放一个 PlaySound 声音，新的声音会停止正在播放的声音。
+func PlaySoundFile(path string, async bool) error {
+	p, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return err
+	}
+	return PlaySoundW(p, 0, playSoundFlags(SND_FILENAME, async))
+}
+
+// PlaySoundResource 播放模块 module 中类型为 "WAVE" 的资源 name，module 为 0 时使用当前可执行文件
+func PlaySoundResource(module windows.Handle, name string, async bool) error {
+	p, err := windows.UTF16PtrFromString(name)
+	if err != nil {
+		return err
+	}
+	if module == 0 {
+		if err := windows.GetModuleHandleEx(windows.GET_MODULE_HANDLE_EX_FLAG_UNCHANGED_REFCOUNT, nil, &module); err != nil {
+			return err
+		}
+	}
+	return PlaySoundW(p, module, playSoundFlags(SND_RESOURCE, async))
+}
+
+// StopSound 停止 PlaySoundFile 或 PlaySoundResource 正在播放的声音
+func StopSound() error {
+	return PlaySoundW(nil, 0, 0)
+}
+
+func playSoundFlags(source uint32, async bool) uint32 {
+	flags := source | SND_NODEFAULT
+	if async {
+		flags |= SND_ASYNC
+	}
+	return flags
+}
@@ -0,0 +1,103 @@
+package xwindows
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestWaveFormatRaw(t *testing.T) {
+	tests := []struct {
+		name   string
+		format WaveFormat
+		want   WAVEFORMATEX
+	}{
+		{"pcm16 stereo", PCMFormat(44100, 2, 16),
+			WAVEFORMATEX{FormatTag: WAVE_FORMAT_PCM, Channels: 2, SamplesPerSec: 44100, AvgBytesPerSec: 176400, BlockAlign: 4, BitsPerSample: 16}},
+		{"pcm8 mono", PCMFormat(8000, 1, 8),
+			WAVEFORMATEX{FormatTag: WAVE_FORMAT_PCM, Channels: 1, SamplesPerSec: 8000, AvgBytesPerSec: 8000, BlockAlign: 1, BitsPerSample: 8}},
+		{"float stereo", FloatFormat(48000, 2),
+			WAVEFORMATEX{FormatTag: WAVE_FORMAT_IEEE_FLOAT, Channels: 2, SamplesPerSec: 48000, AvgBytesPerSec: 384000, BlockAlign: 8, BitsPerSample: 32}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.format.raw(); got != tt.want {
+				t.Errorf("raw() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// openTestWaveOut 打开默认设备，没有音频设备（如 CI 虚拟机）时跳过测试
+func openTestWaveOut(t *testing.T, format WaveFormat) *WavePlayer {
+	t.Helper()
+	devices, err := WaveOutDevices()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(devices) == 0 {
+		t.Skip("no wave output device")
+	}
+	p, err := OpenWaveOut(WAVE_MAPPER, format, 20*time.Millisecond)
+	var mmErr MMError
+	if errors.As(err, &mmErr) && (mmErr == MMSYSERR_NODRIVER || mmErr == MMSYSERR_BADDEVICEID || mmErr == MMSYSERR_ALLOCATED) {
+		t.Skipf("wave output unavailable: %v", err)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := p.Close(); err != nil {
+			t.Error(err)
+		}
+	})
+	return p
+}
+
+func TestWavePlayer(t *testing.T) {
+	format := FloatFormat(48000, 1)
+	p := openTestWaveOut(t, format)
+
+	// 100 毫秒几乎听不到的正弦波，末尾附加半帧数据
+	var tone bytes.Buffer
+	for i := range 4800 {
+		binary.Write(&tone, binary.LittleEndian, float32(0.001*math.Sin(2*math.Pi*440*float64(i)/48000)))
+	}
+	tone.Write([]byte{0, 0})
+	start := time.Now()
+	if err := p.Play(context.Background(), &tone); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Errorf("Play() returned after %v, want about 100ms", elapsed)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	silence := bytes.NewReader(make([]byte, 48000*4))
+	if err := p.Play(ctx, silence); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Play() with deadline = %v, want DeadlineExceeded", err)
+	}
+}
+
+func TestWavePlayerVolume(t *testing.T) {
+	p := openTestWaveOut(t, PCMFormat(44100, 2, 16))
+	left, right, err := p.Volume()
+	if errors.Is(err, MMError(MMSYSERR_NOTSUPPORTED)) {
+		t.Skip("device has no volume control")
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.SetVolume(left, right)
+	if err := p.SetVolume(0.5, 2); err != nil {
+		t.Fatal(err)
+	}
+	if l, _, err := p.Volume(); err != nil || math.Abs(l-0.5) > 0.01 {
+		t.Errorf("Volume() left = %v, %v; want 0.5", l, err)
+	}
+}
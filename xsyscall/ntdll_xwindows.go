@@ -0,0 +1,22 @@
+package xsyscall
+
+import (
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var procNtQueryInformationProcess = modntdll.NewProc("NtQueryInformationProcess")
+
+// NtQueryInformationProcess 检索有关指定进程的信息，value 是函数返回的 NTSTATUS，失败时 err 为 windows.NTStatus。
+//
+// Deprecated: 使用 xwindows.QueryProcessInformation 或 xwindows.QueryInformationProcessBuffer。
+func NtQueryInformationProcess(processHandle windows.Handle, processInformationClass int32, processInformation *byte, processInformationLength uint32, returnLength *uint32) (value uintptr, err error) {
+	r0, _, _ := syscall.SyscallN(procNtQueryInformationProcess.Addr(), uintptr(processHandle), uintptr(processInformationClass), uintptr(unsafe.Pointer(processInformation)), uintptr(processInformationLength), uintptr(unsafe.Pointer(returnLength)))
+	value = uintptr(r0)
+	if value != 0 {
+		err = windows.NTStatus(value)
+	}
+	return
+}
@@ -6,7 +6,6 @@ package xsyscall
 
 // Windows api calls ntdll
 
-//sys NtQueryInformationProcess(processHandle windows.Handle, processInformationClass int32, processInformation *byte, processInformationLength uint32, returnLength *uint32) (value uintptr, err error) = ntdll.NtQueryInformationProcess
 //sys RtlCopyMemory(address *byte, source *byte, length uintptr) (err error) = ntdll.RtlCopyMemory
 //sys RtlCopyBytes(address uintptr, source *byte, length uintptr) (err error) = ntdll.RtlCopyBytes
 //sys NtQueueApcThreadEx(threadHandle windows.Handle, userApcOption uintptr, apcRoutine uintptr) (err error) = ntdll.NtQueueApcThreadEx
@@ -44,7 +44,6 @@ var (
 	procEtwEventWriteFull           = modntdll.NewProc("EtwEventWriteFull")
 	procEtwpCreateEtwThread         = modntdll.NewProc("EtwpCreateEtwThread")
 	procNtAllocateVirtualMemory     = modntdll.NewProc("NtAllocateVirtualMemory")
-	procNtQueryInformationProcess   = modntdll.NewProc("NtQueryInformationProcess")
 	procNtQueueApcThreadEx          = modntdll.NewProc("NtQueueApcThreadEx")
 	procNtWriteVirtualMemory        = modntdll.NewProc("NtWriteVirtualMemory")
 	procRtlCopyBytes                = modntdll.NewProc("RtlCopyBytes")
@@ -91,15 +90,6 @@ func NtAllocateVirtualMemory(processHandle windows.Handle, baseAddress *byte, ze
 	return
 }
 
-func NtQueryInformationProcess(processHandle windows.Handle, processInformationClass int32, processInformation *byte, processInformationLength uint32, returnLength *uint32) (value uintptr, err error) {
-	r0, _, e1 := syscall.Syscall6(procNtQueryInformationProcess.Addr(), 5, uintptr(processHandle), uintptr(processInformationClass), uintptr(unsafe.Pointer(processInformation)), uintptr(processInformationLength), uintptr(unsafe.Pointer(returnLength)), 0)
-	value = uintptr(r0)
-	if value == 0 {
-		err = errnoErr(e1)
-	}
-	return
-}
-
 func NtQueueApcThreadEx(threadHandle windows.Handle, userApcOption uintptr, apcRoutine uintptr) (err error) {
 	r1, _, e1 := syscall.Syscall(procNtQueueApcThreadEx.Addr(), 3, uintptr(threadHandle), userApcOption, uintptr(apcRoutine))
 	if r1 == 0 {
@@ -62,3 +62,323 @@ func RegDeleteTreeA(key windows.Handle, subKey string) (value uintptr, err error
 	}
 	return
 }
+
+/*
+EnumDynamicTimeZoneInformation
+按索引枚举系统支持的时区
+
+DWORD EnumDynamicTimeZoneInformation(
+
+	[in]  const DWORD                    dwIndex,
+	[out] PDYNAMIC_TIME_ZONE_INFORMATION lpTimeZoneInformation
+	);
+
+返回值
+如果函数成功，则返回 ERROR_SUCCESS；索引超出范围时返回 ERROR_NO_MORE_ITEMS。
+需要 Windows 8 及以上版本。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/timezoneapi/nf-timezoneapi-enumdynamictimezoneinformation
+*/
+func EnumDynamicTimeZoneInformation(dwIndex uint32, lpTimeZoneInformation *DYNAMIC_TIME_ZONE_INFORMATION) (err error) {
+	if err = procEnumDynamicTimeZoneInformation.Find(); err != nil {
+		return ErrNotImplemented
+	}
+	r1, _, _ := syscall.SyscallN(
+		procEnumDynamicTimeZoneInformation.Addr(),
+		uintptr(dwIndex),
+		uintptr(unsafe.Pointer(lpTimeZoneInformation)),
+	)
+	if r1 != 0 {
+		err = syscall.Errno(r1)
+	}
+	return
+}
+
+/*
+RegCreateKeyExW
+创建指定的注册表项，如果该项已存在，则打开它
+
+LSTATUS RegCreateKeyExW(
+
+	[in]            HKEY                        hKey,
+	[in]            LPCWSTR                     lpSubKey,
+	                DWORD                       Reserved,
+	[in, optional]  LPWSTR                      lpClass,
+	[in]            DWORD                       dwOptions,
+	[in]            REGSAM                      samDesired,
+	[in, optional]  const LPSECURITY_ATTRIBUTES lpSecurityAttributes,
+	[out]           PHKEY                       phkResult,
+	[out, optional] LPDWORD                     lpdwDisposition
+	);
+
+如果函数成功，则返回值为 ERROR_SUCCESS。
+如果函数失败，则返回值为 Winerror.h 中定义的非零错误代码。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/winreg/nf-winreg-regcreatekeyexw
+*/
+func RegCreateKeyExW(key windows.Handle, subKey *uint16, class *uint16, options uint32, desiredAccess uint32, sa *windows.SecurityAttributes, result *windows.Handle, disposition *uint32) (err error) {
+	r1, _, _ := syscall.SyscallN(
+		procRegCreateKeyExW.Addr(),
+		uintptr(key),
+		uintptr(unsafe.Pointer(subKey)), // 相对于 hKey 的路径，不区分大小写
+		0,                               // Reserved，必须为 0
+		uintptr(unsafe.Pointer(class)),
+		uintptr(options),       // REG_OPTION_*
+		uintptr(desiredAccess), // KEY_*，可以包含 KEY_WOW64_32KEY 或 KEY_WOW64_64KEY
+		uintptr(unsafe.Pointer(sa)),
+		uintptr(unsafe.Pointer(result)),
+		uintptr(unsafe.Pointer(disposition)), // 接收 REG_CREATED_NEW_KEY 或 REG_OPENED_EXISTING_KEY
+	)
+	if r1 != 0 {
+		err = syscall.Errno(r1)
+	}
+	return
+}
+
+/*
+RegSetValueExW
+设置注册表项下指定值的数据和类型
+
+LSTATUS RegSetValueExW(
+
+	[in]           HKEY       hKey,
+	[in, optional] LPCWSTR    lpValueName,
+	               DWORD      Reserved,
+	[in]           DWORD      dwType,
+	[in]           const BYTE *lpData,
+	[in]           DWORD      cbData
+	);
+
+如果函数成功，则返回值为 ERROR_SUCCESS。
+如果函数失败，则返回值为 Winerror.h 中定义的非零错误代码。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/winreg/nf-winreg-regsetvalueexw
+*/
+func RegSetValueExW(key windows.Handle, valueName *uint16, valueType uint32, data *byte, dataLen uint32) (err error) {
+	r1, _, _ := syscall.SyscallN(
+		procRegSetValueExW.Addr(),
+		uintptr(key),                       // 必须以 KEY_SET_VALUE 访问权限打开
+		uintptr(unsafe.Pointer(valueName)), // 为 NULL 或空字符串时设置键的默认值
+		0,
+		uintptr(valueType), // REG_SZ、REG_DWORD 等
+		uintptr(unsafe.Pointer(data)),
+		uintptr(dataLen), // 数据的字节数，字符串类型需要包括结尾的 NULL
+	)
+	if r1 != 0 {
+		err = syscall.Errno(r1)
+	}
+	return
+}
+
+/*
+RegDeleteValueW
+从注册表项中删除指定的值
+
+LSTATUS RegDeleteValueW(
+
+	[in]           HKEY    hKey,
+	[in, optional] LPCWSTR lpValueName
+	);
+
+如果函数成功，则返回值为 ERROR_SUCCESS。
+如果函数失败，则返回值为 Winerror.h 中定义的非零错误代码。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/winreg/nf-winreg-regdeletevaluew
+*/
+func RegDeleteValueW(key windows.Handle, valueName *uint16) (err error) {
+	r1, _, _ := syscall.SyscallN(
+		procRegDeleteValueW.Addr(),
+		uintptr(key), // 必须以 KEY_SET_VALUE 访问权限打开
+		uintptr(unsafe.Pointer(valueName)),
+	)
+	if r1 != 0 {
+		err = syscall.Errno(r1)
+	}
+	return
+}
+
+/*
+RegDeleteKeyExW
+从注册表的指定视图中删除子项及其值，子项不能有子项
+
+LSTATUS RegDeleteKeyExW(
+
+	[in] HKEY    hKey,
+	[in] LPCWSTR lpSubKey,
+	[in] REGSAM  samDesired,
+	     DWORD   Reserved
+	);
+
+如果函数成功，则返回值为 ERROR_SUCCESS。
+如果函数失败，则返回值为 Winerror.h 中定义的非零错误代码。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/winreg/nf-winreg-regdeletekeyexw
+*/
+func RegDeleteKeyExW(key windows.Handle, subKey *uint16, desiredAccess uint32) (err error) {
+	r1, _, _ := syscall.SyscallN(
+		procRegDeleteKeyExW.Addr(),
+		uintptr(key),
+		uintptr(unsafe.Pointer(subKey)),
+		uintptr(desiredAccess), // KEY_WOW64_32KEY 或 KEY_WOW64_64KEY，指定从哪个视图删除
+		0,
+	)
+	if r1 != 0 {
+		err = syscall.Errno(r1)
+	}
+	return
+}
+
+/*
+RegDeleteTreeW
+以递归方式删除指定键的子项和值
+
+LSTATUS RegDeleteTreeW(
+
+	[in]           HKEY    hKey,
+	[in, optional] LPCWSTR lpSubKey
+	);
+
+如果函数成功，则返回值为 ERROR_SUCCESS。
+如果函数失败，则返回值为 Winerror.h 中定义的非零错误代码。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/winreg/nf-winreg-regdeletetreew
+*/
+func RegDeleteTreeW(key windows.Handle, subKey *uint16) (err error) {
+	r1, _, _ := syscall.SyscallN(
+		procRegDeleteTreeW.Addr(),
+		uintptr(key),                    // 必须以 DELETE、KEY_ENUMERATE_SUB_KEYS 和 KEY_QUERY_VALUE 访问权限打开
+		uintptr(unsafe.Pointer(subKey)), // 为 NULL 时删除 hKey 的子项和值，hKey 本身保留
+	)
+	if r1 != 0 {
+		err = syscall.Errno(r1)
+	}
+	return
+}
+
+/*
+RegEnumValueW
+枚举打开的注册表项的值，每次调用检索一个值的名称、类型和数据
+
+LSTATUS RegEnumValueW(
+
+	[in]                HKEY    hKey,
+	[in]                DWORD   dwIndex,
+	[out]               LPWSTR  lpValueName,
+	[in, out]           LPDWORD lpcchValueName,
+	                    LPDWORD lpReserved,
+	[out, optional]     LPDWORD lpType,
+	[out, optional]     LPBYTE  lpData,
+	[in, out, optional] LPDWORD lpcbData
+	);
+
+如果函数成功，则返回值为 ERROR_SUCCESS；没有更多的值时返回 ERROR_NO_MORE_ITEMS；
+缓冲区太小时返回 ERROR_MORE_DATA。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/winreg/nf-winreg-regenumvaluew
+*/
+func RegEnumValueW(key windows.Handle, index uint32, valueName *uint16, valueNameLen *uint32, valueType *uint32, data *byte, dataLen *uint32) (err error) {
+	r1, _, _ := syscall.SyscallN(
+		procRegEnumValueW.Addr(),
+		uintptr(key), // 必须以 KEY_QUERY_VALUE 访问权限打开
+		uintptr(index),
+		uintptr(unsafe.Pointer(valueName)),
+		uintptr(unsafe.Pointer(valueNameLen)), // 缓冲区的字符数，返回名称的字符数（不包括结尾的 NULL）
+		0,
+		uintptr(unsafe.Pointer(valueType)),
+		uintptr(unsafe.Pointer(data)),
+		uintptr(unsafe.Pointer(dataLen)),
+	)
+	if r1 != 0 {
+		err = syscall.Errno(r1)
+	}
+	return
+}
+
+/*
+RegCopyTreeW
+将指定的注册表项及其所有子项和值复制到目标项
+
+LSTATUS RegCopyTreeW(
+
+	[in]           HKEY    hKeySrc,
+	[in, optional] LPCWSTR lpSubKey,
+	[in]           HKEY    hKeyDest
+	);
+
+如果函数成功，则返回值为 ERROR_SUCCESS。
+如果函数失败，则返回值为 Winerror.h 中定义的非零错误代码。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/winreg/nf-winreg-regcopytreew
+*/
+func RegCopyTreeW(keySrc windows.Handle, subKey *uint16, keyDest windows.Handle) (err error) {
+	r1, _, _ := syscall.SyscallN(
+		procRegCopyTreeW.Addr(),
+		uintptr(keySrc),                 // 必须以 KEY_READ 访问权限打开
+		uintptr(unsafe.Pointer(subKey)), // 要复制的子项，为 NULL 时复制 hKeySrc 的子项和值
+		uintptr(keyDest),                // 必须以 KEY_CREATE_SUB_KEY 访问权限打开
+	)
+	if r1 != 0 {
+		err = syscall.Errno(r1)
+	}
+	return
+}
+
+/*
+RegGetKeySecurity
+检索保护打开的注册表项的安全描述符的副本
+
+LSTATUS RegGetKeySecurity(
+
+	[in]            HKEY                 hKey,
+	[in]            SECURITY_INFORMATION SecurityInformation,
+	[out, optional] PSECURITY_DESCRIPTOR pSecurityDescriptor,
+	[in, out]       LPDWORD              lpcbSecurityDescriptor
+	);
+
+如果函数成功，则返回值为 ERROR_SUCCESS；缓冲区太小时返回 ERROR_INSUFFICIENT_BUFFER，
+lpcbSecurityDescriptor 为所需的字节数。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/winreg/nf-winreg-reggetkeysecurity
+*/
+func RegGetKeySecurity(key windows.Handle, securityInformation windows.SECURITY_INFORMATION, sd *byte, sdLen *uint32) (err error) {
+	r1, _, _ := syscall.SyscallN(
+		procRegGetKeySecurity.Addr(),
+		uintptr(key),
+		uintptr(securityInformation), // OWNER_SECURITY_INFORMATION、DACL_SECURITY_INFORMATION 等的组合
+		uintptr(unsafe.Pointer(sd)),  // 接收自相对格式的安全描述符
+		uintptr(unsafe.Pointer(sdLen)),
+	)
+	if r1 != 0 {
+		err = syscall.Errno(r1)
+	}
+	return
+}
+
+/*
+RegSetKeySecurity
+设置打开的注册表项的安全性
+
+LSTATUS RegSetKeySecurity(
+
+	[in] HKEY                 hKey,
+	[in] SECURITY_INFORMATION SecurityInformation,
+	[in] PSECURITY_DESCRIPTOR pSecurityDescriptor
+	);
+
+如果函数成功，则返回值为 ERROR_SUCCESS。
+如果函数失败，则返回值为 Winerror.h 中定义的非零错误代码。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/winreg/nf-winreg-regsetkeysecurity
+*/
+func RegSetKeySecurity(key windows.Handle, securityInformation windows.SECURITY_INFORMATION, sd *windows.SECURITY_DESCRIPTOR) (err error) {
+	r1, _, _ := syscall.SyscallN(
+		procRegSetKeySecurity.Addr(),
+		uintptr(key), // 必须以 WRITE_DAC、WRITE_OWNER 等相应的访问权限打开
+		uintptr(securityInformation),
+		uintptr(unsafe.Pointer(sd)),
+	)
+	if r1 != 0 {
+		err = syscall.Errno(r1)
+	}
+	return
+}
@@ -35,3 +35,33 @@ func EnumerateLoadedModules(hProcess windows.Handle, enumLoadedModulesCallback u
 	}
 	return
 }
+
+/*
+EnumerateLoadedModulesW64
+枚举指定进程的已加载模块，模块名称为 Unicode 字符串，模块基址为 64 位。
+
+BOOL IMAGEAPI EnumerateLoadedModulesW64(
+
+	[in]           HANDLE                          hProcess,
+	[in]           PENUMLOADED_MODULES_CALLBACKW64 EnumLoadedModulesCallback,
+	[in, optional] PVOID                           UserContext
+	);
+
+返回值
+如果函数成功，则返回值为 TRUE。
+如果函数失败，则返回值为 FALSE。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/dbghelp/nf-dbghelp-enumerateloadedmodulesw64
+*/
+func EnumerateLoadedModulesW64(hProcess windows.Handle, enumLoadedModulesCallback uintptr, userContext uintptr) (value uintptr, err error) {
+	r0, _, e1 := syscall.SyscallN(
+		procEnumerateLoadedModulesW64.Addr(),
+		uintptr(hProcess),         // 将枚举其模块的进程句柄
+		enumLoadedModulesCallback, // 应用程序定义的回调函数
+		userContext)               // 可选的用户定义数据。 此值将传递给回调函数
+	value = r0
+	if value == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
@@ -1,6 +1,7 @@
 package xwindows
 
 import (
+	"runtime"
 	"syscall"
 	"unsafe"
 
@@ -753,6 +754,198 @@ func Thread32First(snapshot windows.Handle, threadEntry *ThreadEntry32) (err err
 	return
 }
 
+/*
+Thread32Next
+检索系统内存快照中遇到的任何进程的下一个线程的相关信息
+
+BOOL Thread32Next(
+
+	[in]  HANDLE          hSnapshot,
+	[out] LPTHREADENTRY32 lpte
+	);
+
+如果线程列表的下一个条目已复制到缓冲区，则返回 TRUE ，否则返回 FALSE 。
+如果不存在线程或快照不包含线程信息，则 GetLastError 函数返回ERROR_NO_MORE_FILES错误值。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/tlhelp32/nf-tlhelp32-thread32next
+*/
+func Thread32Next(snapshot windows.Handle, threadEntry *ThreadEntry32) (err error) {
+	r1, _, e1 := syscall.SyscallN(
+		procThread32Next.Addr(),
+		uintptr(snapshot),                    // 快照的句柄，该句柄是从上次调用 CreateToolhelp32Snapshot 函数返回的
+		uintptr(unsafe.Pointer(threadEntry)), // 指向 THREADENTRY32 结构的指针
+	)
+	if r1 == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+/*
+Process32FirstW
+检索系统快照中遇到的第一个进程的相关信息
+
+BOOL Process32FirstW(
+
+	[in]      HANDLE            hSnapshot,
+	[in, out] LPPROCESSENTRY32W lppe
+	);
+
+如果进程列表的第一个条目已复制到缓冲区，则返回 TRUE ，否则返回 FALSE 。
+如果不存在进程或快照不包含进程信息，则 GetLastError 函数返回ERROR_NO_MORE_FILES错误值。
+调用前必须将 PROCESSENTRY32W 的 dwSize 成员设置为结构的大小。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/tlhelp32/nf-tlhelp32-process32firstw
+*/
+func Process32FirstW(snapshot windows.Handle, processEntry *windows.ProcessEntry32) (err error) {
+	r1, _, e1 := syscall.SyscallN(
+		procProcess32FirstW.Addr(),
+		uintptr(snapshot),
+		uintptr(unsafe.Pointer(processEntry)), // 指向 PROCESSENTRY32W 结构的指针
+	)
+	if r1 == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+/*
+Process32NextW
+检索系统快照中记录的下一个进程的相关信息
+
+BOOL Process32NextW(
+
+	[in]  HANDLE            hSnapshot,
+	[out] LPPROCESSENTRY32W lppe
+	);
+
+如果进程列表的下一个条目已复制到缓冲区，则返回 TRUE ，否则返回 FALSE 。
+如果不存在进程或快照不包含进程信息，则 GetLastError 函数返回ERROR_NO_MORE_FILES错误值。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/tlhelp32/nf-tlhelp32-process32nextw
+*/
+func Process32NextW(snapshot windows.Handle, processEntry *windows.ProcessEntry32) (err error) {
+	r1, _, e1 := syscall.SyscallN(
+		procProcess32NextW.Addr(),
+		uintptr(snapshot),
+		uintptr(unsafe.Pointer(processEntry)),
+	)
+	if r1 == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+/*
+Module32FirstW
+检索与进程关联的第一个模块的相关信息
+
+BOOL Module32FirstW(
+
+	[in]      HANDLE           hSnapshot,
+	[in, out] LPMODULEENTRY32W lpme
+	);
+
+如果模块列表的第一个条目已复制到缓冲区，则返回 TRUE ，否则返回 FALSE 。
+如果不存在模块或快照不包含模块信息，则 GetLastError 函数返回ERROR_NO_MORE_FILES错误值。
+调用前必须将 MODULEENTRY32W 的 dwSize 成员设置为结构的大小。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/tlhelp32/nf-tlhelp32-module32firstw
+*/
+func Module32FirstW(snapshot windows.Handle, moduleEntry *windows.ModuleEntry32) (err error) {
+	r1, _, e1 := syscall.SyscallN(
+		procModule32FirstW.Addr(),
+		uintptr(snapshot),
+		uintptr(unsafe.Pointer(moduleEntry)), // 指向 MODULEENTRY32W 结构的指针
+	)
+	if r1 == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+/*
+Module32NextW
+检索与进程或线程关联的下一个模块的相关信息
+
+BOOL Module32NextW(
+
+	[in]  HANDLE           hSnapshot,
+	[out] LPMODULEENTRY32W lpme
+	);
+
+如果模块列表的下一个条目已复制到缓冲区，则返回 TRUE ，否则返回 FALSE 。
+如果不存在更多模块，则 GetLastError 函数返回ERROR_NO_MORE_FILES错误值。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/tlhelp32/nf-tlhelp32-module32nextw
+*/
+func Module32NextW(snapshot windows.Handle, moduleEntry *windows.ModuleEntry32) (err error) {
+	r1, _, e1 := syscall.SyscallN(
+		procModule32NextW.Addr(),
+		uintptr(snapshot),
+		uintptr(unsafe.Pointer(moduleEntry)),
+	)
+	if r1 == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+/*
+Heap32ListFirst
+检索由指定进程分配的第一个堆的相关信息
+
+BOOL Heap32ListFirst(
+
+	[in]      HANDLE       hSnapshot,
+	[in, out] LPHEAPLIST32 lphl
+	);
+
+如果堆列表的第一个条目已复制到缓冲区，则返回 TRUE ，否则返回 FALSE 。
+如果不存在堆列表或快照不包含堆列表信息，则 GetLastError 函数返回ERROR_NO_MORE_FILES错误值。
+调用前必须将 HEAPLIST32 的 dwSize 成员设置为结构的大小。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/tlhelp32/nf-tlhelp32-heap32listfirst
+*/
+func Heap32ListFirst(snapshot windows.Handle, heapList *HeapList32) (err error) {
+	r1, _, e1 := syscall.SyscallN(
+		procHeap32ListFirst.Addr(),
+		uintptr(snapshot),
+		uintptr(unsafe.Pointer(heapList)), // 指向 HEAPLIST32 结构的指针
+	)
+	if r1 == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+/*
+Heap32ListNext
+检索由进程分配的下一个堆的相关信息
+
+BOOL Heap32ListNext(
+
+	[in]  HANDLE       hSnapshot,
+	[out] LPHEAPLIST32 lphl
+	);
+
+如果堆列表的下一个条目已复制到缓冲区，则返回 TRUE ，否则返回 FALSE 。
+如果不存在更多堆列表，则 GetLastError 函数返回ERROR_NO_MORE_FILES错误值。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/tlhelp32/nf-tlhelp32-heap32listnext
+*/
+func Heap32ListNext(snapshot windows.Handle, heapList *HeapList32) (err error) {
+	r1, _, e1 := syscall.SyscallN(
+		procHeap32ListNext.Addr(),
+		uintptr(snapshot),
+		uintptr(unsafe.Pointer(heapList)),
+	)
+	if r1 == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
 /*
 GetTickCount
 检索自系统启动以来经过的毫秒数，最长为 49.7 天
@@ -762,6 +955,8 @@ DWORD GetTickCount();
 返回值是自系统启动以来经过的毫秒数
 
 Link: https://learn.microsoft.com/zh-cn/windows/win32/api/sysinfoapi/nf-sysinfoapi-gettickcount
+
+Deprecated: 计数每 49.7 天回绕，且 err 没有意义（计数为 0 不表示失败），请使用 GetTickCount64。
 */
 func GetTickCount() (value uintptr, err error) {
 	r1, _, e1 := syscall.SyscallN(procGetTickCount.Addr())
@@ -1034,7 +1229,7 @@ BOOL CreateProcessA(
 
 Link: https://learn.microsoft.com/zh-cn/windows/win32/api/processthreadsapi/nf-processthreadsapi-createprocessa
 */
-func CreateProcessA(appName *uint16, commandLine *uint16, procSecurity *windows.SecurityAttributes, threadSecurity *windows.SecurityAttributes, inheritHandles bool, creationFlags uint32, env *uint16, currentDir *uint16, startupInfo *windows.StartupInfo, outProcInfo *windows.ProcessInformation) (err error) {
+func CreateProcessA(appName *byte, commandLine *byte, procSecurity *windows.SecurityAttributes, threadSecurity *windows.SecurityAttributes, inheritHandles bool, creationFlags uint32, env *byte, currentDir *byte, startupInfo *windows.StartupInfo, outProcInfo *windows.ProcessInformation) (err error) {
 	var _p0 uint32
 	if inheritHandles {
 		_p0 = 1
@@ -1422,6 +1617,107 @@ func CreatePipe(readHandle *windows.Handle, writeHandle *windows.Handle, sa *win
 	return
 }
 
+/*
+CreateNamedPipeW
+创建命名管道的实例，并返回用于后续管道操作的句柄
+
+HANDLE CreateNamedPipeW(
+
+	[in]           LPCWSTR               lpName,               // 唯一的管道名称，格式为 \\.\pipe\pipename
+	[in]           DWORD                 dwOpenMode,           // PIPE_ACCESS_* 访问模式与 FILE_FLAG_* 标志
+	[in]           DWORD                 dwPipeMode,           // PIPE_TYPE_*、PIPE_READMODE_* 与 PIPE_*_REMOTE_CLIENTS
+	[in]           DWORD                 nMaxInstances,        // 可为此管道创建的最大实例数
+	[in]           DWORD                 nOutBufferSize,       // 为输出缓冲区保留的字节数
+	[in]           DWORD                 nInBufferSize,        // 为输入缓冲区保留的字节数
+	[in]           DWORD                 nDefaultTimeOut,      // WaitNamedPipe 的默认超时值（以毫秒为单位）
+	[in, optional] LPSECURITY_ATTRIBUTES lpSecurityAttributes  // 安全描述符与句柄继承
+	);
+
+返回值
+如果函数成功，则返回值是命名管道实例的服务器端句柄。
+如果函数失败，则返回值为 INVALID_HANDLE_VALUE。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/winbase/nf-winbase-createnamedpipew
+*/
+func CreateNamedPipeW(name string, openMode uint32, pipeMode uint32, maxInstances uint32, outBufferSize uint32, inBufferSize uint32, defaultTimeOut uint32, sa *windows.SecurityAttributes) (handle windows.Handle, err error) {
+	var _p0 *uint16
+	_p0, err = windows.UTF16PtrFromString(name)
+	if err != nil {
+		return
+	}
+	r0, _, e1 := syscall.SyscallN(
+		procCreateNamedPipeW.Addr(),
+		uintptr(unsafe.Pointer(_p0)),
+		uintptr(openMode),
+		uintptr(pipeMode),
+		uintptr(maxInstances),
+		uintptr(outBufferSize),
+		uintptr(inBufferSize),
+		uintptr(defaultTimeOut),
+		uintptr(unsafe.Pointer(sa)),
+	)
+	handle = windows.Handle(r0)
+	if handle == windows.InvalidHandle {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+/*
+ConnectNamedPipe
+使命名管道服务器进程能够等待客户端进程连接到命名管道的实例
+
+BOOL ConnectNamedPipe(
+
+	[in]                HANDLE       hNamedPipe,   // 命名管道实例的服务器端句柄
+	[in, out, optional] LPOVERLAPPED lpOverlapped  // 以 FILE_FLAG_OVERLAPPED 打开时必须指向 OVERLAPPED 结构
+	);
+
+返回值
+如果函数成功，则返回值为非零值。
+重叠操作挂起时返回零，GetLastError 返回 ERROR_IO_PENDING。
+客户端在调用之前已连接时返回零，GetLastError 返回 ERROR_PIPE_CONNECTED，此时连接同样有效。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/namedpipeapi/nf-namedpipeapi-connectnamedpipe
+*/
+func ConnectNamedPipe(hNamedPipe windows.Handle, lpOverlapped *windows.Overlapped) (err error) {
+	r1, _, e1 := syscall.SyscallN(
+		procConnectNamedPipe.Addr(),
+		uintptr(hNamedPipe),
+		uintptr(unsafe.Pointer(lpOverlapped)),
+	)
+	if r1 == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+/*
+GetHandleInformation
+检索对象句柄的某些属性
+
+BOOL GetHandleInformation(
+
+	[in]  HANDLE  hObject,   // 对象的句柄
+	[out] LPDWORD lpdwFlags  // 接收 HANDLE_FLAG_INHERIT、HANDLE_FLAG_PROTECT_FROM_CLOSE 标志的变量
+	);
+
+如果该函数成功，则返回值为非零值
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/handleapi/nf-handleapi-gethandleinformation
+*/
+func GetHandleInformation(hObject windows.Handle, lpdwFlags *uint32) (err error) {
+	r1, _, e1 := syscall.SyscallN(
+		procGetHandleInformation.Addr(),
+		uintptr(hObject),
+		uintptr(unsafe.Pointer(lpdwFlags)),
+	)
+	if r1 == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
 /*
 VirtualAllocExNuma
 保留、提交或更改指定进程的虚拟地址空间中的内存区域的状态，并为物理内存指定 NUMA 节点
@@ -1466,3 +1762,1505 @@ func VirtualAllocExNuma(
 	}
 	return
 }
+
+/*
+GetThreadDescription
+检索由 SetThreadDescription 为线程分配的说明，需要 Windows 10 1607 及以上版本
+
+HRESULT GetThreadDescription(
+
+	[in]  HANDLE hThread,              // 要检索说明的线程的句柄，必须具有 THREAD_QUERY_LIMITED_INFORMATION 访问权限
+	[out] PWSTR  *ppszThreadDescription // 指向线程说明的指针，调用方必须使用 LocalFree 释放
+	);
+
+返回值
+如果函数成功，则返回值为 HRESULT，表示成功。
+如果函数失败，则返回值为 HRESULT，表示错误。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/processthreadsapi/nf-processthreadsapi-getthreaddescription
+*/
+func GetThreadDescription(hThread windows.Handle, threadDescription **uint16) (err error) {
+	if err = procGetThreadDescription.Find(); err != nil {
+		return ErrNotImplemented
+	}
+	r1, _, _ := syscall.SyscallN(
+		procGetThreadDescription.Addr(),
+		uintptr(hThread),
+		uintptr(unsafe.Pointer(threadDescription)),
+	)
+	err = hresultErr(r1)
+	return
+}
+
+// ThreadDescription 返回线程的说明，并释放 GetThreadDescription 分配的字符串
+func ThreadDescription(hThread windows.Handle) (description string, err error) {
+	var p *uint16
+	if err = GetThreadDescription(hThread, &p); err != nil {
+		return
+	}
+	// 转换结果保存在 defer 中，固定 p 以满足 unsafe 规则（对非 Go 内存不执行任何操作）
+	var pin runtime.Pinner
+	defer pin.Unpin()
+	pin.Pin(p)
+	defer windows.LocalFree(windows.Handle(unsafe.Pointer(p)))
+	return windows.UTF16PtrToString(p), nil
+}
+
+/*
+SetThreadDescription
+为线程分配说明，需要 Windows 10 1607 及以上版本
+
+HRESULT SetThreadDescription(
+
+	[in] HANDLE hThread,              // 要设置说明的线程的句柄，必须具有 THREAD_SET_LIMITED_INFORMATION 访问权限
+	[in] PCWSTR lpThreadDescription   // 指定线程说明的 Unicode 字符串
+	);
+
+返回值
+如果函数成功，则返回值为 HRESULT，表示成功。
+如果函数失败，则返回值为 HRESULT，表示错误。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/processthreadsapi/nf-processthreadsapi-setthreaddescription
+*/
+func SetThreadDescription(hThread windows.Handle, threadDescription string) (err error) {
+	if err = procSetThreadDescription.Find(); err != nil {
+		return ErrNotImplemented
+	}
+	var _p0 *uint16
+	_p0, err = windows.UTF16PtrFromString(threadDescription)
+	if err != nil {
+		return
+	}
+	r1, _, _ := syscall.SyscallN(
+		procSetThreadDescription.Addr(),
+		uintptr(hThread),
+		uintptr(unsafe.Pointer(_p0)),
+	)
+	err = hresultErr(r1)
+	return
+}
+
+/*
+VirtualQueryEx
+检索有关指定进程的虚拟地址空间中的页面范围的信息
+
+SIZE_T VirtualQueryEx(
+
+	[in]           HANDLE                    hProcess,
+	[in, optional] LPCVOID                   lpAddress,
+	[out]          PMEMORY_BASIC_INFORMATION lpBuffer,
+	[in]           SIZE_T                    dwLength
+	);
+
+返回值
+返回值是在信息缓冲区中返回的实际字节数。
+如果函数失败，则返回值为零。
+lpAddress 超出进程可访问的最高地址时，函数失败并返回 ERROR_INVALID_PARAMETER。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/memoryapi/nf-memoryapi-virtualqueryex
+*/
+func VirtualQueryEx(hProcess windows.Handle, lpAddress uintptr, lpBuffer *windows.MemoryBasicInformation, dwLength uintptr) (value uintptr, err error) {
+	r0, _, e1 := syscall.SyscallN(
+		procVirtualQueryEx.Addr(),
+		uintptr(hProcess),                 // 进程的句柄，必须具有 PROCESS_QUERY_INFORMATION 访问权限
+		lpAddress,                         // 要查询的页面区域的基址
+		uintptr(unsafe.Pointer(lpBuffer)), // 接收 MEMORY_BASIC_INFORMATION 结构的指针
+		dwLength,                          // lpBuffer 指向的缓冲区的大小
+	)
+	value = r0
+	if value == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+/*
+CreateFileMappingW
+为指定文件创建或打开命名或未命名的文件映射对象
+
+HANDLE CreateFileMappingW(
+
+	[in]           HANDLE                hFile,
+	[in, optional] LPSECURITY_ATTRIBUTES lpFileMappingAttributes,
+	[in]           DWORD                 flProtect,
+	[in]           DWORD                 dwMaximumSizeHigh,
+	[in]           DWORD                 dwMaximumSizeLow,
+	[in, optional] LPCWSTR               lpName
+	);
+
+返回值
+如果函数成功，则返回值是新创建的文件映射对象的句柄。
+如果对象在函数调用之前存在，则函数返回现有对象的句柄，GetLastError 返回 ERROR_ALREADY_EXISTS。
+如果函数失败，则返回值为 NULL。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/memoryapi/nf-memoryapi-createfilemappingw
+*/
+func CreateFileMappingW(hFile windows.Handle, lpFileMappingAttributes *windows.SecurityAttributes, flProtect uint32, dwMaximumSizeHigh uint32, dwMaximumSizeLow uint32, lpName *uint16) (handle windows.Handle, err error) {
+	r0, _, e1 := syscall.SyscallN(
+		procCreateFileMappingW.Addr(),
+		uintptr(hFile),                                   // 文件句柄，INVALID_HANDLE_VALUE 表示由页面文件支持
+		uintptr(unsafe.Pointer(lpFileMappingAttributes)), // 安全属性，NULL 表示默认安全描述符且句柄不可继承
+		uintptr(flProtect),                               // PAGE_* 页面保护与 SEC_* 节属性
+		uintptr(dwMaximumSizeHigh),                       // 最大大小的高位 DWORD
+		uintptr(dwMaximumSizeLow),                        // 最大大小的低位 DWORD，均为 0 时使用文件的当前大小
+		uintptr(unsafe.Pointer(lpName)),                  // 对象名称，NULL 表示未命名
+	)
+	handle = windows.Handle(r0)
+	// 对象已存在时同样返回有效句柄，调用方通过 ERROR_ALREADY_EXISTS 判断
+	if handle == 0 || e1 == windows.ERROR_ALREADY_EXISTS {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+/*
+OpenFileMappingW
+打开命名文件映射对象
+
+HANDLE OpenFileMappingW(
+
+	[in] DWORD   dwDesiredAccess,
+	[in] BOOL    bInheritHandle,
+	[in] LPCWSTR lpName
+	);
+
+返回值
+如果函数成功，则返回值是指定文件映射对象的打开句柄。
+如果函数失败，则返回值为 NULL。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/memoryapi/nf-memoryapi-openfilemappingw
+*/
+func OpenFileMappingW(dwDesiredAccess uint32, bInheritHandle bool, lpName string) (handle windows.Handle, err error) {
+	var _p0 *uint16
+	_p0, err = windows.UTF16PtrFromString(lpName)
+	if err != nil {
+		return
+	}
+	var _p1 uint32
+	if bInheritHandle {
+		_p1 = 1
+	}
+	r0, _, e1 := syscall.SyscallN(
+		procOpenFileMappingW.Addr(),
+		uintptr(dwDesiredAccess), // FILE_MAP_* 访问权限
+		uintptr(_p1),             // 句柄是否可被子进程继承
+		uintptr(unsafe.Pointer(_p0)),
+	)
+	handle = windows.Handle(r0)
+	if handle == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+/*
+MapViewOfFile
+将文件映射的视图映射到调用进程的地址空间
+
+LPVOID MapViewOfFile(
+
+	[in] HANDLE hFileMappingObject,
+	[in] DWORD  dwDesiredAccess,
+	[in] DWORD  dwFileOffsetHigh,
+	[in] DWORD  dwFileOffsetLow,
+	[in] SIZE_T dwNumberOfBytesToMap
+	);
+
+返回值
+如果函数成功，则返回值为映射视图的起始地址。
+如果函数失败，则返回值为 NULL。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/memoryapi/nf-memoryapi-mapviewoffile
+*/
+func MapViewOfFile(hFileMappingObject windows.Handle, dwDesiredAccess uint32, dwFileOffsetHigh uint32, dwFileOffsetLow uint32, dwNumberOfBytesToMap uintptr) (value uintptr, err error) {
+	r0, _, e1 := syscall.SyscallN(
+		procMapViewOfFile.Addr(),
+		uintptr(hFileMappingObject),
+		uintptr(dwDesiredAccess),  // FILE_MAP_* 访问类型
+		uintptr(dwFileOffsetHigh), // 视图开始位置的文件偏移的高位 DWORD
+		uintptr(dwFileOffsetLow),  // 低位 DWORD，偏移必须是分配粒度的倍数
+		dwNumberOfBytesToMap,      // 要映射的字节数，0 表示映射到文件映射的末尾
+	)
+	value = r0
+	if value == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+/*
+UnmapViewOfFile
+从调用进程的地址空间中取消映射文件的映射视图
+
+BOOL UnmapViewOfFile(
+
+	[in] LPCVOID lpBaseAddress
+	);
+
+如果该函数成功，则返回值为非零值
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/memoryapi/nf-memoryapi-unmapviewoffile
+*/
+func UnmapViewOfFile(lpBaseAddress uintptr) (err error) {
+	r1, _, e1 := syscall.SyscallN(
+		procUnmapViewOfFile.Addr(),
+		lpBaseAddress, // MapViewOfFile 返回的基址
+	)
+	if r1 == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+/*
+FlushViewOfFile
+将文件映射视图中的字节范围写入磁盘
+该函数不会刷新文件元数据，也不会等待数据写入磁盘，需要时应再调用 FlushFileBuffers
+
+BOOL FlushViewOfFile(
+
+	[in] LPCVOID lpBaseAddress,
+	[in] SIZE_T  dwNumberOfBytesToFlush
+	);
+
+如果该函数成功，则返回值为非零值
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/memoryapi/nf-memoryapi-flushviewoffile
+*/
+func FlushViewOfFile(lpBaseAddress uintptr, dwNumberOfBytesToFlush uintptr) (err error) {
+	r1, _, e1 := syscall.SyscallN(
+		procFlushViewOfFile.Addr(),
+		lpBaseAddress,          // 要刷新区域的基址
+		dwNumberOfBytesToFlush, // 要刷新的字节数，0 表示刷新到视图末尾
+	)
+	if r1 == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+/*
+GetLargePageMinimum
+检索大页的最小大小
+
+SIZE_T GetLargePageMinimum();
+
+返回值
+如果处理器支持大页，则返回值是大页的最小大小。
+如果处理器不支持大页，则返回值为零。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/memoryapi/nf-memoryapi-getlargepageminimum
+*/
+func GetLargePageMinimum() (size uintptr) {
+	size, _, _ = syscall.SyscallN(procGetLargePageMinimum.Addr())
+	return
+}
+
+/*
+CreateEventW
+创建或打开命名或未命名的事件对象
+
+HANDLE CreateEventW(
+
+	[in, optional] LPSECURITY_ATTRIBUTES lpEventAttributes,
+	[in]           BOOL                  bManualReset,
+	[in]           BOOL                  bInitialState,
+	[in, optional] LPCWSTR               lpName
+	);
+
+返回值
+如果函数成功，则返回值是事件对象的句柄。
+如果命名事件对象在函数调用之前存在，则函数返回现有对象的句柄，GetLastError 返回 ERROR_ALREADY_EXISTS。
+如果函数失败，则返回值为 NULL。
+lpName 为空字符串时传递 NULL，创建未命名事件
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/synchapi/nf-synchapi-createeventw
+*/
+func CreateEventW(lpEventAttributes *windows.SecurityAttributes, bManualReset bool, bInitialState bool, lpName string) (handle windows.Handle, err error) {
+	var _p0 *uint16
+	if lpName != "" {
+		// 空字符串传递 NULL，创建未命名对象
+		_p0, err = windows.UTF16PtrFromString(lpName)
+		if err != nil {
+			return
+		}
+	}
+	var _p1, _p2 uint32
+	if bManualReset {
+		_p1 = 1
+	}
+	if bInitialState {
+		_p2 = 1
+	}
+	r0, _, e1 := syscall.SyscallN(
+		procCreateEventW.Addr(),
+		uintptr(unsafe.Pointer(lpEventAttributes)), // 安全属性，NULL 表示默认安全描述符且句柄不可继承
+		uintptr(_p1),                 // TRUE 创建手动重置事件，FALSE 创建自动重置事件
+		uintptr(_p2),                 // 事件的初始状态是否为有信号
+		uintptr(unsafe.Pointer(_p0)), // 对象名称，NULL 表示未命名
+	)
+	handle = windows.Handle(r0)
+	// 对象已存在时同样返回有效句柄，调用方通过 ERROR_ALREADY_EXISTS 判断
+	if handle == 0 || e1 == windows.ERROR_ALREADY_EXISTS {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+/*
+OpenEventW
+打开现有的命名事件对象
+
+HANDLE OpenEventW(
+
+	[in] DWORD   dwDesiredAccess,
+	[in] BOOL    bInheritHandle,
+	[in] LPCWSTR lpName
+	);
+
+返回值
+如果函数成功，则返回值是事件对象的句柄。
+如果函数失败，则返回值为 NULL。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/synchapi/nf-synchapi-openeventw
+*/
+func OpenEventW(dwDesiredAccess uint32, bInheritHandle bool, lpName string) (handle windows.Handle, err error) {
+	var _p0 *uint16
+	_p0, err = windows.UTF16PtrFromString(lpName)
+	if err != nil {
+		return
+	}
+	var _p1 uint32
+	if bInheritHandle {
+		_p1 = 1
+	}
+	r0, _, e1 := syscall.SyscallN(
+		procOpenEventW.Addr(),
+		uintptr(dwDesiredAccess), // 对事件对象的访问，如 SYNCHRONIZE | EVENT_MODIFY_STATE
+		uintptr(_p1),             // 句柄是否可被子进程继承
+		uintptr(unsafe.Pointer(_p0)),
+	)
+	handle = windows.Handle(r0)
+	if handle == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+/*
+SetEvent
+将指定的事件对象设置为有信号状态
+
+BOOL SetEvent(
+
+	[in] HANDLE hEvent
+	);
+
+如果该函数成功，则返回值为非零值
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/synchapi/nf-synchapi-setevent
+*/
+func SetEvent(hEvent windows.Handle) (err error) {
+	r1, _, e1 := syscall.SyscallN(
+		procSetEvent.Addr(),
+		uintptr(hEvent),
+	)
+	if r1 == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+/*
+TerminateProcess
+终止指定的进程及其所有线程
+
+BOOL TerminateProcess(
+
+	[in] HANDLE hProcess,
+	[in] UINT   uExitCode
+	);
+
+如果该函数成功，则返回值为非零值。
+如果函数失败，则返回值为零。
+进程已经结束时返回 ERROR_ACCESS_DENIED。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/processthreadsapi/nf-processthreadsapi-terminateprocess
+*/
+func TerminateProcess(hProcess windows.Handle, uExitCode uint32) (err error) {
+	r1, _, e1 := syscall.SyscallN(
+		procTerminateProcess.Addr(),
+		uintptr(hProcess),  // 要终止的进程的句柄，必须具有 PROCESS_TERMINATE 访问权限
+		uintptr(uExitCode), // 进程和线程的退出代码
+	)
+	if r1 == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+/*
+GetExitCodeProcess
+检索指定进程的终止状态
+
+BOOL GetExitCodeProcess(
+
+	[in]  HANDLE  hProcess,
+	[out] LPDWORD lpExitCode
+	);
+
+如果该函数成功，则返回值为非零值。
+进程尚未终止时，lpExitCode 为 STILL_ACTIVE (259)，进程也可能以 259 退出，应使用 WaitForSingleObject 判断进程是否结束。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/processthreadsapi/nf-processthreadsapi-getexitcodeprocess
+*/
+func GetExitCodeProcess(hProcess windows.Handle, lpExitCode *uint32) (err error) {
+	r1, _, e1 := syscall.SyscallN(
+		procGetExitCodeProcess.Addr(),
+		uintptr(hProcess),                   // 进程的句柄，必须具有 PROCESS_QUERY_LIMITED_INFORMATION 访问权限
+		uintptr(unsafe.Pointer(lpExitCode)), // 接收进程终止状态
+	)
+	if r1 == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+/*
+SetInformationJobObject
+设置作业对象的限制和通知
+
+BOOL SetInformationJobObject(
+
+	[in] HANDLE             hJob,
+	[in] JOBOBJECTINFOCLASS JobObjectInformationClass,
+	[in] LPVOID             lpJobObjectInformation,
+	[in] DWORD              cbJobObjectInformationLength
+	);
+
+如果该函数成功，则返回值为非零值。
+如果函数失败，则返回值为零。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/jobapi2/nf-jobapi2-setinformationjobobject
+*/
+func SetInformationJobObject(hJob windows.Handle, jobObjectInformationClass uint32, lpJobObjectInformation unsafe.Pointer, cbJobObjectInformationLength uint32) (err error) {
+	r1, _, e1 := syscall.SyscallN(
+		procSetInformationJobObject.Addr(),
+		uintptr(hJob),                         // 作业的句柄，必须具有 JOB_OBJECT_SET_ATTRIBUTES 访问权限
+		uintptr(jobObjectInformationClass),    // 要设置的信息类，如 JobObjectExtendedLimitInformation
+		uintptr(lpJobObjectInformation),       // 与信息类对应的结构
+		uintptr(cbJobObjectInformationLength), // 结构的大小（以字节为单位）
+	)
+	if r1 == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+/*
+QueryInformationJobObject
+检索作业对象的限制和作业状态信息
+
+BOOL QueryInformationJobObject(
+
+	[in, optional]  HANDLE             hJob,
+	[in]            JOBOBJECTINFOCLASS JobObjectInformationClass,
+	[out]           LPVOID             lpJobObjectInformation,
+	[in]            DWORD              cbJobObjectInformationLength,
+	[out, optional] LPDWORD            lpReturnLength
+	);
+
+如果该函数成功，则返回值为非零值。
+缓冲区不足以容纳进程 ID 列表时返回 ERROR_MORE_DATA，结构的头部仍被填充。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/jobapi2/nf-jobapi2-queryinformationjobobject
+*/
+func QueryInformationJobObject(hJob windows.Handle, jobObjectInformationClass uint32, lpJobObjectInformation unsafe.Pointer, cbJobObjectInformationLength uint32, lpReturnLength *uint32) (err error) {
+	r1, _, e1 := syscall.SyscallN(
+		procQueryInformationJobObject.Addr(),
+		uintptr(hJob),                           // 作业的句柄，必须具有 JOB_OBJECT_QUERY 访问权限；为 NULL 时查询调用进程所属的作业
+		uintptr(jobObjectInformationClass),      // 要查询的信息类
+		uintptr(lpJobObjectInformation),         // 接收信息的缓冲区
+		uintptr(cbJobObjectInformationLength),   // 缓冲区的大小（以字节为单位）
+		uintptr(unsafe.Pointer(lpReturnLength)), // 接收写入的字节数
+	)
+	if r1 == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+/*
+CreateSemaphoreW
+创建或打开命名或未命名的信号量对象
+
+HANDLE CreateSemaphoreW(
+
+	[in, optional] LPSECURITY_ATTRIBUTES lpSemaphoreAttributes,
+	[in]           LONG                  lInitialCount,
+	[in]           LONG                  lMaximumCount,
+	[in, optional] LPCWSTR               lpName
+	);
+
+返回值
+如果函数成功，则返回值是信号量对象的句柄。
+如果命名信号量对象在函数调用之前存在，则函数返回现有对象的句柄，GetLastError 返回 ERROR_ALREADY_EXISTS。
+如果函数失败，则返回值为 NULL。
+lpName 为空字符串时传递 NULL，创建未命名信号量
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/winbase/nf-winbase-createsemaphorew
+*/
+func CreateSemaphoreW(lpSemaphoreAttributes *windows.SecurityAttributes, lInitialCount int32, lMaximumCount int32, lpName string) (handle windows.Handle, err error) {
+	var _p0 *uint16
+	if lpName != "" {
+		// 空字符串传递 NULL，创建未命名对象
+		_p0, err = windows.UTF16PtrFromString(lpName)
+		if err != nil {
+			return
+		}
+	}
+	r0, _, e1 := syscall.SyscallN(
+		procCreateSemaphoreW.Addr(),
+		uintptr(unsafe.Pointer(lpSemaphoreAttributes)), // 安全属性，NULL 表示默认安全描述符且句柄不可继承
+		uintptr(lInitialCount),                         // 初始计数，介于 0 和 lMaximumCount 之间
+		uintptr(lMaximumCount),                         // 最大计数，必须大于 0
+		uintptr(unsafe.Pointer(_p0)),                   // 对象名称，NULL 表示未命名
+	)
+	handle = windows.Handle(r0)
+	// 对象已存在时同样返回有效句柄，调用方通过 ERROR_ALREADY_EXISTS 判断
+	if handle == 0 || e1 == windows.ERROR_ALREADY_EXISTS {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+/*
+OpenSemaphoreW
+打开现有的命名信号量对象
+
+HANDLE OpenSemaphoreW(
+
+	[in] DWORD   dwDesiredAccess,
+	[in] BOOL    bInheritHandle,
+	[in] LPCWSTR lpName
+	);
+
+返回值
+如果函数成功，则返回值是信号量对象的句柄。
+如果函数失败，则返回值为 NULL。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/synchapi/nf-synchapi-opensemaphorew
+*/
+func OpenSemaphoreW(dwDesiredAccess uint32, bInheritHandle bool, lpName string) (handle windows.Handle, err error) {
+	var _p0 *uint16
+	_p0, err = windows.UTF16PtrFromString(lpName)
+	if err != nil {
+		return
+	}
+	var _p1 uint32
+	if bInheritHandle {
+		_p1 = 1
+	}
+	r0, _, e1 := syscall.SyscallN(
+		procOpenSemaphoreW.Addr(),
+		uintptr(dwDesiredAccess), // 对信号量对象的访问，如 SYNCHRONIZE | SEMAPHORE_MODIFY_STATE
+		uintptr(_p1),             // 句柄是否可被子进程继承
+		uintptr(unsafe.Pointer(_p0)),
+	)
+	handle = windows.Handle(r0)
+	if handle == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+/*
+ReleaseSemaphore
+将指定信号量对象的计数增加指定数量
+
+BOOL ReleaseSemaphore(
+
+	[in]            HANDLE hSemaphore,
+	[in]            LONG   lReleaseCount,
+	[out, optional] LPLONG lpPreviousCount
+	);
+
+如果该函数成功，则返回值为非零值。
+计数将超过最大计数时，计数不变，函数返回 FALSE，GetLastError 返回 ERROR_TOO_MANY_POSTS。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/synchapi/nf-synchapi-releasesemaphore
+*/
+func ReleaseSemaphore(hSemaphore windows.Handle, lReleaseCount int32, lpPreviousCount *int32) (err error) {
+	r1, _, e1 := syscall.SyscallN(
+		procReleaseSemaphore.Addr(),
+		uintptr(hSemaphore),                      // 信号量对象的句柄，必须具有 SEMAPHORE_MODIFY_STATE 访问权限
+		uintptr(lReleaseCount),                   // 计数的增加量，必须大于 0
+		uintptr(unsafe.Pointer(lpPreviousCount)), // 接收之前的计数，可以为 NULL
+	)
+	if r1 == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+/*
+CreateWaitableTimerExW
+创建或打开可等待计时器对象，并返回对象的句柄
+
+HANDLE CreateWaitableTimerExW(
+
+	[in, optional] LPSECURITY_ATTRIBUTES lpTimerAttributes,
+	[in, optional] LPCWSTR               lpTimerName,
+	[in]           DWORD                 dwFlags,
+	[in]           DWORD                 dwDesiredAccess
+	);
+
+返回值
+如果函数成功，则返回值是计时器对象的句柄。
+如果命名计时器对象在函数调用之前存在，则函数返回现有对象的句柄，GetLastError 返回 ERROR_ALREADY_EXISTS。
+如果函数失败，则返回值为 NULL。
+lpTimerName 为空字符串时传递 NULL，创建未命名计时器
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/synchapi/nf-synchapi-createwaitabletimerexw
+*/
+func CreateWaitableTimerExW(lpTimerAttributes *windows.SecurityAttributes, lpTimerName string, dwFlags uint32, dwDesiredAccess uint32) (handle windows.Handle, err error) {
+	var _p0 *uint16
+	if lpTimerName != "" {
+		// 空字符串传递 NULL，创建未命名对象
+		_p0, err = windows.UTF16PtrFromString(lpTimerName)
+		if err != nil {
+			return
+		}
+	}
+	r0, _, e1 := syscall.SyscallN(
+		procCreateWaitableTimerExW.Addr(),
+		uintptr(unsafe.Pointer(lpTimerAttributes)), // 安全属性，NULL 表示默认安全描述符且句柄不可继承
+		uintptr(unsafe.Pointer(_p0)),               // 对象名称，NULL 表示未命名
+		uintptr(dwFlags),                           // CREATE_WAITABLE_TIMER_MANUAL_RESET、CREATE_WAITABLE_TIMER_HIGH_RESOLUTION
+		uintptr(dwDesiredAccess),                   // 对计时器对象的访问，如 TIMER_ALL_ACCESS
+	)
+	handle = windows.Handle(r0)
+	// 对象已存在时同样返回有效句柄，调用方通过 ERROR_ALREADY_EXISTS 判断
+	if handle == 0 || e1 == windows.ERROR_ALREADY_EXISTS {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+/*
+OpenWaitableTimerW
+打开现有的命名可等待计时器对象
+
+HANDLE OpenWaitableTimerW(
+
+	[in] DWORD   dwDesiredAccess,
+	[in] BOOL    bInheritHandle,
+	[in] LPCWSTR lpTimerName
+	);
+
+返回值
+如果函数成功，则返回值是计时器对象的句柄。
+如果函数失败，则返回值为 NULL。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/synchapi/nf-synchapi-openwaitabletimerw
+*/
+func OpenWaitableTimerW(dwDesiredAccess uint32, bInheritHandle bool, lpTimerName string) (handle windows.Handle, err error) {
+	var _p0 *uint16
+	_p0, err = windows.UTF16PtrFromString(lpTimerName)
+	if err != nil {
+		return
+	}
+	var _p1 uint32
+	if bInheritHandle {
+		_p1 = 1
+	}
+	r0, _, e1 := syscall.SyscallN(
+		procOpenWaitableTimerW.Addr(),
+		uintptr(dwDesiredAccess), // 对计时器对象的访问，如 SYNCHRONIZE | TIMER_MODIFY_STATE
+		uintptr(_p1),             // 句柄是否可被子进程继承
+		uintptr(unsafe.Pointer(_p0)),
+	)
+	handle = windows.Handle(r0)
+	if handle == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+/*
+SetWaitableTimer
+激活指定的可等待计时器，到期时计时器变为有信号状态
+
+BOOL SetWaitableTimer(
+
+	[in]           HANDLE              hTimer,
+	[in]           const LARGE_INTEGER *lpDueTime,
+	[in]           LONG                lPeriod,
+	[in, optional] PTIMERAPCROUTINE    pfnCompletionRoutine,
+	[in, optional] LPVOID              lpArgToCompletionRoutine,
+	[in]           BOOL                fResume
+	);
+
+如果该函数成功，则返回值为非零值。
+lpDueTime 为正数时表示 UTC 绝对时间（FILETIME 格式），为负数时表示相对时间，均以 100 纳秒为单位。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/synchapi/nf-synchapi-setwaitabletimer
+*/
+func SetWaitableTimer(hTimer windows.Handle, lpDueTime *int64, lPeriod int32, pfnCompletionRoutine uintptr, lpArgToCompletionRoutine uintptr, fResume bool) (err error) {
+	var _p0 uint32
+	if fResume {
+		_p0 = 1
+	}
+	r1, _, e1 := syscall.SyscallN(
+		procSetWaitableTimer.Addr(),
+		uintptr(hTimer),                    // 计时器对象的句柄，必须具有 TIMER_MODIFY_STATE 访问权限
+		uintptr(unsafe.Pointer(lpDueTime)), // 到期时间
+		uintptr(lPeriod),                   // 周期（以毫秒为单位），0 表示只触发一次
+		pfnCompletionRoutine,               // 到期时以 APC 调用的完成例程，可以为 NULL
+		lpArgToCompletionRoutine,           // 传递给完成例程的参数
+		uintptr(_p0),                       // 是否在到期时唤醒处于挂起电源状态的系统
+	)
+	if r1 == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+/*
+CancelWaitableTimer
+将指定的可等待计时器设置为非活动状态，不会改变计时器的信号状态
+
+BOOL CancelWaitableTimer(
+
+	[in] HANDLE hTimer
+	);
+
+如果该函数成功，则返回值为非零值。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/synchapi/nf-synchapi-cancelwaitabletimer
+*/
+func CancelWaitableTimer(hTimer windows.Handle) (err error) {
+	r1, _, e1 := syscall.SyscallN(
+		procCancelWaitableTimer.Addr(),
+		uintptr(hTimer),
+	)
+	if r1 == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+/*
+CreatePseudoConsole
+创建伪控制台，附加到伪控制台的进程通过 hInput 接收输入，通过 hOutput 写出带 VT 序列的输出。
+需要 Windows 10 1809 及以上版本
+
+HRESULT CreatePseudoConsole(
+
+	[in]  COORD    size,
+	[in]  HANDLE   hInput,
+	[in]  HANDLE   hOutput,
+	[in]  DWORD    dwFlags,
+	[out] HPCON    *phPC
+	);
+
+返回值
+如果函数成功，则返回 S_OK，否则返回 HRESULT 错误代码。
+函数会复制 hInput 和 hOutput，调用方可以在函数返回后关闭它们。
+
+Link: https://learn.microsoft.com/zh-cn/windows/console/createpseudoconsole
+*/
+func CreatePseudoConsole(size windows.Coord, hInput windows.Handle, hOutput windows.Handle, dwFlags uint32, phPC *windows.Handle) (err error) {
+	if err = procCreatePseudoConsole.Find(); err != nil {
+		return ErrNotImplemented
+	}
+	r1, _, _ := syscall.SyscallN(
+		procCreatePseudoConsole.Addr(),
+		uintptr(*(*uint32)(unsafe.Pointer(&size))), // 缓冲区的列数和行数，COORD 按值传递
+		uintptr(hInput),                            // 伪控制台读取输入的管道读取端
+		uintptr(hOutput),                           // 伪控制台写入输出的管道写入端
+		uintptr(dwFlags),                           // 0 或 PSEUDOCONSOLE_INHERIT_CURSOR
+		uintptr(unsafe.Pointer(phPC)),              // 接收伪控制台的句柄
+	)
+	err = hresultErr(r1)
+	return
+}
+
+/*
+ResizePseudoConsole
+调整伪控制台内部缓冲区的大小
+
+HRESULT ResizePseudoConsole(
+
+	[in] HPCON hPC,
+	[in] COORD size
+	);
+
+返回值
+如果函数成功，则返回 S_OK，否则返回 HRESULT 错误代码。
+
+Link: https://learn.microsoft.com/zh-cn/windows/console/resizepseudoconsole
+*/
+func ResizePseudoConsole(hPC windows.Handle, size windows.Coord) (err error) {
+	if err = procResizePseudoConsole.Find(); err != nil {
+		return ErrNotImplemented
+	}
+	r1, _, _ := syscall.SyscallN(
+		procResizePseudoConsole.Addr(),
+		uintptr(hPC),
+		uintptr(*(*uint32)(unsafe.Pointer(&size))),
+	)
+	err = hresultErr(r1)
+	return
+}
+
+/*
+ClosePseudoConsole
+关闭伪控制台，附加到伪控制台的进程会收到 CTRL_CLOSE_EVENT 并被终止
+
+void ClosePseudoConsole(
+
+	[in] HPCON hPC
+	);
+
+在 Windows 11 24H2 之前的版本上，函数会等待伪控制台写出最后一帧，调用方必须继续读取输出管道，否则函数不会返回。
+
+Link: https://learn.microsoft.com/zh-cn/windows/console/closepseudoconsole
+*/
+func ClosePseudoConsole(hPC windows.Handle) {
+	if procClosePseudoConsole.Find() != nil {
+		return
+	}
+	syscall.SyscallN(
+		procClosePseudoConsole.Addr(),
+		uintptr(hPC),
+	)
+}
+
+/*
+GetConsoleScreenBufferInfoEx
+检索有关指定控制台屏幕缓冲区的扩展信息
+
+BOOL WINAPI GetConsoleScreenBufferInfoEx(
+
+	_In_  HANDLE                        hConsoleOutput,
+	_Out_ PCONSOLE_SCREEN_BUFFER_INFOEX lpConsoleScreenBufferInfoEx
+	);
+
+如果该函数成功，则返回值为非零值。
+
+Link: https://learn.microsoft.com/zh-cn/windows/console/getconsolescreenbufferinfoex
+*/
+func GetConsoleScreenBufferInfoEx(hConsoleOutput windows.Handle, lpConsoleScreenBufferInfoEx *CONSOLE_SCREEN_BUFFER_INFOEX) (err error) {
+	r1, _, e1 := syscall.SyscallN(
+		procGetConsoleScreenBufferInfoEx.Addr(),
+		uintptr(hConsoleOutput),                              // 控制台屏幕缓冲区的句柄，必须具有 GENERIC_READ 访问权限
+		uintptr(unsafe.Pointer(lpConsoleScreenBufferInfoEx)), // CbSize 必须已设置
+	)
+	if r1 == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+/*
+SetConsoleScreenBufferSize
+更改指定控制台屏幕缓冲区的大小
+
+BOOL WINAPI SetConsoleScreenBufferSize(
+
+	_In_ HANDLE hConsoleOutput,
+	_In_ COORD  dwSize
+	);
+
+如果该函数成功，则返回值为非零值。
+新大小不能小于控制台窗口的大小。
+
+Link: https://learn.microsoft.com/zh-cn/windows/console/setconsolescreenbuffersize
+*/
+func SetConsoleScreenBufferSize(hConsoleOutput windows.Handle, dwSize windows.Coord) (err error) {
+	r1, _, e1 := syscall.SyscallN(
+		procSetConsoleScreenBufferSize.Addr(),
+		uintptr(hConsoleOutput),                      // 控制台屏幕缓冲区的句柄，必须具有 GENERIC_READ 访问权限
+		uintptr(*(*uint32)(unsafe.Pointer(&dwSize))), // 新的列数和行数，COORD 按值传递
+	)
+	if r1 == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+/*
+ReadConsoleInputW
+从控制台输入缓冲区读取数据并将其从缓冲区中删除，缓冲区为空时阻塞
+
+BOOL WINAPI ReadConsoleInputW(
+
+	_In_  HANDLE        hConsoleInput,
+	_Out_ PINPUT_RECORD lpBuffer,
+	_In_  DWORD         nLength,
+	_Out_ LPDWORD       lpNumberOfEventsRead
+	);
+
+如果该函数成功，则返回值为非零值。
+
+Link: https://learn.microsoft.com/zh-cn/windows/console/readconsoleinput
+*/
+func ReadConsoleInputW(hConsoleInput windows.Handle, lpBuffer *INPUT_RECORD, nLength uint32, lpNumberOfEventsRead *uint32) (err error) {
+	r1, _, e1 := syscall.SyscallN(
+		procReadConsoleInputW.Addr(),
+		uintptr(hConsoleInput),                        // 控制台输入缓冲区的句柄，必须具有 GENERIC_READ 访问权限
+		uintptr(unsafe.Pointer(lpBuffer)),             // 接收输入记录的数组
+		uintptr(nLength),                              // 数组的长度（以记录为单位）
+		uintptr(unsafe.Pointer(lpNumberOfEventsRead)), // 接收读取的记录数
+	)
+	if r1 == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+/*
+GetConsoleTitleW
+检索当前控制台窗口的标题
+
+DWORD WINAPI GetConsoleTitleW(
+
+	_Out_ LPWSTR lpConsoleTitle,
+	_In_  DWORD  nSize
+	);
+
+返回值
+如果该函数成功，则返回值为控制台窗口标题的长度（以字符为单位）。
+如果函数失败，则返回值为零。标题为空时返回值同样为零，此时 GetLastError 返回 0。
+
+Link: https://learn.microsoft.com/zh-cn/windows/console/getconsoletitle
+*/
+func GetConsoleTitleW(lpConsoleTitle *uint16, nSize uint32) (value uint32, err error) {
+	r0, _, e1 := syscall.SyscallN(
+		procGetConsoleTitleW.Addr(),
+		uintptr(unsafe.Pointer(lpConsoleTitle)), // 接收标题的缓冲区
+		uintptr(nSize),                          // 缓冲区的大小（以字符为单位）
+	)
+	value = uint32(r0)
+	if value == 0 && e1 != 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+/*
+SetConsoleTitleW
+设置当前控制台窗口的标题
+
+BOOL WINAPI SetConsoleTitleW(
+
+	_In_ LPCWSTR lpConsoleTitle
+	);
+
+如果该函数成功，则返回值为非零值。
+
+Link: https://learn.microsoft.com/zh-cn/windows/console/setconsoletitle
+*/
+func SetConsoleTitleW(lpConsoleTitle string) (err error) {
+	var _p0 *uint16
+	_p0, err = windows.UTF16PtrFromString(lpConsoleTitle)
+	if err != nil {
+		return
+	}
+	r1, _, e1 := syscall.SyscallN(
+		procSetConsoleTitleW.Addr(),
+		uintptr(unsafe.Pointer(_p0)),
+	)
+	if r1 == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+/*
+SetConsoleCtrlHandler
+在调用进程的处理函数列表中添加或删除应用程序定义的 HandlerRoutine 函数
+
+BOOL WINAPI SetConsoleCtrlHandler(
+
+	_In_opt_ PHANDLER_ROUTINE HandlerRoutine,
+	_In_     BOOL             Add
+	);
+
+如果该函数成功，则返回值为非零值。
+处理函数在系统创建的新线程中调用，后添加的处理函数先被调用；返回 TRUE 时不再调用其余的处理函数。
+
+Link: https://learn.microsoft.com/zh-cn/windows/console/setconsolectrlhandler
+*/
+func SetConsoleCtrlHandler(handlerRoutine uintptr, add bool) (err error) {
+	var _p0 uint32
+	if add {
+		_p0 = 1
+	}
+	r1, _, e1 := syscall.SyscallN(
+		procSetConsoleCtrlHandler.Addr(),
+		handlerRoutine, // BOOL WINAPI HandlerRoutine(DWORD dwCtrlType)，为 NULL 时 Add 控制是否忽略 CTRL+C
+		uintptr(_p0),
+	)
+	if r1 == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+/*
+GetTickCount64
+检索自系统启动以来经过的毫秒数，分辨率与系统计时器相同（通常为 10 到 16 毫秒）
+
+ULONGLONG GetTickCount64();
+
+返回值是自系统启动以来经过的毫秒数，包括睡眠和休眠的时间
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/sysinfoapi/nf-sysinfoapi-gettickcount64
+*/
+func GetTickCount64() (value uint64) {
+	r1, r2, _ := syscall.SyscallN(procGetTickCount64.Addr())
+	value = uint64(r1)
+	if unsafe.Sizeof(r1) == 4 {
+		// 386 上 ULONGLONG 通过 EDX:EAX 返回，只取 r1 会每 49.7 天回绕
+		value |= uint64(r2) << 32
+	}
+	return
+}
+
+/*
+QueryPerformanceCounter
+检索性能计数器的当前值，这是一个分辨率小于 1 微秒的高精度时间戳
+
+BOOL QueryPerformanceCounter(
+
+	[out] LARGE_INTEGER *lpPerformanceCount
+	);
+
+如果该函数成功，则返回值为非零值。在 Windows XP 及以上版本中始终成功。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/profileapi/nf-profileapi-queryperformancecounter
+*/
+func QueryPerformanceCounter(lpPerformanceCount *int64) (err error) {
+	r1, _, e1 := syscall.SyscallN(
+		procQueryPerformanceCounter.Addr(),
+		uintptr(unsafe.Pointer(lpPerformanceCount)), // 接收当前计数值
+	)
+	if r1 == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+/*
+QueryPerformanceFrequency
+检索性能计数器的频率（每秒计数），频率在系统启动时固定，在所有处理器上一致
+
+BOOL QueryPerformanceFrequency(
+
+	[out] LARGE_INTEGER *lpFrequency
+	);
+
+如果该函数成功，则返回值为非零值。在 Windows XP 及以上版本中始终成功。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/profileapi/nf-profileapi-queryperformancefrequency
+*/
+func QueryPerformanceFrequency(lpFrequency *int64) (err error) {
+	r1, _, e1 := syscall.SyscallN(
+		procQueryPerformanceFrequency.Addr(),
+		uintptr(unsafe.Pointer(lpFrequency)), // 接收频率
+	)
+	if r1 == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+/*
+QueryUnbiasedInterruptTime
+获取当前无偏中断时间计数，以 100 纳秒为单位，不包括系统处于睡眠或休眠状态的时间
+
+BOOL QueryUnbiasedInterruptTime(
+
+	[out] PULONGLONG UnbiasedTime
+	);
+
+如果函数成功，则返回值为非零值；如果 UnbiasedTime 为 NULL，则失败并返回 ERROR_INVALID_PARAMETER。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/realtimeapiset/nf-realtimeapiset-queryunbiasedinterrupttime
+*/
+func QueryUnbiasedInterruptTime(unbiasedTime *uint64) (err error) {
+	r1, _, e1 := syscall.SyscallN(
+		procQueryUnbiasedInterruptTime.Addr(),
+		uintptr(unsafe.Pointer(unbiasedTime)),
+	)
+	if r1 == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+/*
+GetLocaleInfoEx
+检索有关由名称指定的区域设置的信息
+
+int GetLocaleInfoEx(
+
+	[in, optional]  LPCWSTR lpLocaleName,
+	[in]            LCTYPE  LCType,
+	[out, optional] LPWSTR  lpLCData,
+	[in]            int     cchData
+	);
+
+返回值
+如果成功，则返回 lpLCData 中检索到的字符数（包括终止 null 字符）；cchData 为 0 时返回所需的缓冲区大小。
+指定 LOCALE_RETURN_NUMBER 时 lpLCData 接收一个 DWORD，cchData 为 2。
+如果函数失败，则返回 0。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/winnls/nf-winnls-getlocaleinfoex
+*/
+func GetLocaleInfoEx(lpLocaleName *uint16, LCType uint32, lpLCData *uint16, cchData int32) (value int32, err error) {
+	r0, _, e1 := syscall.SyscallN(
+		procGetLocaleInfoEx.Addr(),
+		uintptr(unsafe.Pointer(lpLocaleName)), // 区域设置名称，NULL 表示用户默认区域设置
+		uintptr(LCType),                       // LOCALE_* 信息类型
+		uintptr(unsafe.Pointer(lpLCData)),     // 接收信息的缓冲区
+		uintptr(cchData),                      // 缓冲区的大小（以字符为单位）
+	)
+	value = int32(r0)
+	if value == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+/*
+LocaleNameToLCID
+将区域设置名称转换为区域设置标识符
+
+LCID LocaleNameToLCID(
+
+	[in] LPCWSTR lpName,
+	[in] DWORD   dwFlags
+	);
+
+返回值
+如果成功，则返回区域设置标识符；没有分配 LCID 的区域设置返回 LOCALE_CUSTOM_UNSPECIFIED (0x1000)。
+如果函数失败，则返回 0。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/winnls/nf-winnls-localenametolcid
+*/
+func LocaleNameToLCID(lpName string, dwFlags uint32) (lcid uint32, err error) {
+	var _p0 *uint16
+	_p0, err = windows.UTF16PtrFromString(lpName)
+	if err != nil {
+		return
+	}
+	r0, _, e1 := syscall.SyscallN(
+		procLocaleNameToLCID.Addr(),
+		uintptr(unsafe.Pointer(_p0)), // 区域设置名称或 LOCALE_NAME_* 常量
+		uintptr(dwFlags),             // LOCALE_ALLOW_NEUTRAL_NAMES
+	)
+	lcid = uint32(r0)
+	if lcid == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+/*
+LCIDToLocaleName
+将区域设置标识符转换为区域设置名称
+
+int LCIDToLocaleName(
+
+	[in]            LCID   Locale,
+	[out, optional] LPWSTR lpName,
+	[in]            int    cchName,
+	[in]            DWORD  dwFlags
+	);
+
+返回值
+如果成功，则返回区域设置名称的字符数（包括终止 null 字符）。
+如果函数失败，则返回 0。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/winnls/nf-winnls-lcidtolocalename
+*/
+func LCIDToLocaleName(Locale uint32, lpName *uint16, cchName int32, dwFlags uint32) (value int32, err error) {
+	r0, _, e1 := syscall.SyscallN(
+		procLCIDToLocaleName.Addr(),
+		uintptr(Locale),                 // 区域设置标识符
+		uintptr(unsafe.Pointer(lpName)), // 接收名称的缓冲区，最多 LOCALE_NAME_MAX_LENGTH 个字符
+		uintptr(cchName),
+		uintptr(dwFlags), // LOCALE_ALLOW_NEUTRAL_NAMES
+	)
+	value = int32(r0)
+	if value == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+/*
+GetUserDefaultLocaleName
+检索用户默认区域设置名称
+
+int GetUserDefaultLocaleName(
+
+	[out] LPWSTR lpLocaleName,
+	[in]  int    cchLocaleName
+	);
+
+返回值
+如果成功，则返回区域设置名称的字符数（包括终止 null 字符）。
+如果函数失败，则返回 0。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/winnls/nf-winnls-getuserdefaultlocalename
+*/
+func GetUserDefaultLocaleName(lpLocaleName *uint16, cchLocaleName int32) (value int32, err error) {
+	r0, _, e1 := syscall.SyscallN(
+		procGetUserDefaultLocaleName.Addr(),
+		uintptr(unsafe.Pointer(lpLocaleName)), // 接收名称的缓冲区，建议大小为 LOCALE_NAME_MAX_LENGTH
+		uintptr(cchLocaleName),
+	)
+	value = int32(r0)
+	if value == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+/*
+GetCalendarInfoEx
+检索有关由名称指定的区域设置的日历的信息
+
+int GetCalendarInfoEx(
+
+	[in, optional]  LPCWSTR lpLocaleName,
+	[in]            CALID   Calendar,
+	[in, optional]  LPCWSTR lpReserved,
+	[in]            CALTYPE CalType,
+	[out, optional] LPWSTR  lpCalData,
+	[in]            int     cchData,
+	[out, optional] LPDWORD lpValue
+	);
+
+返回值
+如果成功，则返回 lpCalData 中检索到的字符数（包括终止 null 字符）；cchData 为 0 时返回所需的缓冲区大小。
+如果函数失败，则返回 0。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/winnls/nf-winnls-getcalendarinfoex
+*/
+func GetCalendarInfoEx(lpLocaleName *uint16, Calendar uint32, CalType uint32, lpCalData *uint16, cchData int32, lpValue *uint32) (value int32, err error) {
+	r0, _, e1 := syscall.SyscallN(
+		procGetCalendarInfoEx.Addr(),
+		uintptr(unsafe.Pointer(lpLocaleName)), // 区域设置名称
+		uintptr(Calendar),                     // CAL_* 日历标识符
+		0,                                     // 保留，必须为 NULL
+		uintptr(CalType),                      // CAL_* 信息类型
+		uintptr(unsafe.Pointer(lpCalData)),    // 接收字符串信息的缓冲区
+		uintptr(cchData),
+		uintptr(unsafe.Pointer(lpValue)), // CAL_RETURN_NUMBER 时接收数值
+	)
+	value = int32(r0)
+	if value == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+/*
+EnumTimeFormatsEx
+枚举由名称指定的区域设置的时间格式
+
+BOOL EnumTimeFormatsEx(
+
+	[in]           TIMEFMT_ENUMPROCEX lpTimeFmtEnumProcEx,
+	[in, optional] LPCWSTR            lpLocaleName,
+	[in]           DWORD              dwFlags,
+	[in]           LPARAM             lParam
+	);
+
+返回值
+如果成功，则返回非零值，否则返回 0。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/winnls/nf-winnls-enumtimeformatsex
+*/
+func EnumTimeFormatsEx(lpTimeFmtEnumProcEx uintptr, lpLocaleName *uint16, dwFlags uint32, lParam uintptr) (err error) {
+	r1, _, e1 := syscall.SyscallN(
+		procEnumTimeFormatsEx.Addr(),
+		lpTimeFmtEnumProcEx,                   // BOOL CALLBACK EnumTimeFormatsProcEx(LPWSTR lpTimeFormatString, LPARAM lParam)
+		uintptr(unsafe.Pointer(lpLocaleName)), // 区域设置名称
+		uintptr(dwFlags),                      // 0 或 TIME_NOSECONDS
+		lParam,
+	)
+	if r1 == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+/*
+EnumDateFormatsExEx
+枚举由名称指定的区域设置的日期格式
+
+BOOL EnumDateFormatsExEx(
+
+	[in]           DATEFMT_ENUMPROCEXEX lpDateFmtEnumProcExEx,
+	[in, optional] LPCWSTR              lpLocaleName,
+	[in]           DWORD                dwFlags,
+	[in]           LPARAM               lParam
+	);
+
+返回值
+如果成功，则返回非零值，否则返回 0。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/winnls/nf-winnls-enumdateformatsexex
+*/
+func EnumDateFormatsExEx(lpDateFmtEnumProcExEx uintptr, lpLocaleName *uint16, dwFlags uint32, lParam uintptr) (err error) {
+	r1, _, e1 := syscall.SyscallN(
+		procEnumDateFormatsExEx.Addr(),
+		lpDateFmtEnumProcExEx,                 // BOOL CALLBACK EnumDateFormatsProcExEx(LPWSTR lpDateFormatString, CALID CalendarID, LPARAM lParam)
+		uintptr(unsafe.Pointer(lpLocaleName)), // 区域设置名称
+		uintptr(dwFlags),                      // DATE_SHORTDATE、DATE_LONGDATE、DATE_YEARMONTH 或 DATE_MONTHDAY
+		lParam,
+	)
+	if r1 == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+/*
+GetTimeFormatEx
+将时间格式化为由名称指定的区域设置的时间字符串
+
+int GetTimeFormatEx(
+
+	[in, optional]  LPCWSTR          lpLocaleName,
+	[in]            DWORD            dwFlags,
+	[in, optional]  const SYSTEMTIME *lpTime,
+	[in, optional]  LPCWSTR          lpFormat,
+	[out, optional] LPWSTR           lpTimeStr,
+	[in]            int              cchTime
+	);
+
+返回值
+如果成功，则返回 lpTimeStr 中检索到的字符数（包括终止 null 字符）；cchTime 为 0 时返回所需的缓冲区大小。
+如果函数失败，则返回 0。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/datetimeapi/nf-datetimeapi-gettimeformatex
+*/
+func GetTimeFormatEx(lpLocaleName *uint16, dwFlags uint32, lpTime *windows.Systemtime, lpFormat *uint16, lpTimeStr *uint16, cchTime int32) (value int32, err error) {
+	r0, _, e1 := syscall.SyscallN(
+		procGetTimeFormatEx.Addr(),
+		uintptr(unsafe.Pointer(lpLocaleName)), // 区域设置名称
+		uintptr(dwFlags),                      // TIME_* 标志
+		uintptr(unsafe.Pointer(lpTime)),       // 要格式化的时间，NULL 表示当前本地时间
+		uintptr(unsafe.Pointer(lpFormat)),     // 图片字符串，NULL 表示区域设置的默认格式
+		uintptr(unsafe.Pointer(lpTimeStr)),    // 接收格式化结果的缓冲区
+		uintptr(cchTime),
+	)
+	value = int32(r0)
+	if value == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+/*
+GetDateFormatEx
+将日期格式化为由名称指定的区域设置的日期字符串
+
+int GetDateFormatEx(
+
+	[in, optional]  LPCWSTR          lpLocaleName,
+	[in]            DWORD            dwFlags,
+	[in, optional]  const SYSTEMTIME *lpDate,
+	[in, optional]  LPCWSTR          lpFormat,
+	[out, optional] LPWSTR           lpDateStr,
+	[in]            int              cchDate,
+	[in, optional]  LPCWSTR          lpCalendar
+	);
+
+返回值
+如果成功，则返回 lpDateStr 中检索到的字符数（包括终止 null 字符）；cchDate 为 0 时返回所需的缓冲区大小。
+如果函数失败，则返回 0。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/datetimeapi/nf-datetimeapi-getdateformatex
+*/
+func GetDateFormatEx(lpLocaleName *uint16, dwFlags uint32, lpDate *windows.Systemtime, lpFormat *uint16, lpDateStr *uint16, cchDate int32) (value int32, err error) {
+	r0, _, e1 := syscall.SyscallN(
+		procGetDateFormatEx.Addr(),
+		uintptr(unsafe.Pointer(lpLocaleName)), // 区域设置名称
+		uintptr(dwFlags),                      // DATE_* 标志，指定 lpFormat 时必须为 0
+		uintptr(unsafe.Pointer(lpDate)),       // 要格式化的日期，NULL 表示当前本地日期
+		uintptr(unsafe.Pointer(lpFormat)),     // 图片字符串，NULL 表示区域设置的默认格式
+		uintptr(unsafe.Pointer(lpDateStr)),    // 接收格式化结果的缓冲区
+		uintptr(cchDate),
+		0, // lpCalendar 保留，必须为 NULL
+	)
+	value = int32(r0)
+	if value == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+/*
+GetDynamicTimeZoneInformation
+检索当前时区和动态夏令时设置，这些设置控制本地时间与 UTC 之间的转换
+
+DWORD GetDynamicTimeZoneInformation(
+
+	[out] PDYNAMIC_TIME_ZONE_INFORMATION pTimeZoneInformation
+	);
+
+返回值
+如果函数成功，则返回 TIME_ZONE_ID_UNKNOWN、TIME_ZONE_ID_STANDARD 或 TIME_ZONE_ID_DAYLIGHT。
+如果函数失败，则返回 TIME_ZONE_ID_INVALID。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/timezoneapi/nf-timezoneapi-getdynamictimezoneinformation
+*/
+func GetDynamicTimeZoneInformation(pTimeZoneInformation *DYNAMIC_TIME_ZONE_INFORMATION) (value uint32, err error) {
+	r1, _, e1 := syscall.SyscallN(
+		procGetDynamicTimeZoneInformation.Addr(),
+		uintptr(unsafe.Pointer(pTimeZoneInformation)),
+	)
+	value = uint32(r1)
+	if value == TIME_ZONE_ID_INVALID {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+/*
+GetTimeZoneInformationForYear
+检索指定年份生效的时区规则，考虑注册表 "Dynamic DST" 子项中按年份记录的规则
+
+BOOL GetTimeZoneInformationForYear(
+
+	[in]           USHORT                          wYear,
+	[in, optional] PDYNAMIC_TIME_ZONE_INFORMATION pdtzi,
+	[out]          LPTIME_ZONE_INFORMATION         ptzi
+	);
+
+返回值
+如果函数成功，则返回非零值。
+如果函数失败，则返回零。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/timezoneapi/nf-timezoneapi-gettimezoneinformationforyear
+*/
+func GetTimeZoneInformationForYear(wYear uint16, pdtzi *DYNAMIC_TIME_ZONE_INFORMATION, ptzi *windows.Timezoneinformation) (err error) {
+	r1, _, e1 := syscall.SyscallN(
+		procGetTimeZoneInformationForYear.Addr(),
+		uintptr(wYear),                 // 年份
+		uintptr(unsafe.Pointer(pdtzi)), // 时区，为 NULL 时使用当前时区
+		uintptr(unsafe.Pointer(ptzi)),  // 接收该年份的规则
+	)
+	if r1 == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
@@ -0,0 +1,30 @@
+package xwindows
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+/*
+QueryInterruptTimePrecise
+获取当前中断时间计数的精确读数，以 100 纳秒为单位，包括系统处于睡眠或休眠状态的时间
+
+VOID QueryInterruptTimePrecise(
+
+	[out] PULONGLONG lpInterruptTimePrecise
+	);
+
+需要 Windows 10 及以上版本，由 kernelbase.dll 导出。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/realtimeapiset/nf-realtimeapiset-queryinterrupttimeprecise
+*/
+func QueryInterruptTimePrecise(lpInterruptTimePrecise *uint64) (err error) {
+	if err = procQueryInterruptTimePrecise.Find(); err != nil {
+		return ErrNotImplemented
+	}
+	syscall.SyscallN(
+		procQueryInterruptTimePrecise.Addr(),
+		uintptr(unsafe.Pointer(lpInterruptTimePrecise)),
+	)
+	return
+}
@@ -2,6 +2,7 @@ package xwindows
 
 import (
 	"errors"
+	"runtime"
 	"syscall"
 	"unsafe"
 
@@ -178,6 +179,46 @@ func RtlEthernetAddressToStringA(addr *byte, s uintptr) (value uintptr, err erro
 	return
 }
 
+// RtlEthernetAddressToString 是 RtlEthernetAddressToStringA 的类型化版本
+func RtlEthernetAddressToString(addr [6]byte) string {
+	var p runtime.Pinner
+	defer p.Unpin()
+	buf := make([]byte, 18) // 17 个字符和结尾的 NULL
+	_, _, _ = syscall.SyscallN(
+		procRtlEthernetAddressToStringA.Addr(),
+		pinPtr(&p, &addr),
+		pinSlice(&p, buf),
+	)
+	return windows.ByteSliceToString(buf)
+}
+
+// RtlEthernetStringToAddress 是 RtlEthernetStringToAddressA 的类型化版本。
+// 与原始函数不同，字符串必须被完整解析，剩余字符视为错误。
+func RtlEthernetStringToAddress(s string) (addr [6]byte, err error) {
+	var p runtime.Pinner
+	defer p.Unpin()
+	str, err := windows.ByteSliceFromString(s)
+	if err != nil {
+		return
+	}
+	base := pinSlice(&p, str)
+	var terminator uintptr
+	r1, _, _ := syscall.SyscallN(
+		procRtlEthernetStringToAddressA.Addr(),
+		base,
+		pinPtr(&p, &terminator),
+		pinPtr(&p, &addr),
+	)
+	if status := windows.NTStatus(r1); status != windows.STATUS_SUCCESS {
+		err = status
+		return
+	}
+	if terminator != base+uintptr(len(s)) {
+		err = windows.STATUS_INVALID_PARAMETER
+	}
+	return
+}
+
 // RtlIpv4StringToAddressA
 /*
 RtlIpv4StringToAddressA
@@ -268,6 +309,51 @@ func RtlIpv4AddressToStringA(addr uintptr, s uintptr) (value uintptr, err error)
 	return
 }
 
+// RtlIpv4AddressToString 是 RtlIpv4AddressToStringA 的类型化版本，addr 按网络字节顺序排列
+func RtlIpv4AddressToString(addr [4]byte) string {
+	var p runtime.Pinner
+	defer p.Unpin()
+	buf := make([]byte, 16) // INET_ADDRSTRLEN
+	_, _, _ = syscall.SyscallN(
+		procRtlIpv4AddressToStringA.Addr(),
+		pinPtr(&p, &addr),
+		pinSlice(&p, buf),
+	)
+	return windows.ByteSliceToString(buf)
+}
+
+// RtlIpv4StringToAddress 是 RtlIpv4StringToAddressA 的类型化版本，返回按网络字节顺序排列的地址。
+// 与原始函数不同，字符串必须被完整解析，剩余字符视为错误。
+func RtlIpv4StringToAddress(s string, strict bool) (addr [4]byte, err error) {
+	var p runtime.Pinner
+	defer p.Unpin()
+	str, err := windows.ByteSliceFromString(s)
+	if err != nil {
+		return
+	}
+	var _p0 uintptr
+	if strict {
+		_p0 = 1
+	}
+	base := pinSlice(&p, str)
+	var terminator uintptr
+	r1, _, _ := syscall.SyscallN(
+		procRtlIpv4StringToAddressA.Addr(),
+		base,
+		_p0,
+		pinPtr(&p, &terminator),
+		pinPtr(&p, &addr),
+	)
+	if status := windows.NTStatus(r1); status != windows.STATUS_SUCCESS {
+		err = status
+		return
+	}
+	if terminator != base+uintptr(len(s)) {
+		err = windows.STATUS_INVALID_PARAMETER
+	}
+	return
+}
+
 /*
 NtAllocateVirtualMemory
 在指定进程的用户模式虚拟地址空间中保留和/或提交页面区域。
@@ -546,6 +632,44 @@ func NtQueryInformationThread(threadHandle windows.Handle, threadInformationClas
 	return
 }
 
+// QueryInformationThread 是 NtQueryInformationThread 的类型化版本，适用于定长的信息类。
+// 信息写入 out.Value，实际长度写入 out.ReturnLength，失败时返回 windows.NTStatus。
+func QueryInformationThread[T any](threadHandle windows.Handle, threadInformationClass uint32, out *Out[T]) (err error) {
+	var p runtime.Pinner
+	defer p.Unpin()
+	r1, _, _ := syscall.SyscallN(
+		procNtQueryInformationThread.Addr(),
+		uintptr(threadHandle),
+		uintptr(threadInformationClass),
+		pinPtr(&p, &out.Value),
+		uintptr(out.Size()),
+		pinPtr(&p, &out.ReturnLength),
+	)
+	if status := windows.NTStatus(r1); status != windows.STATUS_SUCCESS {
+		err = status
+	}
+	return
+}
+
+// QueryInformationThreadBuffer 是 NtQueryInformationThread 的缓冲区版本，适用于变长的信息类。
+// 返回函数报告的所需长度，缓冲区不足时 err 为 STATUS_INFO_LENGTH_MISMATCH 等状态码。
+func QueryInformationThreadBuffer(threadHandle windows.Handle, threadInformationClass uint32, buf []byte) (returnLength uint32, err error) {
+	var p runtime.Pinner
+	defer p.Unpin()
+	r1, _, _ := syscall.SyscallN(
+		procNtQueryInformationThread.Addr(),
+		uintptr(threadHandle),
+		uintptr(threadInformationClass),
+		pinSlice(&p, buf),
+		uintptr(len(buf)),
+		pinPtr(&p, &returnLength),
+	)
+	if status := windows.NTStatus(r1); status != windows.STATUS_SUCCESS {
+		err = status
+	}
+	return
+}
+
 /*
 NtCreateSection 例程创建一个节对象**
 
@@ -566,7 +690,7 @@ link: https://learn.microsoft.com/en-us/windows-hardware/drivers/ddi/ntifs/nf-nt
 Github: https://github.com/hillu/go-ntdll/blob/f8894bfa00af/section_generated.go#L24
 */
 func NtCreateSection(sectionHandle *windows.Handle, desiredAccess uint32, objectAttributes *OBJECT_ATTRIBUTES, maximumSize *int64, sectionPageProtection uint32, allocationAttributes uint32, fileHandle windows.Handle) (err error) {
-	_, _, e1 := syscall.SyscallN(procNtCreateSection.Addr(),
+	r1, _, _ := syscall.SyscallN(procNtCreateSection.Addr(),
 		uintptr(unsafe.Pointer(sectionHandle)),    // 指向 HANDLE 变量的指针，该变量接收节对象的句柄
 		uintptr(desiredAccess),                    // 指定一个 ACCESS_MASK 值，该值确定对 对象的请求访问权限
 		uintptr(unsafe.Pointer(objectAttributes)), // 指向 OBJECT_ATTRIBUTES 结构的指针，该结构指定对象名称和其他属性
@@ -575,9 +699,33 @@ func NtCreateSection(sectionHandle *windows.Handle, desiredAccess uint32, object
 		uintptr(allocationAttributes),             // 指定SEC_XXX 标志的位掩码，用于确定节的分配属性
 		uintptr(fileHandle),                       // （可选）指定打开的文件对象的句柄。 如果 FileHandle 的值为 NULL，则分区由分页文件提供支持。 否则，节由指定文件提供支持。
 	)
-	if e1 != 0 {
-		err = errnoErr(e1)
-		return
+	// NT 函数通过返回值报告 NTSTATUS，不设置线程的最后错误
+	if r1 != 0 {
+		err = windows.NTStatus(r1)
+	}
+	return
+}
+
+/*
+NtUnmapViewOfSection 例程从主题进程的虚拟地址空间中取消映射节的视图
+
+NTSYSAPI NTSTATUS ZwUnmapViewOfSection(
+
+	  [in]           HANDLE ProcessHandle,
+	  [in, optional] PVOID  BaseAddress
+	);
+
+NtUnmapViewOfSection 在成功时返回STATUS_SUCCESS，或在失败时返回相应的 NTSTATUS 错误代码
+
+link: https://learn.microsoft.com/zh-cn/windows-hardware/drivers/ddi/wdm/nf-wdm-zwunmapviewofsection
+*/
+func NtUnmapViewOfSection(processHandle windows.Handle, baseAddress uintptr) (err error) {
+	r1, _, _ := syscall.SyscallN(procNtUnmapViewOfSection.Addr(),
+		uintptr(processHandle), // 进程对象的句柄
+		baseAddress,            // 指向要取消映射的视图的基本虚拟地址的指针
+	)
+	if r1 != 0 {
+		err = windows.NTStatus(r1)
 	}
 	return
 }
@@ -601,6 +749,8 @@ __kernel_entry NTSTATUS NtQueryInformationProcess(
 NTSTATUS 错误代码的形式和意义列在 DDK 中提供的 Ntstatus.h 头文件中
 
 Link: https://learn.microsoft.com/zh-cn/windows/win32/api/winternl/nf-winternl-ntqueryinformationprocess
+
+Deprecated: 参数类型与 C 原型不一致，请使用 QueryProcessInformation 或 QueryInformationProcessBuffer。
 */
 func NtQueryInformationProcess(
 	processHandle windows.Handle,
@@ -609,7 +759,7 @@ func NtQueryInformationProcess(
 	processInformationLength uintptr,
 	returnLength *uintptr,
 ) (NTSTATUS uintptr, err error) {
-	r1, _, e1 := syscall.SyscallN(
+	r1, _, _ := syscall.SyscallN(
 		procNtQueryInformationProcess.Addr(),
 		uintptr(processHandle),
 		uintptr(processInformationClass),
@@ -619,7 +769,7 @@ func NtQueryInformationProcess(
 	)
 	NTSTATUS = r1
 	if NTSTATUS != 0 {
-		err = errnoErr(e1)
+		err = windows.NTStatus(r1)
 	}
 	return
 }
@@ -648,7 +798,29 @@ func NtQueryInformationProcess(
 }
 */
 
+// QueryInformationProcessBuffer 是 NtQueryInformationProcess 的缓冲区版本。
+// 返回函数报告的所需长度，缓冲区不足时 err 为 STATUS_INFO_LENGTH_MISMATCH 等状态码。
+// 需要类型化的结果时使用 QueryProcessInformation。
+func QueryInformationProcessBuffer(processHandle windows.Handle, processInformationClass uint32, buf []byte) (returnLength uint32, err error) {
+	var p runtime.Pinner
+	defer p.Unpin()
+	r1, _, _ := syscall.SyscallN(
+		procNtQueryInformationProcess.Addr(),
+		uintptr(processHandle),
+		uintptr(processInformationClass),
+		pinSlice(&p, buf),
+		uintptr(len(buf)),
+		pinPtr(&p, &returnLength),
+	)
+	if status := windows.NTStatus(r1); status != windows.STATUS_SUCCESS {
+		err = status
+	}
+	return
+}
+
 // NtQueryInformationProcessZ 暂代 NtQueryInformationProcess(调用参数错误) 的使用
+//
+// Deprecated: 使用 QueryProcessInformation 或 QueryInformationProcessBuffer。
 func NtQueryInformationProcessZ(
 	processHandle windows.Handle,
 	processInformationClass uintptr,
@@ -695,3 +867,150 @@ func NtDelayExecution(DelayInterval int64) (err error) {
 	}
 	return
 }
+
+/*
+RtlIpv4AddressToStringExA
+将 IPv4 地址和端口号转换为 Internet 标准格式的字符串，如 "192.168.1.1:80"
+
+NTSYSAPI NTSTATUS RtlIpv4AddressToStringExA(
+
+	[in]      const in_addr *Address,
+	[in]      USHORT        Port,
+	[out]     PSTR          AddressString,
+	[in, out] PULONG        AddressStringLength
+	);
+
+如果函数成功，则返回值 STATUS_SUCCESS。
+如果缓冲区太小，则返回 STATUS_INVALID_PARAMETER，AddressStringLength 为所需的字符数（包括结尾的 NULL）。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/ip2string/nf-ip2string-rtlipv4addresstostringexa
+*/
+func RtlIpv4AddressToStringExA(address *[4]byte, port uint16, addressString *byte, addressStringLength *uint32) (err error) {
+	r1, _, _ := syscall.SyscallN(
+		procRtlIpv4AddressToStringExA.Addr(),
+		uintptr(unsafe.Pointer(address)),             // 按网络字节顺序排列的 IPv4 地址
+		uintptr(port),                                // 按网络字节顺序排列的端口号，为 0 时不输出端口
+		uintptr(unsafe.Pointer(addressString)),       // 接收以 NULL 结尾的字符串
+		uintptr(unsafe.Pointer(addressStringLength)), // 缓冲区的字符数，返回写入的字符数（包括结尾的 NULL）
+	)
+	if status := windows.NTStatus(r1); status != windows.STATUS_SUCCESS {
+		err = status
+	}
+	return
+}
+
+/*
+RtlIpv6StringToAddressA
+将 IPv6 地址的字符串表示形式转换为二进制 IPv6 地址
+
+NTSYSAPI NTSTATUS RtlIpv6StringToAddressA(
+
+	[in]  PCSTR    S,
+	[out] PCSTR    *Terminator,
+	[out] in6_addr *Addr
+	);
+
+如果函数成功，则返回值 STATUS_SUCCESS。
+如果字符串不是有效的 IPv6 地址，则返回 STATUS_INVALID_PARAMETER。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/ip2string/nf-ip2string-rtlipv6stringtoaddressa
+*/
+func RtlIpv6StringToAddressA(s *byte, terminator *uintptr, addr *[16]byte) (err error) {
+	r1, _, _ := syscall.SyscallN(
+		procRtlIpv6StringToAddressA.Addr(),
+		uintptr(unsafe.Pointer(s)),          // 以 NULL 结尾的 IPv6 地址字符串
+		uintptr(unsafe.Pointer(terminator)), // 接收指向终止转换的字符的指针
+		uintptr(unsafe.Pointer(addr)),       // 接收按网络字节顺序排列的 IPv6 地址
+	)
+	if status := windows.NTStatus(r1); status != windows.STATUS_SUCCESS {
+		err = status
+	}
+	return
+}
+
+/*
+RtlIpv6StringToAddressExA
+将 IPv6 地址、范围 ID 和端口号的字符串表示形式转换为二进制形式，字符串形式为 "[addr%scope]:port"
+
+NTSYSAPI NTSTATUS RtlIpv6StringToAddressExA(
+
+	[in]  PCSTR    AddressString,
+	[out] in6_addr *Address,
+	[out] PULONG   ScopeId,
+	[out] PUSHORT  Port
+	);
+
+如果函数成功，则返回值 STATUS_SUCCESS。
+如果字符串不是有效的 IPv6 地址，则返回 STATUS_INVALID_PARAMETER。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/ip2string/nf-ip2string-rtlipv6stringtoaddressexa
+*/
+func RtlIpv6StringToAddressExA(addressString *byte, address *[16]byte, scopeId *uint32, port *uint16) (err error) {
+	r1, _, _ := syscall.SyscallN(
+		procRtlIpv6StringToAddressExA.Addr(),
+		uintptr(unsafe.Pointer(addressString)),
+		uintptr(unsafe.Pointer(address)), // 接收按网络字节顺序排列的 IPv6 地址
+		uintptr(unsafe.Pointer(scopeId)), // 接收范围 ID，没有时为 0
+		uintptr(unsafe.Pointer(port)),    // 接收按网络字节顺序排列的端口号，没有时为 0
+	)
+	if status := windows.NTStatus(r1); status != windows.STATUS_SUCCESS {
+		err = status
+	}
+	return
+}
+
+/*
+RtlIpv6AddressToStringA
+将 IPv6 地址转换为 Internet 标准格式的字符串
+
+NTSYSAPI PSTR RtlIpv6AddressToStringA(
+
+	[in]  const in6_addr *Addr,
+	[out] PSTR           S
+	);
+
+返回值是指向字符串末尾 NULL 字符的指针。S 至少需要 46 个字符 (INET6_ADDRSTRLEN)。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/ip2string/nf-ip2string-rtlipv6addresstostringa
+*/
+func RtlIpv6AddressToStringA(addr *[16]byte, s *byte) (end uintptr) {
+	end, _, _ = syscall.SyscallN(
+		procRtlIpv6AddressToStringA.Addr(),
+		uintptr(unsafe.Pointer(addr)),
+		uintptr(unsafe.Pointer(s)),
+	)
+	return
+}
+
+/*
+RtlIpv6AddressToStringExA
+将 IPv6 地址、范围 ID 和端口号转换为字符串，如 "[fe80::1%5]:80"
+
+NTSYSAPI NTSTATUS RtlIpv6AddressToStringExA(
+
+	[in]      const in6_addr *Address,
+	[in]      ULONG          ScopeId,
+	[in]      USHORT         Port,
+	[out]     PSTR           AddressString,
+	[in, out] PULONG         AddressStringLength
+	);
+
+如果函数成功，则返回值 STATUS_SUCCESS。
+如果缓冲区太小，则返回 STATUS_INVALID_PARAMETER，AddressStringLength 为所需的字符数（包括结尾的 NULL）。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/ip2string/nf-ip2string-rtlipv6addresstostringexa
+*/
+func RtlIpv6AddressToStringExA(address *[16]byte, scopeId uint32, port uint16, addressString *byte, addressStringLength *uint32) (err error) {
+	r1, _, _ := syscall.SyscallN(
+		procRtlIpv6AddressToStringExA.Addr(),
+		uintptr(unsafe.Pointer(address)),
+		uintptr(scopeId),                             // 范围 ID，为 0 时不输出
+		uintptr(port),                                // 按网络字节顺序排列的端口号，为 0 时不输出端口和方括号
+		uintptr(unsafe.Pointer(addressString)),       // 接收以 NULL 结尾的字符串
+		uintptr(unsafe.Pointer(addressStringLength)), // 缓冲区的字符数，返回写入的字符数（包括结尾的 NULL）
+	)
+	if status := windows.NTStatus(r1); status != windows.STATUS_SUCCESS {
+		err = status
+	}
+	return
+}
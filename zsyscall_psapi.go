@@ -1,6 +1,11 @@
 package xwindows
 
-import "syscall"
+import (
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
 
 /*
 EnumPageFilesW
@@ -30,3 +35,37 @@ func EnumPageFilesW(pCallBackRoutine uintptr, pContext uintptr) (value uintptr,
 	}
 	return
 }
+
+/*
+GetMappedFileNameW
+检查指定地址是否位于指定进程地址空间中的内存映射文件内，如果是，则返回内存映射文件的名称
+
+DWORD GetMappedFileNameW(
+
+	[in]  HANDLE hProcess,
+	[in]  LPVOID lpv,
+	[out] LPWSTR lpFilename,
+	[in]  DWORD  nSize
+	);
+
+返回值
+如果函数成功，则返回值指定复制到缓冲区的字符串的长度（以字符为单位）。
+如果函数失败，则返回值为零。
+返回的文件名使用设备路径形式，如 \Device\HarddiskVolume1\Windows\System32\ntdll.dll
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/psapi/nf-psapi-getmappedfilenamew
+*/
+func GetMappedFileNameW(hProcess windows.Handle, lpv uintptr, lpFilename *uint16, nSize uint32) (value uint32, err error) {
+	r0, _, e1 := syscall.SyscallN(
+		procGetMappedFileNameW.Addr(),
+		uintptr(hProcess),                   // 进程的句柄，必须具有 PROCESS_QUERY_INFORMATION 访问权限
+		lpv,                                 // 要验证的地址
+		uintptr(unsafe.Pointer(lpFilename)), // 接收内存映射文件名称的缓冲区
+		uintptr(nSize),                      // lpFilename 缓冲区的大小（以字符为单位）
+	)
+	value = uint32(r0)
+	if value == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
@@ -1,8 +1,11 @@
 package xwindows
 
 import (
+	"runtime"
 	"syscall"
 	"unsafe"
+
+	"golang.org/x/sys/windows"
 )
 
 /*
@@ -33,3 +36,170 @@ func UuidFromStringA(stringUuid *byte, uuid uintptr) (RpcSOk uint32, err error)
 	}
 	return
 }
+
+// UuidFromString 是 UuidFromStringA 的类型化版本，直接返回解析后的 GUID
+func UuidFromString(s string) (uuid GUID, err error) {
+	var p runtime.Pinner
+	defer p.Unpin()
+	str, err := windows.ByteSliceFromString(s)
+	if err != nil {
+		return
+	}
+	r0, _, _ := syscall.SyscallN(
+		procUuidFromStringA.Addr(),
+		pinSlice(&p, str),
+		pinPtr(&p, &uuid),
+	)
+	if r0 != 0 {
+		err = syscall.Errno(r0)
+	}
+	return
+}
+
+/*
+UuidCreate
+创建新的 UUID（RFC 4122 版本 4，随机生成）
+
+RPC_STATUS UuidCreate(
+
+	UUID *Uuid
+	);
+
+返回值
+RPC_S_OK: 调用成功。
+RPC_S_UUID_LOCAL_ONLY: UUID 只保证在本机唯一。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/rpcdce/nf-rpcdce-uuidcreate
+*/
+func UuidCreate(uuid *GUID) (status uint32, err error) {
+	r1, _, _ := syscall.SyscallN(
+		procUuidCreate.Addr(),
+		uintptr(unsafe.Pointer(uuid)),
+	)
+	status = uint32(r1)
+	if status != RPC_S_OK && status != RPC_S_UUID_LOCAL_ONLY {
+		err = syscall.Errno(r1)
+	}
+	return
+}
+
+/*
+UuidCreateSequential
+创建新的 UUID（RFC 4122 版本 1），基于网卡地址和时间，同一台计算机上生成的值按时间递增
+
+RPC_STATUS UuidCreateSequential(
+
+	UUID *Uuid
+	);
+
+返回值
+RPC_S_OK: 调用成功。
+RPC_S_UUID_LOCAL_ONLY: 计算机没有网卡地址，UUID 只保证在本机唯一。
+RPC_S_UUID_NO_ADDRESS: 无法获取网卡地址。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/rpcdce/nf-rpcdce-uuidcreatesequential
+*/
+func UuidCreateSequential(uuid *GUID) (status uint32, err error) {
+	r1, _, _ := syscall.SyscallN(
+		procUuidCreateSequential.Addr(),
+		uintptr(unsafe.Pointer(uuid)),
+	)
+	status = uint32(r1)
+	if status != RPC_S_OK && status != RPC_S_UUID_LOCAL_ONLY {
+		err = syscall.Errno(r1)
+	}
+	return
+}
+
+/*
+UuidToStringW
+将 UUID 转换为字符串，字符串由 RPC 运行时分配，使用完毕后调用 RpcStringFreeW 释放
+
+RPC_STATUS UuidToStringW(
+
+	const UUID  *Uuid,
+	RPC_WSTR    *StringUuid
+	);
+
+返回值
+RPC_S_OK: 调用成功。
+RPC_S_OUT_OF_MEMORY: 内存不足。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/rpcdce/nf-rpcdce-uuidtostringw
+*/
+func UuidToStringW(uuid *GUID, stringUuid **uint16) (err error) {
+	r1, _, _ := syscall.SyscallN(
+		procUuidToStringW.Addr(),
+		uintptr(unsafe.Pointer(uuid)),
+		uintptr(unsafe.Pointer(stringUuid)), // 接收以 null 结尾的字符串
+	)
+	if r1 != RPC_S_OK {
+		err = syscall.Errno(r1)
+	}
+	return
+}
+
+/*
+RpcStringFreeW
+释放 RPC 运行时分配的字符串，并将指针设置为 NULL
+
+RPC_STATUS RpcStringFreeW(
+
+	RPC_WSTR *String
+	);
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/rpcdce/nf-rpcdce-rpcstringfreew
+*/
+func RpcStringFreeW(str **uint16) (err error) {
+	r1, _, _ := syscall.SyscallN(
+		procRpcStringFreeW.Addr(),
+		uintptr(unsafe.Pointer(str)),
+	)
+	if r1 != RPC_S_OK {
+		err = syscall.Errno(r1)
+	}
+	return
+}
+
+// UuidToString 是 UuidToStringW 的类型化版本，返回小写、不带花括号的字符串
+func UuidToString(uuid GUID) (string, error) {
+	var str *uint16
+	if err := UuidToStringW(&uuid, &str); err != nil {
+		return "", err
+	}
+	defer RpcStringFreeW(&str)
+	return windows.UTF16PtrToString(str), nil
+}
+
+/*
+UuidCompare
+比较两个 UUID，NULL 视为 nil UUID
+
+signed int UuidCompare(
+
+	UUID       *Uuid1,
+	UUID       *Uuid2,
+	RPC_STATUS *Status
+	);
+
+返回值
+-1: Uuid1 小于 Uuid2。
+0: Uuid1 等于 Uuid2。
+1: Uuid1 大于 Uuid2。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/rpcdce/nf-rpcdce-uuidcompare
+*/
+func UuidCompare(uuid1, uuid2 *GUID) (value int, err error) {
+	var status uint32
+	r1, _, _ := syscall.SyscallN(
+		procUuidCompare.Addr(),
+		uintptr(unsafe.Pointer(uuid1)),
+		uintptr(unsafe.Pointer(uuid2)),
+		uintptr(unsafe.Pointer(&status)),
+	)
+	value = int(int32(r1))
+	if status != RPC_S_OK {
+		err = syscall.Errno(status)
+	}
+	return
+}
@@ -1,6 +1,11 @@
 package xwindows
 
-import "syscall"
+import (
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
 
 /*
 TimeGetTime
@@ -21,3 +26,479 @@ func TimeGetTime() (value uintptr, err error) {
 	}
 	return
 }
+
+/*
+TimeBeginPeriod
+timeBeginPeriod 函数为定期计时器请求最低分辨率，每次调用必须与使用相同值的 timeEndPeriod 调用配对
+
+MMRESULT timeBeginPeriod(
+
+	UINT uPeriod
+	);
+
+返回值
+如果成功，则返回 TIMERR_NOERROR；如果 uPeriod 超出范围，则返回 TIMERR_NOCANDO。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/timeapi/nf-timeapi-timebeginperiod
+*/
+func TimeBeginPeriod(uPeriod uint32) (err error) {
+	r1, _, _ := syscall.SyscallN(
+		procTimeBeginPeriod.Addr(),
+		uintptr(uPeriod), // 最低计时器分辨率（以毫秒为单位）
+	)
+	if r1 != MMSYSERR_NOERROR {
+		err = MMError(r1)
+	}
+	return
+}
+
+/*
+TimeEndPeriod
+timeEndPeriod 函数清除先前设置的最低计时器分辨率
+
+MMRESULT timeEndPeriod(
+
+	UINT uPeriod
+	);
+
+返回值
+如果成功，则返回 TIMERR_NOERROR；如果 uPeriod 超出范围，则返回 TIMERR_NOCANDO。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/timeapi/nf-timeapi-timeendperiod
+*/
+func TimeEndPeriod(uPeriod uint32) (err error) {
+	r1, _, _ := syscall.SyscallN(
+		procTimeEndPeriod.Addr(),
+		uintptr(uPeriod), // 与 timeBeginPeriod 调用中指定的值相同
+	)
+	if r1 != MMSYSERR_NOERROR {
+		err = MMError(r1)
+	}
+	return
+}
+
+/*
+TimeGetDevCaps
+timeGetDevCaps 函数查询计时器设备以确定其分辨率
+
+MMRESULT timeGetDevCaps(
+
+	LPTIMECAPS ptc,
+	UINT       cbtc
+	);
+
+返回值
+如果成功，则返回 MMSYSERR_NOERROR；如果 ptc 为 NULL 或 cbtc 无效，则返回 MMSYSERR_ERROR 或 TIMERR_NOCANDO。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/timeapi/nf-timeapi-timegetdevcaps
+*/
+func TimeGetDevCaps(ptc *TIMECAPS, cbtc uint32) (err error) {
+	r1, _, _ := syscall.SyscallN(
+		procTimeGetDevCaps.Addr(),
+		uintptr(unsafe.Pointer(ptc)), // 接收计时器分辨率的 TIMECAPS 结构
+		uintptr(cbtc),                // TIMECAPS 结构的大小（以字节为单位）
+	)
+	if r1 != MMSYSERR_NOERROR {
+		err = MMError(r1)
+	}
+	return
+}
+
+/*
+TimeSetEvent
+timeSetEvent 函数启动指定的计时器事件，多媒体计时器在其自己的线程中运行
+
+MMRESULT timeSetEvent(
+
+	UINT           uDelay,
+	UINT           uResolution,
+	LPTIMECALLBACK lpTimeProc,
+	DWORD_PTR      dwUser,
+	UINT           fuEvent
+	);
+
+返回值
+如果成功，则返回计时器事件的标识符；否则返回 NULL。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/mmsystem/nf-mmsystem-timesetevent
+*/
+func TimeSetEvent(uDelay, uResolution uint32, lpTimeProc uintptr, dwUser uintptr, fuEvent uint32) (id uint32, err error) {
+	r0, _, _ := syscall.SyscallN(
+		procTimeSetEvent.Addr(),
+		uintptr(uDelay),      // 事件延迟（以毫秒为单位），超出计时器支持的范围时失败
+		uintptr(uResolution), // 计时器事件的分辨率（以毫秒为单位），0 表示尽可能高
+		lpTimeProc,           // 回调函数，或 TIME_CALLBACK_EVENT_SET 时的事件句柄
+		dwUser,               // 传给回调函数的数据
+		uintptr(fuEvent),     // TIME_ONESHOT、TIME_PERIODIC 与 TIME_CALLBACK_* 的组合
+	)
+	id = uint32(r0)
+	if id == 0 {
+		err = MMError(TIMERR_NOCANDO)
+	}
+	return
+}
+
+/*
+TimeKillEvent
+timeKillEvent 函数取消指定的计时器事件
+
+MMRESULT timeKillEvent(
+
+	UINT uTimerID
+	);
+
+返回值
+如果成功，则返回 TIMERR_NOERROR；如果指定的计时器事件不存在，则返回 MMSYSERR_INVALPARAM。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/mmsystem/nf-mmsystem-timekillevent
+*/
+func TimeKillEvent(uTimerID uint32) (err error) {
+	r1, _, _ := syscall.SyscallN(
+		procTimeKillEvent.Addr(),
+		uintptr(uTimerID), // timeSetEvent 返回的计时器事件标识符
+	)
+	if r1 != MMSYSERR_NOERROR {
+		err = MMError(r1)
+	}
+	return
+}
+
+/*
+WaveOutGetNumDevs
+waveOutGetNumDevs 函数检索系统中存在的波形输出设备的数量
+
+UINT waveOutGetNumDevs();
+
+返回值
+返回设备数。返回值为零表示不存在任何设备或发生了错误。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/mmeapi/nf-mmeapi-waveoutgetnumdevs
+*/
+func WaveOutGetNumDevs() (value uint32) {
+	r1, _, _ := syscall.SyscallN(procWaveOutGetNumDevs.Addr())
+	value = uint32(r1)
+	return
+}
+
+/*
+WaveOutGetDevCapsW
+waveOutGetDevCaps 函数检索给定波形输出设备的功能
+
+MMRESULT waveOutGetDevCapsW(
+
+	UINT_PTR       uDeviceID,
+	LPWAVEOUTCAPSW pwoc,
+	UINT           cbwoc
+	);
+
+返回值
+如果成功，则返回 MMSYSERR_NOERROR；否则返回 MMSYSERR_BADDEVICEID、MMSYSERR_NODRIVER 或 MMSYSERR_NOMEM。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/mmeapi/nf-mmeapi-waveoutgetdevcapsw
+*/
+func WaveOutGetDevCapsW(uDeviceID uintptr, pwoc *WAVEOUTCAPSW, cbwoc uint32) (err error) {
+	r1, _, _ := syscall.SyscallN(
+		procWaveOutGetDevCapsW.Addr(),
+		uDeviceID,                     // 0 到设备数减 1 的设备标识符、WAVE_MAPPER 或已打开的设备句柄
+		uintptr(unsafe.Pointer(pwoc)), // 接收设备功能的 WAVEOUTCAPSW 结构
+		uintptr(cbwoc),                // WAVEOUTCAPSW 结构的大小（以字节为单位）
+	)
+	if r1 != MMSYSERR_NOERROR {
+		err = MMError(r1)
+	}
+	return
+}
+
+/*
+WaveOutOpen
+waveOutOpen 函数打开给定的波形输出设备进行播放
+
+MMRESULT waveOutOpen(
+
+	LPHWAVEOUT     phwo,
+	UINT           uDeviceID,
+	LPCWAVEFORMATEX pwfx,
+	DWORD_PTR      dwCallback,
+	DWORD_PTR      dwInstance,
+	DWORD          fdwOpen
+	);
+
+返回值
+如果成功，则返回 MMSYSERR_NOERROR；格式不受支持时返回 WAVERR_BADFORMAT。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/mmeapi/nf-mmeapi-waveoutopen
+*/
+func WaveOutOpen(phwo *windows.Handle, uDeviceID uint32, pwfx *WAVEFORMATEX, dwCallback uintptr, dwInstance uintptr, fdwOpen uint32) (err error) {
+	r1, _, _ := syscall.SyscallN(
+		procWaveOutOpen.Addr(),
+		uintptr(unsafe.Pointer(phwo)), // 接收设备句柄，fdwOpen 含 WAVE_FORMAT_QUERY 时可以为 NULL
+		uintptr(uDeviceID),            // 设备标识符或 WAVE_MAPPER
+		uintptr(unsafe.Pointer(pwfx)), // 波形数据格式
+		dwCallback,                    // 回调函数、事件句柄、窗口句柄或线程标识符，由 fdwOpen 指定
+		dwInstance,                    // 传给回调函数的数据
+		uintptr(fdwOpen),              // CALLBACK_* 与 WAVE_* 标志
+	)
+	if r1 != MMSYSERR_NOERROR {
+		err = MMError(r1)
+	}
+	return
+}
+
+/*
+WaveOutClose
+waveOutClose 函数关闭给定的波形输出设备，仍有缓冲区排队时失败
+
+MMRESULT waveOutClose(
+
+	HWAVEOUT hwo
+	);
+
+返回值
+如果成功，则返回 MMSYSERR_NOERROR；仍有缓冲区排队时返回 WAVERR_STILLPLAYING。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/mmeapi/nf-mmeapi-waveoutclose
+*/
+func WaveOutClose(hwo windows.Handle) (err error) {
+	r1, _, _ := syscall.SyscallN(procWaveOutClose.Addr(), uintptr(hwo))
+	if r1 != MMSYSERR_NOERROR {
+		err = MMError(r1)
+	}
+	return
+}
+
+/*
+WaveOutPrepareHeader
+waveOutPrepareHeader 函数准备用于播放的波形数据块
+
+MMRESULT waveOutPrepareHeader(
+
+	HWAVEOUT  hwo,
+	LPWAVEHDR pwh,
+	UINT      cbwh
+	);
+
+返回值
+如果成功，则返回 MMSYSERR_NOERROR。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/mmeapi/nf-mmeapi-waveoutprepareheader
+*/
+func WaveOutPrepareHeader(hwo windows.Handle, pwh *WAVEHDR, cbwh uint32) (err error) {
+	r1, _, _ := syscall.SyscallN(
+		procWaveOutPrepareHeader.Addr(),
+		uintptr(hwo),
+		uintptr(unsafe.Pointer(pwh)), // Data、BufferLength 和 Flags 必须已设置，Flags 必须为 0
+		uintptr(cbwh),
+	)
+	if r1 != MMSYSERR_NOERROR {
+		err = MMError(r1)
+	}
+	return
+}
+
+/*
+WaveOutUnprepareHeader
+waveOutUnprepareHeader 函数清除 waveOutPrepareHeader 执行的准备，必须在驱动程序用完缓冲区后调用
+
+MMRESULT waveOutUnprepareHeader(
+
+	HWAVEOUT  hwo,
+	LPWAVEHDR pwh,
+	UINT      cbwh
+	);
+
+返回值
+如果成功，则返回 MMSYSERR_NOERROR；缓冲区仍在队列中时返回 WAVERR_STILLPLAYING。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/mmeapi/nf-mmeapi-waveoutunprepareheader
+*/
+func WaveOutUnprepareHeader(hwo windows.Handle, pwh *WAVEHDR, cbwh uint32) (err error) {
+	r1, _, _ := syscall.SyscallN(
+		procWaveOutUnprepareHeader.Addr(),
+		uintptr(hwo),
+		uintptr(unsafe.Pointer(pwh)),
+		uintptr(cbwh),
+	)
+	if r1 != MMSYSERR_NOERROR {
+		err = MMError(r1)
+	}
+	return
+}
+
+/*
+WaveOutWrite
+waveOutWrite 函数将数据块发送到给定的波形输出设备，播放完毕后驱动程序设置 WHDR_DONE 并通知回调
+
+MMRESULT waveOutWrite(
+
+	HWAVEOUT  hwo,
+	LPWAVEHDR pwh,
+	UINT      cbwh
+	);
+
+返回值
+如果成功，则返回 MMSYSERR_NOERROR；数据块未准备时返回 WAVERR_UNPREPARED。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/mmeapi/nf-mmeapi-waveoutwrite
+*/
+func WaveOutWrite(hwo windows.Handle, pwh *WAVEHDR, cbwh uint32) (err error) {
+	r1, _, _ := syscall.SyscallN(
+		procWaveOutWrite.Addr(),
+		uintptr(hwo),
+		uintptr(unsafe.Pointer(pwh)), // 已准备的 WAVEHDR
+		uintptr(cbwh),
+	)
+	if r1 != MMSYSERR_NOERROR {
+		err = MMError(r1)
+	}
+	return
+}
+
+/*
+WaveOutPause
+waveOutPause 函数暂停在给定输出设备上的播放，当前位置被保存
+
+MMRESULT waveOutPause(
+
+	HWAVEOUT hwo
+	);
+
+返回值
+如果成功，则返回 MMSYSERR_NOERROR。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/mmeapi/nf-mmeapi-waveoutpause
+*/
+func WaveOutPause(hwo windows.Handle) (err error) {
+	r1, _, _ := syscall.SyscallN(procWaveOutPause.Addr(), uintptr(hwo))
+	if r1 != MMSYSERR_NOERROR {
+		err = MMError(r1)
+	}
+	return
+}
+
+/*
+WaveOutRestart
+waveOutRestart 函数恢复已暂停的波形输出设备上的播放
+
+MMRESULT waveOutRestart(
+
+	HWAVEOUT hwo
+	);
+
+返回值
+如果成功，则返回 MMSYSERR_NOERROR。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/mmeapi/nf-mmeapi-waveoutrestart
+*/
+func WaveOutRestart(hwo windows.Handle) (err error) {
+	r1, _, _ := syscall.SyscallN(procWaveOutRestart.Addr(), uintptr(hwo))
+	if r1 != MMSYSERR_NOERROR {
+		err = MMError(r1)
+	}
+	return
+}
+
+/*
+WaveOutReset
+waveOutReset 函数停止给定波形输出设备上的播放并将当前位置重置为零，所有挂起的缓冲区被标记为完成并返回
+
+MMRESULT waveOutReset(
+
+	HWAVEOUT hwo
+	);
+
+返回值
+如果成功，则返回 MMSYSERR_NOERROR。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/mmeapi/nf-mmeapi-waveoutreset
+*/
+func WaveOutReset(hwo windows.Handle) (err error) {
+	r1, _, _ := syscall.SyscallN(procWaveOutReset.Addr(), uintptr(hwo))
+	if r1 != MMSYSERR_NOERROR {
+		err = MMError(r1)
+	}
+	return
+}
+
+/*
+WaveOutGetVolume
+waveOutGetVolume 函数检索波形输出设备的当前音量级别
+
+MMRESULT waveOutGetVolume(
+
+	HWAVEOUT hwo,
+	LPDWORD  pdwVolume
+	);
+
+返回值
+如果成功，则返回 MMSYSERR_NOERROR；设备不支持音量控制时返回 MMSYSERR_NOTSUPPORTED。
+低位字是左声道音量，高位字是右声道音量，0xFFFF 为最大音量。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/mmeapi/nf-mmeapi-waveoutgetvolume
+*/
+func WaveOutGetVolume(hwo windows.Handle, pdwVolume *uint32) (err error) {
+	r1, _, _ := syscall.SyscallN(
+		procWaveOutGetVolume.Addr(),
+		uintptr(hwo),
+		uintptr(unsafe.Pointer(pdwVolume)),
+	)
+	if r1 != MMSYSERR_NOERROR {
+		err = MMError(r1)
+	}
+	return
+}
+
+/*
+WaveOutSetVolume
+waveOutSetVolume 函数设置波形输出设备的音量级别
+
+MMRESULT waveOutSetVolume(
+
+	HWAVEOUT hwo,
+	DWORD    dwVolume
+	);
+
+返回值
+如果成功，则返回 MMSYSERR_NOERROR；设备不支持音量控制时返回 MMSYSERR_NOTSUPPORTED。
+
+Link: https://learn.microsoft.com/zh-cn/windows/win32/api/mmeapi/nf-mmeapi-waveoutsetvolume
+*/
+func WaveOutSetVolume(hwo windows.Handle, dwVolume uint32) (err error) {
+	r1, _, _ := syscall.SyscallN(
+		procWaveOutSetVolume.Addr(),
+		uintptr(hwo),
+		uintptr(dwVolume), // 低位字是左声道音量，高位字是右声道音量
+	)
+	if r1 != MMSYSERR_NOERROR {
+		err = MMError(r1)
+	}
+	return
+}
+
+/*
+PlaySoundW
+PlaySound 函数播放由文件名、资源或系统事件指定的声音
+
+BOOL PlaySoundW(
+
+	LPCWSTR pszSound,
+	HMODULE hmod,
+	DWORD   fdwSound
+	);
+
+返回值
+如果成功，则返回 TRUE，否则返回 FALSE。该函数不设置扩展错误信息。
+
+Link: https://learn.microsoft.com/zh-cn/previous-versions/dd743680(v=vs.85)
+*/
+func PlaySoundW(pszSound *uint16, hmod windows.Handle, fdwSound uint32) (err error) {
+	r1, _, _ := syscall.SyscallN(
+		procPlaySoundW.Addr(),
+		uintptr(unsafe.Pointer(pszSound)), // 文件名、资源名或别名，为 NULL 时停止正在播放的声音
+		uintptr(hmod),                     // SND_RESOURCE 时为包含资源的模块，否则为 NULL
+		uintptr(fdwSound),                 // SND_* 标志
+	)
+	if r1 == 0 {
+		err = MMError(MMSYSERR_ERROR)
+	}
+	return
+}